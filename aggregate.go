@@ -0,0 +1,66 @@
+package art
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Aggregate builds a consensus model from models trained on different clients, for federated learning:
+// the categories of every client are matched to the consensus categories built from the previous clients,
+// each one to the consensus category it overlaps the most, by at least threshold (see MergeOverlapping),
+// and matched prototypes are averaged weighted by their sample counts, as in federated averaging.
+// A consensus category is matched by at most one category of every client, the categories without a match are added.
+// The consensus model has the hyperparameters of the first model and the given options,
+// all the models must have the same input length and are not modified.
+func Aggregate(models []*FuzzyART, threshold float64, opts ...Option) (*FuzzyART, error) {
+	if len(models) == 0 {
+		return nil, errors.New("at least one model must be aggregated")
+	}
+	if threshold <= 0 || threshold > 1 {
+		return nil, fmt.Errorf("overlap threshold must be between 0 (excluded) and 1, got %f", threshold)
+	}
+	first := models[0]
+	for i, m := range models {
+		if m.M != first.M {
+			return nil, fmt.Errorf("model %d must have %d features, got %d", i, first.M, m.M)
+		}
+	}
+	f, err := NewFuzzyART(first.M, first.rho, first.alpha, first.beta, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range models {
+		// the consensus categories built from the previous clients, and whether a category of m matched them
+		n := len(f.w)
+		matched := make([]bool, n)
+		for j, w := range m.w {
+			a, best := -1, threshold
+			for k := range n {
+				if o := overlap(f.w[k], w); !matched[k] && o >= best {
+					a, best = k, o
+				}
+			}
+			b := f.appendCategoryOf(m, j)
+			if a != -1 {
+				matched[a] = true
+				f.averageInto(a, b)
+				// errors of the metadata store are returned by the next metadata operation
+				f.compactMetadata(func(k int) bool { return k != b })
+			}
+		}
+	}
+	return f, nil
+}
+
+// averageInto merges category b into category a averaging their weights, weighted by their counts.
+func (f *FuzzyARTOf[T]) averageInto(a, b int) {
+	f.unshare(a)
+	wa, wb := f.w[a], f.w[b]
+	na, nb := float64(max(f.categories[a].count, 1)), float64(max(f.categories[b].count, 1))
+	for i := range wa {
+		wa[i] = T((na*float64(wa[i]) + nb*float64(wb[i])) / (na + nb))
+	}
+	f.categories[a].wNorm = float64(f.kernels().SumFloat64(wa))
+	f.mergeState(a, b)
+}
@@ -0,0 +1,50 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	client := func(points ...[]float64) *FuzzyART {
+		model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, a := range points {
+			model.Fit(a)
+		}
+		return model
+	}
+	// both clients learned a category around 0.2, only the second one around 0.8
+	a := client([]float64{0.2, 0.2, 0.2, 0.2}, []float64{0.28, 0.28, 0.28, 0.28}, []float64{0.22, 0.22, 0.22, 0.22})
+	defer a.Close()
+	b := client([]float64{0.2, 0.2, 0.2, 0.2}, []float64{0.25, 0.25, 0.25, 0.25}, []float64{0.8, 0.8, 0.8, 0.8})
+	defer b.Close()
+
+	consensus, err := Aggregate([]*FuzzyART{a, b}, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer consensus.Close()
+	if len(consensus.w) != 2 || consensus.Count(0) != 5 || consensus.Count(1) != 1 {
+		t.Fatalf("expected the 0.2 categories averaged and the 0.8 one added, got %d categories", len(consensus.w))
+	}
+	// count-weighted average of [0.2, 0.28] (3 samples) and [0.2, 0.25] (2 samples)
+	want := []float64{0.2, 0.2, 0.2, 0.2, 0.732, 0.732, 0.732, 0.732}
+	for i, v := range consensus.w[0] {
+		if math.Abs(v-want[i]) > 1e-12 {
+			t.Fatalf("expected the weights %v, got %v", want, consensus.w[0])
+		}
+	}
+	if _, j := consensus.Predict([]float64{0.8, 0.8, 0.8, 0.8}, false); j != 1 {
+		t.Errorf("expected the 0.8 category to match, got %d", j)
+	}
+	if consensus.rho != a.rho || len(a.w) != 1 || len(b.w) != 2 {
+		t.Error("expected the hyperparameters of the first model and the models unchanged")
+	}
+
+	if _, err := Aggregate(nil, 0.5); err == nil {
+		t.Error("expected an error without models")
+	}
+}
@@ -0,0 +1,305 @@
+package art
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ArchivedCategory is a category removed from the active model by ArchiveUnused.
+type ArchivedCategory struct {
+	// ID is the category ID in the model it was archived from, see CategoryID.
+	// It's 0 for the categories archived before IDs were recorded.
+	ID         uint64    `json:"id,omitempty"`
+	Weights    []float64 `json:"weights"`
+	Count      int       `json:"count"`
+	Label      string    `json:"label,omitempty"`
+	LastUsed   time.Time `json:"last_used"`
+	Created    time.Time `json:"created"`
+	ArchivedAt time.Time `json:"archived_at"`
+}
+
+// Archive stores the categories removed from the active model, so that long-tail knowledge
+// is retained while the active model stays small and fast.
+// The archive file holds a JSON object per line, new categories are appended to it.
+type Archive struct {
+	mu      sync.Mutex
+	path    string
+	entries []ArchivedCategory
+}
+
+// OpenArchive opens the archive file at path, creating it if it doesn't exist.
+func OpenArchive(path string) (*Archive, error) {
+	ar := &Archive{path: path}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ar, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var entry ArchivedCategory
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("archive %s line %d: %w", path, line, err)
+		}
+		ar.entries = append(ar.entries, entry)
+	}
+	return ar, scanner.Err()
+}
+
+// Len returns the number of archived categories.
+func (ar *Archive) Len() int {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	return len(ar.entries)
+}
+
+// Entry returns the archived category i.
+func (ar *Archive) Entry(i int) ArchivedCategory {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	return ar.entries[i]
+}
+
+// add appends the entries to the archive file.
+func (ar *Archive) add(entries []ArchivedCategory) error {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	file, err := os.OpenFile(ar.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	ar.entries = append(ar.entries, entries...)
+	return nil
+}
+
+// remove deletes entry i, rewriting the archive file.
+func (ar *Archive) remove(i int) (ArchivedCategory, error) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	entries := slices.Delete(slices.Clone(ar.entries), i, i+1)
+	tmp := ar.path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return ArchivedCategory{}, err
+	}
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err = enc.Encode(entry); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, ar.path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return ArchivedCategory{}, err
+	}
+
+	entry := ar.entries[i]
+	ar.entries = entries
+	return entry, nil
+}
+
+// Search returns the index and the resonance of the archived category matching the input best,
+// among those with resonance >= rho, or -1 if there is none.
+// The input is complement coded as in FuzzyART, RestoreMatching searches the input
+// encoded by the model instead when it uses WithEncoder.
+func (ar *Archive) Search(a []float64, rho float64) (index int, resonance float64) {
+	A := make([]float64, 2*len(a))
+	for i, v := range a {
+		A[i], A[i+len(a)] = v, 1-v
+	}
+	return ar.search(A, rho)
+}
+
+// search works like Search for the input A, already complement coded or encoded.
+func (ar *Archive) search(A []float64, rho float64) (index int, resonance float64) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	var aNorm float64
+	for _, v := range A {
+		aNorm += v
+	}
+	index = -1
+	for i, entry := range ar.entries {
+		if len(entry.Weights) != len(A) {
+			continue
+		}
+		var fiNorm float64
+		for k, v := range A {
+			fiNorm += min(v, entry.Weights[k])
+		}
+		if r := fiNorm / aNorm; r >= rho && r > resonance {
+			index, resonance = i, r
+		}
+	}
+	return index, resonance
+}
+
+// ArchiveUnused moves the categories that didn't learn any input for longer than olderThan to the archive.
+// It returns the index remapping of the remaining categories: old index -> new index, -1 for archived categories.
+func (f *FuzzyARTOf[T]) ArchiveUnused(ar *Archive, olderThan time.Duration) (remap []int, err error) {
+	now := time.Now()
+	var entries []ArchivedCategory
+	unused := make([]bool, len(f.w))
+	for j, c := range f.categories {
+		if now.Sub(c.lastUsed) > olderThan {
+			unused[j] = true
+			entries = append(entries, ArchivedCategory{
+				ID:         c.id,
+				Weights:    cloneFloat64s(f.w[j]),
+				Count:      c.count,
+				Label:      c.label,
+				LastUsed:   c.lastUsed,
+				Created:    c.created,
+				ArchivedAt: now,
+			})
+		}
+	}
+	if len(entries) == 0 {
+		return identityRemap(len(f.w)), nil
+	}
+
+	// the model is changed only once the categories are safely archived
+	if err := ar.add(entries); err != nil {
+		return nil, err
+	}
+	return f.compactMetadata(func(j int) bool { return !unused[j] })
+}
+
+func identityRemap(n int) []int {
+	remap := make([]int, n)
+	for j := range remap {
+		remap[j] = j
+	}
+	return remap
+}
+
+// RestoreMatching restores the archived category matching the input best,
+// if no active category passes the vigilance test for it.
+// It returns the index of the restored category, or -1 if none was restored.
+// The restored category keeps its ID, unless the model already has a category with that ID,
+// and it's inserted at the index of its ID, since IDs increase with the category index:
+// the following categories shift up by one.
+// With a category cap, CapEvictLRU and CapEvictSmallest evict a category to make room for the restored one,
+// the other strategies return an error when the cap is reached.
+func (f *FuzzyARTOf[T]) RestoreMatching(ar *Archive, a []float64) (categoryIndex int, err error) {
+	if len(f.w) > 0 {
+		if resonance, _ := f.bestResonance(a); resonance >= f.rho {
+			return -1, nil
+		}
+	}
+
+	atCap := f.maxCategories > 0 && len(f.w) >= f.maxCategories
+	evicting := f.capStrategy == CapEvictLRU || f.capStrategy == CapEvictSmallest
+	if atCap && !evicting {
+		return -1, fmt.Errorf("category cap of %d reached", f.maxCategories)
+	}
+
+	i, _ := ar.search(float64s(f.complementCode(a)), f.rho)
+	if i == -1 {
+		return -1, nil
+	}
+	entry, err := ar.remove(i)
+	if err != nil {
+		return -1, err
+	}
+
+	if atCap {
+		f.evict()
+	}
+	lastID := f.lastID
+	categoryIndex = f.appendNewCategory(valuesOf[T](entry.Weights))
+	c := &f.categories[categoryIndex]
+	c.count = entry.Count
+	c.label = entry.Label
+	if !entry.Created.IsZero() {
+		c.created = entry.Created
+	}
+	// the position of the ID among the other categories
+	k, exists := slices.BinarySearchFunc(f.categories[:categoryIndex], entry.ID, compareID)
+	if entry.ID == 0 || exists {
+		return categoryIndex, nil
+	}
+	c.id, f.lastID = entry.ID, max(lastID, entry.ID)
+	f.moveLast(k)
+	return k, nil
+}
+
+// moveLast moves the last category to index k, the following categories shift up by one.
+func (f *FuzzyARTOf[T]) moveLast(k int) {
+	n := len(f.w) - 1
+	if k == n {
+		return
+	}
+	f.compactions++
+	if f.mmap != nil {
+		// rows alias the mapping in order, move the weights instead
+		last := slices.Clone(f.w[n])
+		for j := n; j > k; j-- {
+			copy(f.w[j], f.w[j-1])
+		}
+		copy(f.w[k], last)
+	} else {
+		w := f.w[n]
+		copy(f.w[k+1:], f.w[k:n])
+		f.w[k] = w
+	}
+	c := f.categories[n]
+	copy(f.categories[k+1:], f.categories[k:n])
+	f.categories[k] = c
+	f.coarseDirty = true
+}
+
+// bestResonance returns the highest resonance among the active categories and its category index.
+func (f *FuzzyARTOf[T]) bestResonance(a []float64) (resonance float64, categoryIndex int) {
+	A := f.complementCode(a)
+	f.activateCategories(A)
+	aNorm := f.inputNorm(A)
+	categoryIndex = -1
+	for _, t := range f.t {
+		if r := f.normalizedActivation(t.fiNorm, aNorm); r > resonance || categoryIndex == -1 {
+			resonance, categoryIndex = r, t.j
+		}
+	}
+	return resonance, categoryIndex
+}
@@ -0,0 +1,172 @@
+package art
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.jsonl")
+	ar, err := OpenArchive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	old := []float64{0.1, 0.1, 0.1, 0.1}
+	model.Fit(old)
+	model.Fit([]float64{0.9, 0.9, 0.9, 0.9})
+	model.categories[0].lastUsed = time.Now().Add(-time.Hour)
+
+	remap, err := model.ArchiveUnused(ar, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remap[0] != -1 || remap[1] != 0 || len(model.w) != 1 {
+		t.Fatalf("unexpected remap %v with %d categories", remap, len(model.w))
+	}
+
+	// the archive survives reopening
+	if ar, err = OpenArchive(path); err != nil {
+		t.Fatal(err)
+	}
+	if ar.Len() != 1 {
+		t.Fatalf("expected 1 archived category, got %d", ar.Len())
+	}
+
+	if j, err := model.RestoreMatching(ar, []float64{0.9, 0.9, 0.9, 0.9}); err != nil || j != -1 {
+		t.Errorf("inputs matching active categories should not restore, got %d (%v)", j, err)
+	}
+	j, err := model.RestoreMatching(ar, old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the restored category keeps ID 1, before the category with ID 2
+	if j != 0 || ar.Len() != 0 {
+		t.Errorf("expected category restored at 0 and an empty archive, got %d and %d", j, ar.Len())
+	}
+	if id := model.CategoryID(0); id != 1 {
+		t.Errorf("expected the restored category to keep ID 1, got %d", id)
+	}
+	if k, ok := model.CategoryIndex(2); !ok || k != 1 {
+		t.Errorf("expected ID 2 shifted to index 1, got %d (%t)", k, ok)
+	}
+	if _, k := model.Predict(old, false); k != 0 {
+		t.Errorf("restored category should match, got %d", k)
+	}
+	if _, id := model.FitID([]float64{0.5, 0.5, 0.5, 0.5}); id != 3 {
+		t.Errorf("expected a new category ID 3, got %d", id)
+	}
+}
+
+func TestRestoreMatchingCap(t *testing.T) {
+	old, other := []float64{0.1, 0.1, 0.1, 0.1}, []float64{0.9, 0.9, 0.9, 0.9}
+	tests := []struct {
+		name     string
+		strategy CapStrategy
+		// wantIndex is -1 when the restore fails
+		wantIndex int
+	}{
+		{"adapt vigilance", CapAdaptVigilance, -1},
+		{"merge", CapMerge, -1},
+		{"evict LRU", CapEvictLRU, 0},
+		{"evict smallest", CapEvictSmallest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ar, err := OpenArchive(filepath.Join(t.TempDir(), "archive.jsonl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithMaxCategories(1, tt.strategy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer model.Close()
+
+			model.Fit(old)
+			model.categories[0].lastUsed = time.Now().Add(-time.Hour)
+			if _, err := model.ArchiveUnused(ar, time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			model.Fit(other)
+
+			j, err := model.RestoreMatching(ar, old)
+			if tt.wantIndex == -1 {
+				if err == nil || ar.Len() != 1 {
+					t.Errorf("expected an error keeping the category archived, got %v with %d archived", err, ar.Len())
+				}
+			} else if err != nil || j != tt.wantIndex {
+				t.Errorf("expected the category restored at %d, got %d (%v)", tt.wantIndex, j, err)
+			}
+			if len(model.w) != 1 {
+				t.Errorf("expected the cap of 1 category, got %d", len(model.w))
+			}
+		})
+	}
+}
+
+func TestRestoreMatchingDuplicateID(t *testing.T) {
+	ar, err := OpenArchive(filepath.Join(t.TempDir(), "archive.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := []float64{0.1, 0.1, 0.1, 0.1}
+	source, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer source.Close()
+	source.Fit(old)
+	source.categories[0].lastUsed = time.Now().Add(-time.Hour)
+	if _, err := source.ArchiveUnused(ar, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	// another model already has a category with the archived ID 1
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.9, 0.9, 0.9, 0.9})
+	j, err := model.RestoreMatching(ar, old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j != 1 || model.CategoryID(1) != 2 {
+		t.Errorf("expected the category restored at 1 with a new ID 2, got %d with ID %d", j, model.CategoryID(j))
+	}
+}
+
+func TestRestoreMatchingEncoder(t *testing.T) {
+	ar, err := OpenArchive(filepath.Join(t.TempDir(), "archive.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the encoder reverses the complement coding, the archive must search with it
+	reversed := EncoderFunc(func(a []float64) []float64 {
+		return []float64{1 - a[0], 1 - a[1], a[0], a[1]}
+	})
+	model, err := NewFuzzyART(2, 0.9, 0.01, 1, WithEncoder(reversed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	old := []float64{0.1, 0.2}
+	model.Fit(old)
+	model.categories[0].lastUsed = time.Now().Add(-time.Hour)
+	if _, err := model.ArchiveUnused(ar, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if j, err := model.RestoreMatching(ar, old); err != nil || j != 0 {
+		t.Errorf("expected the category restored at 0, got %d (%v)", j, err)
+	}
+}
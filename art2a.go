@@ -0,0 +1,172 @@
+package art
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/oblq/art/internal/simd"
+)
+
+// ART2A implements ART 2-A (Carpenter, Grossberg & Rosen, 1991) for continuous inputs.
+// Inputs are L2-normalized and contrast enhanced, so they don't need to be bounded to [0, 1]:
+// only the direction of the input vector matters, not its magnitude.
+// The activation of a category is the dot product between the input and its unit-length prototype,
+// the cosine similarity between the two.
+type ART2A struct {
+	// Vigilance parameter - minimum cosine similarity between an input and the resonating category
+	// Range: 0.0 to 1.0
+	// Adjustment:
+	// Increase rho to make the model more selective, creating more categories.
+	// Decrease rho to allow more generalization, creating fewer categories.
+	rho float64
+
+	// Choice parameter - activation of an uncommitted category, relative to the input L1 norm
+	// Range: 0.0 to 1/sqrt(M)
+	// Purpose: When the best category activation is lower than alpha * |I|, a new category is created.
+	alpha float64
+
+	// Learning rate - controls weight update speed
+	// Recommended value: 0.1 to 0.5 for noisy data, 1.0 for fast learning.
+	// Range: 0.0 to 1.0
+	beta float64
+
+	// Contrast enhancement threshold, normalized components with absolute value lower than theta are suppressed.
+	// Recommended value: 1/sqrt(M) or lower.
+	// Range: 0.0 to 1/sqrt(M)
+	theta float64
+
+	// M is the number of features of the input, its dimensionality.
+	M int
+
+	// W is the weight matrix - stores the unit-length category prototypes
+	W [][]float64
+}
+
+func NewART2A(inputLen int, rho, alpha, beta, theta float64) (*ART2A, error) {
+	if rho < 0 || rho > 1 {
+		return nil, fmt.Errorf("vigilance parameter (rho) must be between 0 and 1, got %f", rho)
+	}
+	maxAlpha := 1 / math.Sqrt(float64(inputLen))
+	if alpha < 0 || alpha > maxAlpha {
+		return nil, fmt.Errorf("choice parameter (alpha) must be between 0 and 1/sqrt(M) = %f, got %f", maxAlpha, alpha)
+	}
+	if beta <= 0 || beta > 1 {
+		return nil, fmt.Errorf("learning rate (beta) must be between 0 and 1, got %f", beta)
+	}
+	if theta < 0 || theta > maxAlpha {
+		return nil, fmt.Errorf("contrast enhancement threshold (theta) must be between 0 and 1/sqrt(M) = %f, got %f", maxAlpha, theta)
+	}
+
+	return &ART2A{
+		rho:   rho,
+		alpha: alpha,
+		beta:  beta,
+		theta: theta,
+		M:     inputLen,
+		W:     make([][]float64, 0),
+	}, nil
+}
+
+// normalize scales v in place to unit L2 norm, zero vectors are left untouched.
+func normalize(v []float64) []float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x * x
+	}
+	if sum == 0 {
+		return v
+	}
+	norm := math.Sqrt(sum)
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
+
+// preprocess normalizes the input, suppresses the components below theta and normalizes again.
+func (m *ART2A) preprocess(a []float64) []float64 {
+	I := normalize(append([]float64(nil), a...))
+	for i, x := range I {
+		if math.Abs(x) <= m.theta {
+			I[i] = 0
+		}
+	}
+	return normalize(I)
+}
+
+func dot(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// bestCategory returns the index and the activation of the most active category, -1 if there is none.
+// In case of equal activations older categories win.
+func (m *ART2A) bestCategory(I []float64) (categoryIndex int, activation float64) {
+	categoryIndex = -1
+	for j, w := range m.W {
+		if t := dot(I, w); categoryIndex == -1 || t > activation {
+			categoryIndex, activation = j, t
+		}
+	}
+	return categoryIndex, activation
+}
+
+// learn moves the prototype of category j toward the input,
+// only along the features the prototype didn't suppress.
+func (m *ART2A) learn(j int, I []float64) {
+	psi := make([]float64, len(I))
+	w := m.W[j]
+	for i := range I {
+		if math.Abs(w[i]) > m.theta {
+			psi[i] = I[i]
+		}
+	}
+	normalize(psi)
+	for i := range w {
+		w[i] = m.beta*psi[i] + (1-m.beta)*w[i]
+	}
+	normalize(w)
+}
+
+// resonateOrReset learns the input on the most active category if it beats an uncommitted one
+// and passes the vigilance test, otherwise it creates a new category.
+// Since the activation is also the match value there is no need to search other categories:
+// if the most active one fails the test all the others would too.
+func (m *ART2A) resonateOrReset(I []float64) (categoryActivation float64, categoryIndex int) {
+	j, t := m.bestCategory(I)
+	uncommitted := m.alpha * simd.Shared.SumFloat64(I)
+	if j != -1 && t >= uncommitted && t >= m.rho {
+		m.learn(j, I)
+		return t, j
+	}
+
+	m.W = append(m.W, I)
+	return t, len(m.W) - 1
+}
+
+// Fit implements the complete ART learning cycle.
+func (m *ART2A) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	return m.resonateOrReset(m.preprocess(a))
+}
+
+// Predict returns the activation (cosine similarity) and the index of the most active category,
+// or -1 if the model has no categories yet.
+// If learn is true, it also updates the matching category.
+func (m *ART2A) Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int) {
+	I := m.preprocess(a)
+	if learn {
+		return m.resonateOrReset(I)
+	}
+
+	j, t := m.bestCategory(I)
+	if j == -1 {
+		return 0, -1
+	}
+	return t, j
+}
+
+// Close is a no-op, it exists so that ART2A can be swapped with FuzzyART.
+func (m *ART2A) Close() {}
@@ -0,0 +1,103 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestART2A(t *testing.T) {
+	// cos(30°) = 0.866 and cos(60°) = 0.5 from the first input
+	deg30 := []float64{math.Cos(math.Pi / 6), math.Sin(math.Pi / 6)}
+	deg60 := []float64{math.Cos(math.Pi / 3), math.Sin(math.Pi / 3)}
+
+	tests := []struct {
+		name   string
+		rho    float64
+		inputs [][]float64
+		want   []int
+	}{
+		// only the direction matters, not the magnitude
+		{"scale invariance", 0.9, [][]float64{{1, 0}, {10, 0}, {0.5, 0.01}}, []int{0, 0, 0}},
+		{"opposite direction", 0.9, [][]float64{{1, 0}, {-1, 0}}, []int{0, 1}},
+		{"vigilance reset", 0.9, [][]float64{{1, 0}, deg30}, []int{0, 1}},
+		{"resonance", 0.8, [][]float64{{1, 0}, deg30}, []int{0, 0}},
+		{"low vigilance", 0.4, [][]float64{{1, 0}, deg60}, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		model, err := NewART2A(2, tt.rho, 0.1, 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, a := range tt.inputs {
+			if _, j := model.Fit(a); j != tt.want[i] {
+				t.Errorf("%s: input %d expected category %d, got %d", tt.name, i, tt.want[i], j)
+			}
+		}
+	}
+}
+
+func TestART2ALearning(t *testing.T) {
+	model, err := NewART2A(2, 0.5, 0.1, 0.5, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, j := model.Predict([]float64{1, 0}, false); j != -1 {
+		t.Errorf("empty model should return -1, got %d", j)
+	}
+
+	// the prototype moves halfway toward the input and keeps unit length
+	model.Fit([]float64{2, 1})
+	if _, j := model.Fit([]float64{1, 2}); j != 0 {
+		t.Fatalf("expected category 0, got %d", j)
+	}
+	if w := model.W[0]; math.Abs(w[0]-math.Sqrt2/2) > 1e-12 || math.Abs(w[1]-math.Sqrt2/2) > 1e-12 {
+		t.Errorf("expected the prototype at 45°, got %v", w)
+	}
+
+	// a new category returns the activation of the best one, at 135°
+	activation, j := model.Fit([]float64{0, -1})
+	if j != 1 {
+		t.Fatalf("expected a new category 1, got %d", j)
+	}
+	if math.Abs(activation+math.Sqrt2/2) > 1e-12 {
+		t.Errorf("expected the activation -0.707 of category 0, got %f", activation)
+	}
+	// the features suppressed in the prototype are not learned
+	model.Fit([]float64{1, -1})
+	if w := model.W[1]; w[0] != 0 || w[1] != -1 {
+		t.Errorf("expected the prototype [0 -1], got %v", w)
+	}
+
+	if _, j := model.Predict([]float64{3, 3}, false); j != 0 || len(model.W) != 2 {
+		t.Errorf("expected category 0 without new categories, got %d with %d categories", j, len(model.W))
+	}
+}
+
+func TestART2AContrastEnhancement(t *testing.T) {
+	model, err := NewART2A(2, 0.9, 0.1, 1, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the second component, 0.05 after the normalization, is suppressed
+	model.Fit([]float64{1, 0.05})
+	if w := model.W[0]; w[0] != 1 || w[1] != 0 {
+		t.Errorf("expected the prototype [1 0], got %v", w)
+	}
+}
+
+func TestNewART2AErrors(t *testing.T) {
+	tests := []struct {
+		name                    string
+		rho, alpha, beta, theta float64
+	}{
+		{"rho", 1.1, 0.1, 1, 0},
+		{"alpha", 0.9, 1, 1, 0},
+		{"beta", 0.9, 0.1, 0, 0},
+		{"theta", 0.9, 0.1, 1, 1},
+	}
+	for _, tt := range tests {
+		if _, err := NewART2A(2, tt.rho, tt.alpha, tt.beta, tt.theta); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package art
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/oblq/art/internal/npy"
+)
+
+// ArtlibParams holds the FuzzyART parameters as named by the Python artlib (AdaptiveResonanceLib) package.
+type ArtlibParams struct {
+	Rho   float64 `json:"rho"`
+	Alpha float64 `json:"alpha"`
+	Beta  float64 `json:"beta"`
+}
+
+// ExportArtlib writes the model in a layout compatible with artlib.FuzzyART:
+// the weights as a .npy array of complement-coded prototype rows (categories x 2M),
+// the same layout of artlib, and the parameters as a JSON object.
+// The model can be rebuilt in Python with:
+//
+//	params = json.load(open("params.json"))
+//	model = artlib.FuzzyART(**params)
+//	model.W = list(np.load("weights.npy"))
+//	model.dim_ = model.W[0].shape[0]
+//
+// Inputs must be normalized to [0, 1] and complement coded (model.prepare_data) on the Python side.
+func (f *FuzzyARTOf[T]) ExportArtlib(weights, params io.Writer) error {
+	data := make([]float64, 0, len(f.w)*2*f.M)
+	for _, w := range f.w {
+		data = append(data, float64s(w)...)
+	}
+	if err := npy.WriteFloat64(weights, data, len(f.w), 2*f.M); err != nil {
+		return err
+	}
+	return json.NewEncoder(params).Encode(ArtlibParams{Rho: f.rho, Alpha: f.alpha, Beta: f.beta})
+}
+
+// ImportArtlib rebuilds a model exported from artlib.FuzzyART,
+// e.g.: np.save("weights.npy", np.array(model.W)) and json.dump(model.params, open("params.json", "w")).
+// Unknown parameters are ignored.
+func ImportArtlib(weights, params io.Reader, opts ...Option) (*FuzzyART, error) {
+	var p ArtlibParams
+	if err := json.NewDecoder(params).Decode(&p); err != nil {
+		return nil, fmt.Errorf("reading artlib parameters: %w", err)
+	}
+	data, shape, err := npy.ReadFloat64(weights)
+	if err != nil {
+		return nil, fmt.Errorf("reading artlib weights: %w", err)
+	}
+	if len(shape) != 2 || shape[1]%2 != 0 {
+		return nil, fmt.Errorf("artlib weights must be a categories x 2M array, got shape %v", shape)
+	}
+
+	inputLen := shape[1] / 2
+	f, err := NewFuzzyART(inputLen, p.Rho, p.Alpha, p.Beta, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for j := range shape[0] {
+		w := make([]float64, 2*inputLen)
+		copy(w, data[j*2*inputLen:])
+		f.appendNewCategory(w)
+	}
+	return f, nil
+}
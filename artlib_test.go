@@ -0,0 +1,37 @@
+package art
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestArtlibRoundTrip(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, v := range []float64{0.1, 0.15, 0.9} {
+		model.Fit([]float64{v, v, v, v})
+	}
+
+	var weights, params bytes.Buffer
+	if err := model.ExportArtlib(&weights, &params); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ImportArtlib(&weights, &params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+
+	if loaded.M != 4 || loaded.rho != 0.8 || loaded.beta != 0.5 || len(loaded.w) != len(model.w) {
+		t.Fatalf("imported model differs")
+	}
+	for j := range model.w {
+		if !slices.Equal(loaded.w[j], model.w[j]) {
+			t.Errorf("category %d differs", j)
+		}
+	}
+}
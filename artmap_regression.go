@@ -0,0 +1,107 @@
+package art
+
+import "fmt"
+
+// ARTMAPRegressor implements Fuzzy ARTMAP (Carpenter et al., 1992) for function approximation.
+// ART-b clusters the continuous target values, ART-a clusters the inputs
+// and the map field links every ART-a category to an ART-b category.
+// As in SFAM, a wrong link triggers match tracking on ART-a.
+// Predictions interpolate the ART-b prototypes linked to the best matching ART-a categories,
+// weighted by their activations.
+type ARTMAPRegressor struct {
+	artA *FuzzyART
+	artB *FuzzyART
+
+	// Match tracking parameter - the vigilance increase after a wrong prediction
+	// Recommended value: 0.001
+	epsilon float64
+
+	// Number of ART-a categories interpolated by Predict
+	// Recommended value: 1 to 3, 1 gives the piecewise constant output of the original ARTMAP.
+	neighbors int
+
+	// mapField stores the ART-b category of every ART-a category
+	mapField []int
+}
+
+// NewARTMAPRegressor creates a regressor learning outputLen targets, in [0, 1], from inputLen features.
+// The vigilance of ART-b (rhoB) sets the resolution of the output values.
+func NewARTMAPRegressor(inputLen, outputLen int, rhoA, rhoB, alpha, beta, epsilon float64, neighbors int) (*ARTMAPRegressor, error) {
+	if epsilon <= -1 || epsilon >= 1 {
+		return nil, fmt.Errorf("match tracking parameter (epsilon) must be between -1 and 1, got %f", epsilon)
+	}
+	if neighbors < 1 {
+		return nil, fmt.Errorf("interpolated neighbors must be at least 1, got %d", neighbors)
+	}
+	artA, err := NewFuzzyART(inputLen, rhoA, alpha, beta)
+	if err != nil {
+		return nil, err
+	}
+	artB, err := NewFuzzyART(outputLen, rhoB, alpha, beta)
+	if err != nil {
+		artA.Close()
+		return nil, err
+	}
+
+	return &ARTMAPRegressor{
+		artA:      artA,
+		artB:      artB,
+		epsilon:   epsilon,
+		neighbors: neighbors,
+		mapField:  make([]int, 0),
+	}, nil
+}
+
+// Fit learns the input with its target values and returns the index of the learning ART-a category.
+func (r *ARTMAPRegressor) Fit(a, y []float64) (categoryIndex int) {
+	_, target := r.artB.Fit(y)
+
+	A := r.artA.learningInput(a)
+	r.artA.activateCategories(A)
+	_, categoryIndex, created := r.artA.trackMatch(A, r.epsilon, func(j int) bool {
+		return r.mapField[j] == target
+	})
+	if created {
+		r.mapField = append(r.mapField, target)
+	}
+	return categoryIndex
+}
+
+// Predict returns the interpolated target values for the input and the index of the best matching ART-a category,
+// or nil, -1 if the model has no categories yet.
+func (r *ARTMAPRegressor) Predict(a []float64) (y []float64, categoryIndex int) {
+	if len(r.mapField) == 0 {
+		return nil, -1
+	}
+
+	f := r.artA
+	f.activateCategories(f.complementCode(a))
+	y = make([]float64, r.artB.M)
+	var sum float64
+	for _, t := range f.t[:min(r.neighbors, len(f.t))] {
+		p := prototype(r.artB, r.mapField[t.j])
+		for i := range y {
+			y[i] += t.activation * p[i]
+		}
+		sum += t.activation
+	}
+
+	categoryIndex = f.t[0].j
+	if sum == 0 {
+		return prototype(r.artB, r.mapField[categoryIndex]), categoryIndex
+	}
+	for i := range y {
+		y[i] /= sum
+	}
+	return y, categoryIndex
+}
+
+// CategoryCount returns the number of ART-a and ART-b categories.
+func (r *ARTMAPRegressor) CategoryCount() (a, b int) {
+	return len(r.artA.w), len(r.artB.w)
+}
+
+func (r *ARTMAPRegressor) Close() {
+	r.artA.Close()
+	r.artB.Close()
+}
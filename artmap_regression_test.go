@@ -0,0 +1,37 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestARTMAPRegressor(t *testing.T) {
+	model, err := NewARTMAPRegressor(4, 4, 0.9, 0.95, 0.01, 1, 0.001, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	// y = x^2, inputs and targets are repeated to fill 4 features
+	fn := func(x float64) float64 { return x * x }
+	repeat := func(v float64) []float64 { return []float64{v, v, v, v} }
+	for range 3 {
+		for i := range 101 {
+			x := float64(i) / 100
+			model.Fit(repeat(x), repeat(fn(x)))
+		}
+	}
+
+	var maxErr float64
+	for i := range 20 {
+		x := (float64(i) + 0.5) / 20
+		y, j := model.Predict(repeat(x))
+		if j == -1 {
+			t.Fatal("expected a category")
+		}
+		maxErr = math.Max(maxErr, math.Abs(y[0]-fn(x)))
+	}
+	if maxErr > 0.1 {
+		t.Errorf("approximation error too high: %f", maxErr)
+	}
+}
@@ -0,0 +1,236 @@
+package art
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/oblq/art/internal/linalg"
+)
+
+type bayesianActivation struct {
+	// log of the unnormalized posterior, log(N(x|mu, sigma) * P(j))
+	logPosterior float64
+	// posterior probability of the category given the input
+	posterior float64
+	// index of the category
+	j int
+}
+
+// BayesianART implements Bayesian ART (Vigdor & Lerner, 2007).
+// Every category is a multivariate Gaussian with a prior proportional to
+// the number of samples it learned, the winning category is the one
+// with the highest posterior probability given the input.
+// Predict returns that posterior, a calibrated membership confidence,
+// instead of the resonance ratio returned by FuzzyART.
+type BayesianART struct {
+	// Vigilance parameter - maximum hypervolume (determinant of the covariance matrix) of a category
+	// Range: > 0.0
+	// Purpose: A category resonates only if, after learning the input, det(sigma) <= sMax.
+	// Adjustment:
+	// Decrease sMax to make the model more selective, creating more (smaller) categories.
+	// Increase sMax to allow more generalization, creating fewer (larger) categories.
+	// It's stored as log(sMax), the hypervolume underflows for high dimensional inputs.
+	logSMax float64
+
+	// Initial standard deviation of new categories, their covariance is sigma0^2 * I.
+	// It should be in the order of the expected spread of a category along each feature.
+	sigma0 float64
+
+	// M is the number of features of the input, its dimensionality.
+	M int
+
+	// W stores the category means.
+	W [][]float64
+
+	// Sigma stores the category covariance matrices, M x M in row-major order.
+	Sigma [][]float64
+
+	// N stores the number of samples learned by each category.
+	N []int
+
+	// chol caches the Cholesky decomposition of every covariance matrix
+	chol [][]float64
+	// logDet caches the log determinant of every covariance matrix
+	logDet []float64
+
+	// t is the activation list - stores category activations
+	t []*bayesianActivation
+
+	// scratch buffers
+	z, candidateSigma, candidateChol []float64
+}
+
+func NewBayesianART(inputLen int, sMax, sigma0 float64) (*BayesianART, error) {
+	if sMax <= 0 {
+		return nil, fmt.Errorf("maximum hypervolume (sMax) must be positive, got %f", sMax)
+	}
+	return newBayesianART(inputLen, math.Log(sMax), sigma0)
+}
+
+// newBayesianART works like NewBayesianART, but takes the log of the maximum hypervolume.
+func newBayesianART(inputLen int, logSMax, sigma0 float64) (*BayesianART, error) {
+	if sigma0 <= 0 {
+		return nil, fmt.Errorf("initial standard deviation (sigma0) must be positive, got %f", sigma0)
+	}
+
+	return &BayesianART{
+		logSMax:        logSMax,
+		sigma0:         sigma0,
+		M:              inputLen,
+		W:              make([][]float64, 0),
+		Sigma:          make([][]float64, 0),
+		N:              make([]int, 0),
+		t:              make([]*bayesianActivation, 0),
+		z:              make([]float64, inputLen),
+		candidateSigma: make([]float64, inputLen*inputLen),
+		candidateChol:  make([]float64, inputLen*inputLen),
+	}, nil
+}
+
+// minJitter is the smallest diagonal regularization added
+// to covariance matrices that collapsed to singular ones.
+const minJitter = 1e-9
+
+// activateCategories computes the posterior probability of every category
+// and sorts the activation list, the most probable category first.
+func (b *BayesianART) activateCategories(a []float64) {
+	var total int
+	for _, n := range b.N {
+		total += n
+	}
+
+	maxLog := math.Inf(-1)
+	for j, mu := range b.W {
+		t := b.t[j]
+		t.j = j
+		d2 := linalg.Mahalanobis(b.chol[j], b.M, a, mu, b.z)
+		logLikelihood := -0.5 * (float64(b.M)*math.Log(2*math.Pi) + b.logDet[j] + d2)
+		t.logPosterior = logLikelihood + math.Log(float64(b.N[j])/float64(total))
+		maxLog = math.Max(maxLog, t.logPosterior)
+	}
+
+	// log-sum-exp normalization
+	var sum float64
+	for _, t := range b.t {
+		sum += math.Exp(t.logPosterior - maxLog)
+	}
+	for _, t := range b.t {
+		t.posterior = math.Exp(t.logPosterior-maxLog) / sum
+	}
+
+	slices.SortStableFunc(b.t, func(a, b *bayesianActivation) int {
+		if a.logPosterior > b.logPosterior {
+			return -1
+		}
+		if a.logPosterior < b.logPosterior {
+			return 1
+		}
+		return 0
+	})
+}
+
+// candidateUpdate computes in b.candidateSigma the covariance of category j
+// after learning a, and returns the new mean.
+// The initial covariance sigma0^2 * I counts as one prior sample,
+// so that after n samples sigma = (sigma0^2 * I + scatter) / n:
+// the unbiased (n-1) estimate, regularized by the prior.
+func (b *BayesianART) candidateUpdate(j int, a []float64) []float64 {
+	n := float64(b.N[j] + 1)
+	mu := make([]float64, b.M)
+	for i := range mu {
+		mu[i] = (1-1/n)*b.W[j][i] + a[i]/n
+	}
+
+	// Welford update of the scatter matrix: (a - oldMu) * (a - newMu)^T
+	oldMu := b.W[j]
+	sigma := b.Sigma[j]
+	for r := range b.M {
+		dr := a[r] - oldMu[r]
+		for c := range b.M {
+			dc := a[c] - mu[c]
+			b.candidateSigma[r*b.M+c] = ((n-1)*sigma[r*b.M+c] + dr*dc) / n
+		}
+	}
+
+	return mu
+}
+
+func (b *BayesianART) appendNewCategory(a []float64) int {
+	sigma := make([]float64, b.M*b.M)
+	for i := range b.M {
+		sigma[i*b.M+i] = b.sigma0 * b.sigma0
+	}
+	l := make([]float64, b.M*b.M)
+	linalg.Cholesky(sigma, b.M, l)
+
+	b.W = append(b.W, slices.Clone(a))
+	b.Sigma = append(b.Sigma, sigma)
+	b.N = append(b.N, 1)
+	b.chol = append(b.chol, l)
+	b.logDet = append(b.logDet, linalg.LogDet(l, b.M))
+	b.t = append(b.t, &bayesianActivation{})
+	return len(b.W) - 1
+}
+
+// resonateOrReset searches the categories in posterior order for the first one
+// whose hypervolume stays below sMax after learning the input, and updates it.
+// If none passes the vigilance test a new category is created.
+func (b *BayesianART) resonateOrReset(a []float64) (posterior float64, categoryIndex int) {
+	for _, t := range b.t {
+		mu := b.candidateUpdate(t.j, a)
+		if _, err := linalg.CholeskyJitter(b.candidateSigma, b.M, b.candidateChol, minJitter); err != nil {
+			// the input can't be learned by this category (e.g.: it contains NaN)
+			posterior = math.Max(posterior, t.posterior)
+			continue
+		}
+		logDet := linalg.LogDet(b.candidateChol, b.M)
+		if logDet <= b.logSMax {
+			b.W[t.j] = mu
+			copy(b.Sigma[t.j], b.candidateSigma)
+			copy(b.chol[t.j], b.candidateChol)
+			b.logDet[t.j] = logDet
+			b.N[t.j]++
+			return t.posterior, t.j
+		}
+		posterior = math.Max(posterior, t.posterior)
+	}
+
+	categoryIndex = b.appendNewCategory(a)
+	return
+}
+
+// Fit implements the complete ART learning cycle.
+func (b *BayesianART) Fit(a []float64) (posterior float64, categoryIndex int) {
+	b.activateCategories(a)
+	return b.resonateOrReset(a)
+}
+
+// Predict returns the posterior probability and the index of the most probable category,
+// or -1 if the model has no categories yet.
+// If learn is true, it also updates the matching category.
+func (b *BayesianART) Predict(a []float64, learn bool) (posterior float64, categoryIndex int) {
+	b.activateCategories(a)
+	if !learn {
+		if len(b.t) == 0 {
+			return 0, -1
+		}
+		return b.t[0].posterior, b.t[0].j
+	}
+
+	return b.resonateOrReset(a)
+}
+
+// Posteriors returns the posterior probability of every category given the input,
+// indexed by category. The values sum to 1.
+func (b *BayesianART) Posteriors(a []float64) []float64 {
+	b.activateCategories(a)
+	p := make([]float64, len(b.t))
+	for _, t := range b.t {
+		p[t.j] = t.posterior
+	}
+	return p
+}
+
+// Close is a no-op, it exists so that BayesianART can be swapped with FuzzyART.
+func (b *BayesianART) Close() {}
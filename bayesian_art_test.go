@@ -0,0 +1,126 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBayesianART(t *testing.T) {
+	model, err := NewBayesianART(2, 2e-4, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	_, j0 := model.Fit([]float64{0.1, 0.1})
+	_, j1 := model.Fit([]float64{0.12, 0.1})
+	_, j2 := model.Fit([]float64{0.9, 0.9})
+
+	if j0 != 0 || j1 != 0 {
+		t.Errorf("close inputs should share category 0, got %d and %d", j0, j1)
+	}
+	if j2 != 1 {
+		t.Errorf("distant input should fail the hypervolume test and create category 1, got %d", j2)
+	}
+	if model.N[0] != 2 || model.N[1] != 1 {
+		t.Errorf("expected 2 and 1 samples per category, got %v", model.N)
+	}
+
+	posterior, j := model.Predict([]float64{0.11, 0.1}, false)
+	if j != 0 || posterior < 0.99 {
+		t.Errorf("expected category 0 with posterior >= 0.99, got %d (%f)", j, posterior)
+	}
+	if len(model.W) != 2 {
+		t.Errorf("Predict without learning must not create categories, got %d", len(model.W))
+	}
+
+	var sum float64
+	for _, p := range model.Posteriors([]float64{0.5, 0.5}) {
+		sum += p
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Errorf("expected the posteriors to sum to 1, got %f", sum)
+	}
+}
+
+func TestBayesianARTCovariance(t *testing.T) {
+	const sigma0 = 0.1
+	model, err := NewBayesianART(2, 1, sigma0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	samples := [][]float64{{0.1, 0.2}, {0.3, 0.1}, {0.2, 0.6}}
+	for _, a := range samples {
+		if _, j := model.Fit(a); j != 0 {
+			t.Fatalf("expected every sample in category 0, got %d", j)
+		}
+	}
+
+	// the initial covariance counts as one prior sample: sigma = (sigma0^2 * I + scatter) / n
+	n := float64(len(samples))
+	var mu [2]float64
+	for _, a := range samples {
+		mu[0] += a[0] / n
+		mu[1] += a[1] / n
+	}
+	for r := range 2 {
+		for c := range 2 {
+			var want float64
+			if r == c {
+				want = sigma0 * sigma0
+			}
+			for _, a := range samples {
+				want += (a[r] - mu[r]) * (a[c] - mu[c])
+			}
+			want /= n
+			if got := model.Sigma[0][r*2+c]; math.Abs(got-want) > 1e-12 {
+				t.Errorf("sigma[%d][%d]: expected %f, got %f", r, c, want, got)
+			}
+		}
+	}
+}
+
+func TestBayesianARTSingularInput(t *testing.T) {
+	model, err := NewBayesianART(2, 1, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	model.Fit([]float64{0.1, 0.1})
+	// a NaN input makes the candidate covariance not positive definite
+	// for any jitter, it must be rejected instead of retrying forever
+	if _, j := model.Fit([]float64{math.NaN(), 0.1}); j != 1 {
+		t.Errorf("expected the NaN input in a new category, got %d", j)
+	}
+}
+
+func TestBayesianARTEmptyPredict(t *testing.T) {
+	model, err := NewBayesianART(2, 1, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, j := model.Predict([]float64{0.5, 0.5}, false); j != -1 {
+		t.Errorf("empty model should return -1, got %d", j)
+	}
+}
+
+func TestNewBayesianARTErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		sMax, sigma0 float64
+	}{
+		{"zero sMax", 0, 0.1},
+		{"negative sMax", -1, 0.1},
+		{"zero sigma0", 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBayesianART(2, tt.sMax, tt.sigma0); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Package boltstore implements art.MetadataStore on top of bbolt,
+// keeping per-category metadata on disk instead of in the model memory.
+package boltstore
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/oblq/art"
+	bolt "go.etcd.io/bbolt"
+)
+
+var bucket = []byte("categories")
+
+// Store is a persistent art.MetadataStore.
+type Store struct {
+	db *bolt.DB
+}
+
+var _ art.MetadataStore = (*Store)(nil)
+
+// Open opens or creates the store database at path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// key encodes the category ID big endian, so that keys are sorted by category.
+func key(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, id)
+}
+
+func (s *Store) Get(id uint64) (value []byte, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(bucket).Get(key(id)); v != nil {
+			// values are only valid during the transaction
+			value = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	return value, err
+}
+
+func (s *Store) Put(id uint64, value []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put(key(id), value)
+	})
+}
+
+// Delete removes the keys in a single transaction, so that the store is never left half updated.
+func (s *Store) Delete(ids ...uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		for _, id := range ids {
+			if err := b.Delete(key(id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,62 @@
+package boltstore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/oblq/art"
+)
+
+func TestStoreDelete(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "metadata.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	for id, v := range map[uint64]string{1: "a", 2: "b", 3: "c"} {
+		if err := s.Put(id, []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Delete(2, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	for id, want := range map[uint64]string{1: "a", 2: "", 3: "c"} {
+		v, err := s.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(v) != want {
+			t.Errorf("category %d: expected %q, got %q", id, want, v)
+		}
+	}
+}
+
+func TestStoreFollowsCategories(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "metadata.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := art.NewFuzzyART(2, 0.9, 0.01, 1, art.WithMetadataStore(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	defer s.Close()
+
+	for _, a := range [][]float64{{0.1, 0.1}, {0.5, 0.5}, {0.9, 0.9}} {
+		model.Fit(a)
+	}
+	if err := model.SetMetadata(2, []byte("last")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := model.DeleteCategory(0); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := model.Metadata(1); err != nil || string(v) != "last" {
+		t.Errorf("expected the metadata to follow the category, got %q (%v)", v, err)
+	}
+}
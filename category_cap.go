@@ -0,0 +1,121 @@
+package art
+
+import (
+	"fmt"
+)
+
+// CapStrategy tells what to do when the category cap is reached and no category resonates.
+type CapStrategy int
+
+const (
+	// CapAdaptVigilance lowers the vigilance to the resonance of the best matching category,
+	// which then learns the input, as in ART-C (He, Tan & Tan, 2004).
+	// The lowered vigilance persists, so that the model keeps coarsening as it fills up.
+	CapAdaptVigilance CapStrategy = iota
+	// CapMerge creates the new category, then merges the two categories whose union
+	// is the smallest hyper-rectangle into the older one.
+	CapMerge
+	// CapEvictLRU evicts the least recently used category, the one that resonated least recently,
+	// then creates the new category, for memory-bounded deployments. See also WithEvictor.
+	CapEvictLRU
+	// CapEvictSmallest evicts the category that learned the fewest samples,
+	// the least recently used one among equal counts, then creates the new category.
+	CapEvictSmallest
+)
+
+// WithMaxCategories caps the number of categories to maxCategories.
+func WithMaxCategories(maxCategories int, strategy CapStrategy) Option {
+	return func(f *fuzzyState) error {
+		if maxCategories < 1 {
+			return fmt.Errorf("maximum categories must be positive, got %d", maxCategories)
+		}
+		if strategy < CapAdaptVigilance || strategy > CapEvictSmallest {
+			return fmt.Errorf("unknown cap strategy %d", strategy)
+		}
+		f.maxCategories = maxCategories
+		f.capStrategy = strategy
+		return nil
+	}
+}
+
+// resolveCap learns the input when the cap is reached and no category passed the vigilance test,
+// after activateCategories.
+func (f *FuzzyARTOf[T]) resolveCap(A []T, aNorm, beta float64) (resonance float64, categoryIndex int) {
+	if f.capStrategy == CapAdaptVigilance {
+		best := f.t[0]
+		for _, t := range f.t[1:] {
+			if t.fiNorm > best.fiNorm || (t.fiNorm == best.fiNorm && t.j < best.j) {
+				best = t
+			}
+		}
+		resonance = f.normalizedActivation(best.fiNorm, aNorm)
+		f.rho = resonance
+		f.learnInput(best.j, A, best.fi, beta)
+		return resonance, best.j
+	}
+
+	if f.capStrategy == CapEvictLRU || f.capStrategy == CapEvictSmallest {
+		f.evict()
+		return 1, f.commitNewCategory(A)
+	}
+
+	categoryIndex = f.commitNewCategory(A)
+	a, b := f.closestCategories()
+	f.mergeInto(a, b)
+	// errors of the metadata store are returned by the next metadata operation
+	remap, _ := f.compactMetadata(func(j int) bool { return j != b })
+
+	if categoryIndex == b {
+		categoryIndex = a
+	} else {
+		categoryIndex = remap[categoryIndex]
+	}
+	fi := make([]T, len(A))
+	fiNorm := f.intersectionNorm(A, categoryIndex, fi)
+	return f.normalizedActivation(fiNorm, aNorm), categoryIndex
+}
+
+// closestCategories returns the pair of categories, a < b, whose union is the smallest hyper-rectangle,
+// that is the pair with the largest |w_a ∧ w_b|.
+func (f *FuzzyARTOf[T]) closestCategories() (a, b int) {
+	fi := make([]T, 2*f.M)
+	var best T = -1
+	for i := range f.w {
+		for j := i + 1; j < len(f.w); j++ {
+			if fiNorm := f.kernels().FuzzyIntersection(f.w[i], f.w[j], fi); fiNorm > best {
+				best, a, b = fiNorm, i, j
+			}
+		}
+	}
+	return a, b
+}
+
+// mergeInto merges category b into category a, the union of the two hyper-rectangles.
+func (f *FuzzyARTOf[T]) mergeInto(a, b int) {
+	f.unshare(a)
+	wa, wb := f.w[a], f.w[b]
+	for i := range wa {
+		wa[i] = min(wa[i], wb[i])
+	}
+	f.categories[a].wNorm = float64(f.kernels().SumFloat64(wa))
+	f.mergeState(a, b)
+}
+
+// mergeState merges the state of category b into category a, after merging their weights.
+func (f *FuzzyARTOf[T]) mergeState(a, b int) {
+	f.mergeLabels(a, b)
+	f.categories[a].count += f.categories[b].count
+	if f.halfLife > 0 {
+		f.categories[a].usage, f.categories[a].usageAt = f.decayedUsage(a)+f.decayedUsage(b), f.age
+	}
+	if cb := f.categories[b]; cb.rho != 0 && f.categories[a].rho != 0 {
+		f.categories[a].rho = max(f.categories[a].rho, cb.rho)
+	}
+	if cb := f.categories[b]; cb.lastUsed.After(f.categories[a].lastUsed) {
+		f.categories[a].lastUsed = cb.lastUsed
+	}
+	if cb := f.categories[b]; !cb.created.IsZero() && cb.created.Before(f.categories[a].created) {
+		f.categories[a].created = cb.created
+	}
+	f.coarseDirty = true
+}
@@ -0,0 +1,39 @@
+package art
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestMaxCategories(t *testing.T) {
+	for name, strategy := range map[string]CapStrategy{"adapt": CapAdaptVigilance, "merge": CapMerge} {
+		t.Run(name, func(t *testing.T) {
+			model, err := NewFuzzyART(4, 0.95, 0.01, 1, WithMaxCategories(5, strategy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer model.Close()
+
+			rng := rand.New(rand.NewPCG(1, 2))
+			for range 200 {
+				a := []float64{rng.Float64(), rng.Float64(), rng.Float64(), rng.Float64()}
+				if _, j := model.Fit(a); j < 0 || j >= len(model.w) {
+					t.Fatalf("invalid category index %d", j)
+				}
+			}
+			if n := len(model.w); n != 5 {
+				t.Errorf("expected 5 categories, got %d", n)
+			}
+			total := 0
+			for j := range model.w {
+				total += model.Count(j)
+			}
+			if total != 200 {
+				t.Errorf("categories should account for all the samples, got %d", total)
+			}
+			if strategy == CapAdaptVigilance && model.rho >= 0.95 {
+				t.Errorf("vigilance should have been lowered, got %f", model.rho)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package art
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// CategoryID returns the stable identifier of category j, assigned when the category is created.
+// Category indexes change when categories are removed (e.g. by Prune, MergeCategories or DeleteCategory),
+// IDs don't: they are never reused and they are persisted with the model,
+// so that they can be stored outside the model (e.g. in a database).
+// IDs start at 1 and increase with the category index.
+func (f *FuzzyARTOf[T]) CategoryID(j int) uint64 {
+	return f.categories[j].id
+}
+
+// CategoryIndex returns the current index of the category with the given ID,
+// false if it doesn't exist (anymore).
+func (f *FuzzyARTOf[T]) CategoryIndex(id uint64) (j int, ok bool) {
+	return slices.BinarySearchFunc(f.categories, id, compareID)
+}
+
+// compareID compares the ID of c to id, for the binary searches by ID.
+func compareID(c category, id uint64) int {
+	return cmp.Compare(c.id, id)
+}
+
+// FitID works like Fit, returning the ID of the learning category instead of its index, see CategoryID.
+// The ID is 0 if no category learned the input, e.g. on a frozen model without categories.
+func (f *FuzzyARTOf[T]) FitID(a []float64) (categoryActivation float64, categoryID uint64) {
+	categoryActivation, categoryIndex := f.Fit(a)
+	return categoryActivation, f.idOf(categoryIndex)
+}
+
+// PredictID works like Predict, returning the ID of the winning category instead of its index, see CategoryID.
+// The ID is 0 if the model has no categories.
+func (f *FuzzyARTOf[T]) PredictID(a []float64, learn bool) (categoryActivation float64, categoryID uint64) {
+	categoryActivation, categoryIndex := f.Predict(a, learn)
+	return categoryActivation, f.idOf(categoryIndex)
+}
+
+// idOf returns the ID of category j, 0 for the -1 index of no category.
+func (f *FuzzyARTOf[T]) idOf(j int) uint64 {
+	if j < 0 {
+		return 0
+	}
+	return f.categories[j].id
+}
+
+// restoreIDs sets the IDs of the restored categories, ids is nil for models saved without them.
+func (f *FuzzyARTOf[T]) restoreIDs(ids []uint64, lastID uint64) error {
+	if ids != nil && len(ids) != len(f.categories) {
+		return fmt.Errorf("expected the IDs of %d categories, got %d", len(f.categories), len(ids))
+	}
+	for j, id := range ids {
+		if id == 0 {
+			return fmt.Errorf("category %d ID must be positive", j)
+		}
+		if j > 0 && id <= ids[j-1] {
+			return fmt.Errorf("category IDs must be increasing, got %d after %d", id, ids[j-1])
+		}
+		f.categories[j].id = id
+		f.lastID = id
+	}
+	f.lastID = max(f.lastID, lastID)
+	return nil
+}
@@ -0,0 +1,134 @@
+package art
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestCategoryIDs(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.5, 0.5, 0.5, 0.5}, {0.9, 0.9, 0.9, 0.9}} {
+		model.Fit(a)
+	}
+	for j := range model.w {
+		if id := model.CategoryID(j); id != uint64(j+1) {
+			t.Errorf("expected category %d ID %d, got %d", j, j+1, id)
+		}
+	}
+
+	if _, err := model.DeleteCategory(0); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := model.CategoryIndex(1); ok {
+		t.Error("expected the deleted category ID not to be found")
+	}
+	if j, ok := model.CategoryIndex(3); !ok || j != 1 {
+		t.Errorf("expected ID 3 at index 1, got %d (%t)", j, ok)
+	}
+
+	// IDs are never reused, even after the last category is deleted
+	if _, err := model.DeleteCategory(1); err != nil {
+		t.Fatal(err)
+	}
+	_, j := model.Fit([]float64{0.3, 0.7, 0.3, 0.7})
+	if id := model.CategoryID(j); id != 4 {
+		t.Errorf("expected a new category ID 4, got %d", id)
+	}
+}
+
+func TestFitPredictID(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	if _, id := model.PredictID([]float64{0.1, 0.1, 0.1, 0.1}, false); id != 0 {
+		t.Errorf("expected no category ID from an empty model, got %d", id)
+	}
+	for _, a := range [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.5, 0.5, 0.5, 0.5}, {0.9, 0.9, 0.9, 0.9}} {
+		model.FitID(a)
+	}
+
+	// the ID of a category survives the removal of the categories before it
+	a := []float64{0.9, 0.9, 0.9, 0.9}
+	_, want := model.PredictID(a, false)
+	if _, err := model.DeleteCategory(0); err != nil {
+		t.Fatal(err)
+	}
+	if _, id := model.PredictID(a, false); id != want {
+		t.Errorf("expected ID %d after the deletion, got %d", want, id)
+	}
+	if _, id := model.FitID(a); id != want {
+		t.Errorf("expected FitID to return ID %d, got %d", want, id)
+	}
+	if _, id := model.FitID([]float64{0.3, 0.7, 0.3, 0.7}); id != 4 {
+		t.Errorf("expected a new category ID 4, got %d", id)
+	}
+}
+
+func TestCategoryIDsPersistence(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.5, 0.5, 0.5, 0.5}, {0.9, 0.9, 0.9, 0.9}} {
+		model.Fit(a)
+	}
+	if _, err := model.DeleteCategory(2); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := model.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := LoadFuzzyART(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer saved.Close()
+
+	data, err := json.Marshal(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unmarshaled := new(FuzzyART)
+	if err := json.Unmarshal(data, unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	defer unmarshaled.Close()
+
+	decoded, err := FromProto(model.ToProto())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Close()
+
+	for name, loaded := range map[string]*FuzzyART{"binary": saved, "json": unmarshaled, "proto": decoded} {
+		for j := range model.w {
+			if loaded.CategoryID(j) != model.CategoryID(j) {
+				t.Errorf("%s: category %d ID %d, expected %d", name, j, loaded.CategoryID(j), model.CategoryID(j))
+			}
+		}
+		if j := loaded.appendNewCategory([]float64{0.3, 0.3, 0.3, 0.3, 0.7, 0.7, 0.7, 0.7}); loaded.CategoryID(j) != 4 {
+			t.Errorf("%s: expected the next ID 4, got %d", name, loaded.CategoryID(j))
+		}
+	}
+}
+
+func TestRestoreIDsValidation(t *testing.T) {
+	state := &persistedState{Rho: 0.9, Alpha: 0.01, Beta: 1, M: 1, W: [][]float64{{0.5, 0.5}, {0.2, 0.2}}, IDs: []uint64{3, 2}}
+	if err := new(FuzzyART).restoreState(state); err == nil {
+		t.Error("expected an error restoring decreasing IDs")
+	}
+	state.IDs = []uint64{0, 2}
+	if err := new(FuzzyART).restoreState(state); err == nil {
+		t.Error("expected an error restoring a zero ID")
+	}
+}
@@ -0,0 +1,31 @@
+package art
+
+import "time"
+
+// CategoryStats describes the usage of a category, see CategoryStats.
+type CategoryStats struct {
+	// ID is the stable identifier of the category, see CategoryID.
+	ID uint64
+	// Count is the number of samples learned by the category.
+	Count int
+	// Created is the time the category was created,
+	// zero for the categories of models saved before it was tracked, or imported without it.
+	Created time.Time
+	// LastUpdated is the last time the category learned an input, its creation time if it didn't learn any.
+	LastUpdated time.Time
+	// Label is the label of the category, empty if it has none.
+	Label string
+	// Usage is the decayed number of samples learned by the category with WithDecay, Count otherwise.
+	Usage float64
+}
+
+// CategoryStats returns the usage statistics of category j.
+// Merged categories keep the earliest creation time and the latest update of the two.
+func (f *FuzzyARTOf[T]) CategoryStats(j int) CategoryStats {
+	c := &f.categories[j]
+	usage := float64(c.count)
+	if f.halfLife > 0 {
+		usage = f.decayedUsage(j)
+	}
+	return CategoryStats{ID: c.id, Count: c.count, Created: c.created, LastUpdated: c.lastUsed, Label: c.label, Usage: usage}
+}
@@ -0,0 +1,54 @@
+package art
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCategoryStats(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	start := time.Now()
+	model.FitLabeled([]float64{0.1, 0.1, 0.1, 0.1}, "low")
+	model.Fit([]float64{0.9, 0.9, 0.9, 0.9})
+	created := model.CategoryStats(0).Created
+	time.Sleep(time.Millisecond)
+	model.Fit([]float64{0.11, 0.1, 0.1, 0.1})
+
+	stats := model.CategoryStats(0)
+	if stats.ID != model.CategoryID(0) || stats.Count != 2 || stats.Label != "low" {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if stats.Created.Before(start) || !stats.LastUpdated.After(stats.Created) {
+		t.Errorf("expected an update after the creation, got %+v", stats)
+	}
+	if s := model.CategoryStats(1); s.Count != 1 || !s.LastUpdated.Equal(s.Created) {
+		t.Errorf("expected a category updated at its creation, got %+v", s)
+	}
+
+	var buf bytes.Buffer
+	if err := model.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFuzzyART(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+	if got := loaded.CategoryStats(0); !got.Created.Equal(created) || !got.LastUpdated.Equal(stats.LastUpdated) {
+		t.Errorf("expected the times to survive Save, got %+v, want %+v", got, stats)
+	}
+
+	time.Sleep(time.Millisecond)
+	model.Fit([]float64{0.9, 0.89, 0.9, 0.9})
+	updated := model.CategoryStats(1).LastUpdated
+	model.MergeCategories(1, 0)
+	if merged := model.CategoryStats(0); merged.Count != 4 || !merged.Created.Equal(created) || !merged.LastUpdated.Equal(updated) {
+		t.Errorf("expected the earliest creation and the latest update, got %+v", merged)
+	}
+}
@@ -0,0 +1,168 @@
+package art
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Checkpoint files are named checkpoint-<samples>.fart, samples being the number of
+// learned samples when the checkpoint was written, and contain the model written by Save.
+const (
+	checkpointPrefix = "checkpoint-"
+	checkpointSuffix = ".fart"
+)
+
+// saver is a model written by Save, e.g. a FuzzyARTOf of any weight type.
+type saver interface {
+	Save(w io.Writer) error
+}
+
+// checkpointer writes the model to dir every everyN learned samples.
+type checkpointer struct {
+	dir     string
+	everyN  uint64
+	samples uint64
+	// err is the last error writing a checkpoint, see CheckpointErr.
+	err error
+}
+
+// WithCheckpoint writes the model to dir every everyN learned samples (calls to Fit or Predict with learning),
+// so that long training runs can be resumed with ResumeFuzzyART after a crash.
+// Checkpoints are written atomically and only the latest one is kept.
+func WithCheckpoint(dir string, everyN int) Option {
+	return func(f *fuzzyState) error {
+		if everyN <= 0 {
+			return fmt.Errorf("checkpoint interval must be positive, got %d", everyN)
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("creating checkpoint directory: %w", err)
+		}
+		f.checkpoint = &checkpointer{dir: dir, everyN: uint64(everyN)}
+		return nil
+	}
+}
+
+// learned counts a learned sample, writing a checkpoint every everyN samples.
+func (c *checkpointer) learned(f saver) {
+	c.samples++
+	if c.samples%c.everyN == 0 {
+		c.err = c.write(f)
+	}
+}
+
+// write saves f to a temporary file renamed on success, then removes the older checkpoints.
+func (c *checkpointer) write(f saver) error {
+	name := filepath.Join(c.dir, fmt.Sprintf("%s%020d%s", checkpointPrefix, c.samples, checkpointSuffix))
+	tmp := name + ".tmp"
+	file, err := os.Create(tmp)
+	if err == nil {
+		err = f.Save(file)
+		if syncErr := file.Sync(); err == nil {
+			err = syncErr
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmp, name)
+		}
+		if err != nil {
+			os.Remove(tmp)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+
+	checkpoints, _ := listCheckpoints(c.dir)
+	for _, cp := range checkpoints {
+		if cp.samples < c.samples {
+			os.Remove(cp.path)
+		}
+	}
+	return nil
+}
+
+type checkpointFile struct {
+	path    string
+	samples uint64
+}
+
+// listCheckpoints returns the checkpoints in dir, sorted by samples.
+func listCheckpoints(dir string) ([]checkpointFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var checkpoints []checkpointFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, checkpointPrefix) || !strings.HasSuffix(name, checkpointSuffix) {
+			continue
+		}
+		samples, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, checkpointPrefix), checkpointSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		checkpoints = append(checkpoints, checkpointFile{path: filepath.Join(dir, name), samples: samples})
+	}
+	slices.SortFunc(checkpoints, func(a, b checkpointFile) int { return cmp.Compare(a.samples, b.samples) })
+	return checkpoints, nil
+}
+
+// Checkpoint writes a checkpoint immediately, e.g. at the end of a training run.
+// It is a no-op unless the model was created with WithCheckpoint.
+func (f *FuzzyARTOf[T]) Checkpoint() error {
+	if f.checkpoint == nil {
+		return nil
+	}
+	f.checkpoint.err = f.checkpoint.write(f)
+	return f.checkpoint.err
+}
+
+// CheckpointErr returns the error of the last checkpoint write, nil if it succeeded.
+// Learning doesn't stop when a checkpoint can't be written, the next one is attempted anyway.
+func (f *FuzzyARTOf[T]) CheckpointErr() error {
+	if f.checkpoint == nil {
+		return nil
+	}
+	return f.checkpoint.err
+}
+
+// ResumeFuzzyART loads the latest checkpoint in dir and keeps checkpointing it every everyN samples.
+// It also returns the number of samples learned when the checkpoint was written,
+// so that the caller can skip them in the training stream.
+// If dir has no checkpoints the returned error matches fs.ErrNotExist.
+func ResumeFuzzyART(dir string, everyN int) (f *FuzzyART, samples uint64, err error) {
+	checkpoints, err := listCheckpoints(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, err
+	}
+	if len(checkpoints) == 0 {
+		return nil, 0, fmt.Errorf("no checkpoint in %s: %w", dir, fs.ErrNotExist)
+	}
+	latest := checkpoints[len(checkpoints)-1]
+
+	file, err := os.Open(latest.path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+	if f, err = LoadFuzzyART(file); err != nil {
+		return nil, 0, fmt.Errorf("loading checkpoint %s: %w", latest.path, err)
+	}
+	if err := WithCheckpoint(dir, everyN)(&f.fuzzyState); err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	f.checkpoint.samples = latest.samples
+	return f, latest.samples, nil
+}
@@ -0,0 +1,83 @@
+package art
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	dir := t.TempDir()
+	if _, _, err := ResumeFuzzyART(dir, 2); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected fs.ErrNotExist without checkpoints, got %v", err)
+	}
+
+	inputs := [][]float64{
+		{0.1, 0.1, 0.1, 0.1},
+		{0.9, 0.9, 0.9, 0.9},
+		{0.5, 0.1, 0.9, 0.5},
+		{0.12, 0.1, 0.1, 0.1},
+		{0.3, 0.7, 0.3, 0.7},
+	}
+
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithCheckpoint(dir, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range inputs {
+		model.Fit(a)
+	}
+	if err := model.CheckpointErr(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "checkpoint-00000000000000000004.fart" {
+		t.Fatalf("expected only the checkpoint after 4 samples, got %v", entries)
+	}
+
+	resumed, samples, err := ResumeFuzzyART(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resumed.Close()
+	if samples != 4 {
+		t.Fatalf("expected 4 learned samples, got %d", samples)
+	}
+	for _, a := range inputs[samples:] {
+		resumed.Fit(a)
+	}
+
+	if len(resumed.w) != len(model.w) {
+		t.Fatalf("expected %d categories, got %d", len(model.w), len(resumed.w))
+	}
+	for j := range model.w {
+		if !slices.Equal(resumed.w[j], model.w[j]) {
+			t.Errorf("category %d differs: %v != %v", j, resumed.w[j], model.w[j])
+		}
+	}
+
+	if err := resumed.Checkpoint(); err != nil {
+		t.Fatal(err)
+	}
+	final, samples, err := ResumeFuzzyART(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer final.Close()
+	if samples != 5 {
+		t.Errorf("expected the forced checkpoint after 5 samples, got %d", samples)
+	}
+}
+
+func TestCheckpointInvalidInterval(t *testing.T) {
+	if _, err := NewFuzzyART(4, 0.9, 0.01, 1, WithCheckpoint(t.TempDir(), 0)); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+}
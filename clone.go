@@ -0,0 +1,39 @@
+package art
+
+import "slices"
+
+// Clone returns a deep copy of the model, weights, category state and configuration,
+// e.g. to fork a shared base model for an A/B experiment or a per-tenant fine-tuning.
+// The copy has its own worker pool and latency stats (reset), and keeps its weights in memory even if
+// the model uses WithMmapWeights. The attached metadata store is not copied, neither is WithCheckpoint,
+// so that the copy doesn't write over the files of the model, nor are the hooks. Its random source restarts from the seed.
+func (f *FuzzyARTOf[T]) Clone() *FuzzyARTOf[T] {
+	c := new(FuzzyARTOf[T])
+	// the parameters are valid, init can't fail
+	c.init(f.M, f.rho, f.alpha, f.beta)
+	c.batchSize = f.batchSize
+	c.featureNames = slices.Clone(f.featureNames)
+	c.signature = f.signature
+	c.maxCategories, c.capStrategy, c.evictor = f.maxCategories, f.capStrategy, f.evictor
+	if f.latency != nil {
+		c.latency = new(latencyStats)
+	}
+	c.tieBreak, c.seed = f.tieBreak, f.seed
+	c.priorStrength = f.priorStrength
+	c.matchTracking, c.epsilon = f.matchTracking, f.epsilon
+	c.commitmentRate = f.commitmentRate
+	c.halfLife, c.minUsage, c.nextSweep = f.halfLife, f.minUsage, f.nextSweep
+	c.encoder = f.encoder
+	c.schedule = f.schedule
+	c.frozen = f.frozen
+	c.deterministic = f.deterministic
+
+	for j, w := range f.w {
+		c.appendNewCategory(slices.Clone(w))
+		c.categories[j] = f.categories[j]
+		c.categories[j].shared = false
+	}
+	cloneVotes(c.categories)
+	c.lastID, c.age = f.lastID, f.age
+	return c
+}
@@ -0,0 +1,44 @@
+package art
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.85, 0.01, 0.5, WithTieBreak(TieBreakLargest), WithFeatureNames([]string{"a", "b", "c", "d"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}, {0.12, 0.1, 0.1, 0.1}} {
+		model.FitLabeled(a, "x")
+	}
+
+	c := model.Clone()
+	defer c.Close()
+
+	var want, got bytes.Buffer
+	if err := model.Save(&want); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save(&got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(want.Bytes(), got.Bytes()) {
+		t.Fatal("expected the clone to save the same model")
+	}
+
+	w := slices.Clone(model.w[0])
+	for range 3 {
+		c.FitLabeled([]float64{0.2, 0.1, 0.1, 0.1}, "y")
+	}
+	c.FitLabeled([]float64{0.5, 0.9, 0.1, 0.5}, "y")
+	if !slices.Equal(model.w[0], w) || len(model.w) != 2 || model.Count(0) != 2 || model.Label(0) != "x" {
+		t.Errorf("expected the clone learning not to change the model")
+	}
+	if len(c.w) != 3 || c.Label(0) != "y" || c.CategoryID(2) != 3 {
+		t.Errorf("expected the clone to learn on its own, got %d categories", len(c.w))
+	}
+}
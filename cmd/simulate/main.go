@@ -0,0 +1,130 @@
+// Command simulate trains FuzzyART on 2D synthetic data and renders the hyperboxes
+// (rectangles in 2D) forming over time as an animated SVG or GIF.
+// It's meant to build intuition about rho, alpha and beta,
+// and since the output is deterministic for a given seed it doubles as a visual regression test.
+//
+// Usage:
+//
+//	go run ./cmd/simulate -rho 0.8 -beta 1 -out simulate.svg
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oblq/art"
+)
+
+// box is a category hyper-rectangle in the original 2D space.
+type box struct {
+	x0, y0, x1, y1 float64
+}
+
+// frame is a snapshot of the training.
+type frame struct {
+	// samples learned since the previous frame
+	points [][2]float64
+	// category of every point
+	categories []int
+	// categories hyperboxes
+	boxes []box
+}
+
+func main() {
+	rho := flag.Float64("rho", 0.8, "vigilance parameter")
+	alpha := flag.Float64("alpha", 0.01, "choice parameter")
+	beta := flag.Float64("beta", 1, "learning rate")
+	samples := flag.Int("samples", 1000, "number of samples")
+	clusters := flag.Int("clusters", 4, "number of gaussian clusters")
+	spread := flag.Float64("spread", 0.06, "standard deviation of the clusters")
+	seed := flag.Uint64("seed", 1, "random seed")
+	frames := flag.Int("frames", 50, "number of animation frames")
+	size := flag.Int("size", 400, "image size in pixels")
+	duration := flag.Float64("duration", 10, "animation duration in seconds")
+	out := flag.String("out", "simulate.svg", "output file, .svg or .gif")
+	flag.Parse()
+
+	if *samples < 1 || *frames < 1 || *clusters < 1 {
+		log.Fatal("samples, frames and clusters must be positive")
+	}
+
+	data := generate(*samples, *clusters, *spread, rand.New(rand.NewPCG(*seed, *seed)))
+
+	model, err := art.NewFuzzyART(2, *rho, *alpha, *beta)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer model.Close()
+
+	history := train(model, data, *frames)
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(*out)) {
+	case ".svg":
+		err = writeSVG(f, history, *size, *duration)
+	case ".gif":
+		err = writeGIF(f, history, *size, *duration)
+	default:
+		err = fmt.Errorf("unsupported output format %q, use .svg or .gif", filepath.Ext(*out))
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%d samples, %d categories, written to %s\n", len(data), model.CategoryCount(), *out)
+}
+
+// generate returns n points drawn from k gaussian clusters with random centers, clipped to [0, 1].
+func generate(n, k int, spread float64, rng *rand.Rand) [][2]float64 {
+	centers := make([][2]float64, k)
+	for i := range centers {
+		centers[i] = [2]float64{0.15 + 0.7*rng.Float64(), 0.15 + 0.7*rng.Float64()}
+	}
+
+	clip := func(v float64) float64 { return min(1, max(0, v)) }
+	points := make([][2]float64, n)
+	for i := range points {
+		c := centers[rng.IntN(k)]
+		points[i] = [2]float64{clip(c[0] + rng.NormFloat64()*spread), clip(c[1] + rng.NormFloat64()*spread)}
+	}
+	return points
+}
+
+// train learns the points one at a time and takes a snapshot of the categories at every frame.
+func train(model *art.FuzzyART, data [][2]float64, frames int) []frame {
+	history := make([]frame, 0, frames)
+	perFrame := max(1, (len(data)+frames-1)/frames)
+
+	var current frame
+	for i, p := range data {
+		_, j := model.Fit([]float64{p[0], p[1]})
+		current.points = append(current.points, p)
+		current.categories = append(current.categories, j)
+
+		if (i+1)%perFrame == 0 || i == len(data)-1 {
+			current.boxes = boxes(model)
+			history = append(history, current)
+			current = frame{}
+		}
+	}
+	return history
+}
+
+// boxes decodes the complement-coded weights [x, y, 1-x, 1-y] into rectangles.
+func boxes(model *art.FuzzyART) []box {
+	bb := make([]box, model.CategoryCount())
+	for j, w := range model.AllWeights() {
+		bb[j] = box{x0: w[0], y0: w[1], x1: 1 - w[2], y1: 1 - w[3]}
+	}
+	return bb
+}
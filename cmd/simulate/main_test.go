@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/gif"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/oblq/art"
+)
+
+func TestGenerate(t *testing.T) {
+	a := generate(200, 3, 0.5, rand.New(rand.NewPCG(1, 1)))
+	b := generate(200, 3, 0.5, rand.New(rand.NewPCG(1, 1)))
+	if !slices.Equal(a, b) {
+		t.Error("the same seed must generate the same points")
+	}
+	for _, p := range a {
+		if p[0] < 0 || p[0] > 1 || p[1] < 0 || p[1] > 1 {
+			t.Fatalf("point %v out of the unit square", p)
+		}
+	}
+}
+
+func TestTrain(t *testing.T) {
+	tests := []struct {
+		samples, frames int
+		want            int
+	}{
+		{100, 10, 10},
+		{105, 10, 10},
+		// a frame can't have less than one sample
+		{5, 10, 5},
+	}
+	for _, tt := range tests {
+		model, err := art.NewFuzzyART(2, 0.8, 0.01, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := generate(tt.samples, 4, 0.06, rand.New(rand.NewPCG(1, 1)))
+		history := train(model, data, tt.frames)
+		if len(history) != tt.want {
+			t.Errorf("%d samples in %d frames: expected %d frames, got %d", tt.samples, tt.frames, tt.want, len(history))
+		}
+
+		n := 0
+		for i, f := range history {
+			n += len(f.points)
+			// with fast learning the box of a category contains all its points
+			for k, p := range f.points {
+				b := f.boxes[f.categories[k]]
+				if p[0] < b.x0-1e-12 || p[0] > b.x1+1e-12 || p[1] < b.y0-1e-12 || p[1] > b.y1+1e-12 {
+					t.Errorf("frame %d: point %v outside the box %+v of its category", i, p, b)
+				}
+			}
+		}
+		if n != tt.samples {
+			t.Errorf("expected %d points in the frames, got %d", tt.samples, n)
+		}
+		if last := history[len(history)-1]; len(last.boxes) != model.CategoryCount() {
+			t.Errorf("expected %d boxes in the last frame, got %d", model.CategoryCount(), len(last.boxes))
+		}
+		model.Close()
+	}
+}
+
+func TestWrite(t *testing.T) {
+	model, err := art.NewFuzzyART(2, 0.8, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	history := train(model, generate(50, 2, 0.06, rand.New(rand.NewPCG(1, 1))), 5)
+
+	var svg bytes.Buffer
+	if err := writeSVG(&svg, history, 100, 1); err != nil {
+		t.Fatal(err)
+	}
+	d := xml.NewDecoder(bytes.NewReader(svg.Bytes()))
+	for {
+		if _, err := d.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid SVG: %v", err)
+		}
+	}
+	if n := strings.Count(svg.String(), "categories</text>"); n != len(history) {
+		t.Errorf("expected %d SVG frames, got %d", len(history), n)
+	}
+
+	var buf bytes.Buffer
+	if err := writeGIF(&buf, history, 100, 1); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != len(history) {
+		t.Errorf("expected %d GIF frames, got %d", len(history), len(anim.Image))
+	}
+}
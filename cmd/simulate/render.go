@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+)
+
+var palette = []color.RGBA{
+	{0x1f, 0x77, 0xb4, 0xff},
+	{0xff, 0x7f, 0x0e, 0xff},
+	{0x2c, 0xa0, 0x2c, 0xff},
+	{0xd6, 0x27, 0x28, 0xff},
+	{0x94, 0x67, 0xbd, 0xff},
+	{0x8c, 0x56, 0x4b, 0xff},
+	{0xe3, 0x77, 0xc2, 0xff},
+	{0x7f, 0x7f, 0x7f, 0xff},
+	{0xbc, 0xbd, 0x22, 0xff},
+	{0x17, 0xbe, 0xcf, 0xff},
+}
+
+func categoryColor(j int) color.RGBA {
+	return palette[j%len(palette)]
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// writeSVG renders the frames as an SVG animation (SMIL), looping forever.
+// Points appear at their frame and stay, boxes are shown only in their frame.
+func writeSVG(w io.Writer, history []frame, size int, duration float64) error {
+	bw := bufio.NewWriter(w)
+	n := float64(len(history))
+	s := float64(size)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		size, size, size, size)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="white"/>`+"\n", size, size)
+
+	for i, f := range history {
+		start := float64(i) / n
+		end := float64(i+1) / n
+
+		// points, visible from this frame on
+		fmt.Fprintf(bw, `<g display="none"><animate attributeName="display" values="none;inline;inline" keyTimes="0;%.6f;1" dur="%gs" repeatCount="indefinite" calcMode="discrete"/>`+"\n",
+			start, duration)
+		for k, p := range f.points {
+			fmt.Fprintf(bw, `<circle cx="%.1f" cy="%.1f" r="2" fill="%s" fill-opacity="0.6"/>`+"\n",
+				p[0]*s, (1-p[1])*s, hex(categoryColor(f.categories[k])))
+		}
+		fmt.Fprintln(bw, `</g>`)
+
+		// boxes, visible only in this frame
+		fmt.Fprintf(bw, `<g display="none"><animate attributeName="display" values="none;inline;none;none" keyTimes="0;%.6f;%.6f;1" dur="%gs" repeatCount="indefinite" calcMode="discrete"/>`+"\n",
+			start, end, duration)
+		for j, b := range f.boxes {
+			// single points are drawn with a minimum size to stay visible
+			width, height := max((b.x1-b.x0)*s, 2), max((b.y1-b.y0)*s, 2)
+			fmt.Fprintf(bw, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="none" stroke="%s" stroke-width="1.5"/>`+"\n",
+				b.x0*s, (1-b.y1)*s, width, height, hex(categoryColor(j)))
+		}
+		fmt.Fprintf(bw, `<text x="6" y="16" font-family="monospace" font-size="12">frame %d/%d, %d categories</text>`+"\n",
+			i+1, len(history), len(f.boxes))
+		fmt.Fprintln(bw, `</g>`)
+	}
+
+	fmt.Fprintln(bw, `</svg>`)
+	return bw.Flush()
+}
+
+// writeGIF renders the frames as an animated GIF, looping forever.
+func writeGIF(w io.Writer, history []frame, size int, duration float64) error {
+	pal := color.Palette{color.White, color.Black}
+	for _, c := range palette {
+		pal = append(pal, c)
+	}
+	colorIndex := func(j int) uint8 { return uint8(2 + j%len(palette)) }
+
+	delay := max(1, int(duration*100/float64(len(history))))
+	anim := &gif.GIF{}
+	points := image.NewPaletted(image.Rect(0, 0, size, size), pal)
+	s := float64(size - 1)
+
+	for _, f := range history {
+		for k, p := range f.points {
+			x, y := int(p[0]*s), int((1-p[1])*s)
+			for dx := -1; dx <= 1; dx++ {
+				for dy := -1; dy <= 1; dy++ {
+					if image.Pt(x+dx, y+dy).In(points.Rect) {
+						points.SetColorIndex(x+dx, y+dy, colorIndex(f.categories[k]))
+					}
+				}
+			}
+		}
+
+		img := image.NewPaletted(points.Rect, pal)
+		copy(img.Pix, points.Pix)
+		for j, b := range f.boxes {
+			x0, y0 := int(b.x0*s), int((1-b.y1)*s)
+			x1, y1 := int(b.x1*s), int((1-b.y0)*s)
+			for x := x0; x <= x1; x++ {
+				img.SetColorIndex(x, y0, colorIndex(j))
+				img.SetColorIndex(x, y1, colorIndex(j))
+			}
+			for y := y0; y <= y1; y++ {
+				img.SetColorIndex(x0, y, colorIndex(j))
+				img.SetColorIndex(x1, y, colorIndex(j))
+			}
+		}
+
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	return gif.EncodeAll(w, anim)
+}
@@ -0,0 +1,113 @@
+package art
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// codebookMagic identifies the codebook binary format.
+var codebookMagic = [4]byte{'A', 'R', 'V', 'Q'}
+
+const codebookVersion = 1
+
+// Codebook is a vector quantization codebook: a set of codewords and the nearest-codeword assignment,
+// so that ART can be used as an online codebook learner by compression and retrieval pipelines.
+//
+// The binary format is little endian:
+//
+//	magic     [4]byte "ARVQ"
+//	version   uint32  1
+//	codewords uint32  K
+//	dimension uint32  D
+//	data      [K*D]float64, codewords one after the other
+type Codebook struct {
+	Codewords [][]float64
+}
+
+// Codebook returns the codebook of the model, the center of every category hyper-rectangle.
+func (f *FuzzyARTOf[T]) Codebook() *Codebook {
+	c := &Codebook{Codewords: make([][]float64, len(f.w))}
+	for j := range f.w {
+		c.Codewords[j] = prototype(f, j)
+	}
+	return c
+}
+
+// Assign returns the index of the nearest codeword (euclidean distance) and the squared distance,
+// or -1 if the codebook is empty. Ties are broken in favor of the lowest index.
+func (c *Codebook) Assign(a []float64) (index int, distance float64) {
+	index = -1
+	for k, w := range c.Codewords {
+		var d float64
+		for i := range a {
+			d += (a[i] - w[i]) * (a[i] - w[i])
+		}
+		if index == -1 || d < distance {
+			index, distance = k, d
+		}
+	}
+	return index, distance
+}
+
+// WriteTo writes the codebook in binary format.
+func (c *Codebook) WriteTo(w io.Writer) (n int64, err error) {
+	dim := 0
+	if len(c.Codewords) > 0 {
+		dim = len(c.Codewords[0])
+	}
+
+	bw := bufio.NewWriter(w)
+	header := make([]byte, 0, 16)
+	header = append(header, codebookMagic[:]...)
+	header = binary.LittleEndian.AppendUint32(header, codebookVersion)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(c.Codewords)))
+	header = binary.LittleEndian.AppendUint32(header, uint32(dim))
+	bw.Write(header)
+
+	buf := make([]byte, 8)
+	for k, codeword := range c.Codewords {
+		if len(codeword) != dim {
+			return 0, fmt.Errorf("codeword %d length must be %d, got %d", k, dim, len(codeword))
+		}
+		for _, v := range codeword {
+			binary.LittleEndian.PutUint64(buf, math.Float64bits(v))
+			bw.Write(buf)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	return int64(len(header) + 8*len(c.Codewords)*dim), nil
+}
+
+// ReadCodebook reads a codebook written by Codebook.WriteTo.
+func ReadCodebook(r io.Reader) (*Codebook, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("reading codebook header: %w", err)
+	}
+	if [4]byte(header[:4]) != codebookMagic {
+		return nil, fmt.Errorf("not a codebook, magic %q", header[:4])
+	}
+	if v := binary.LittleEndian.Uint32(header[4:]); v != codebookVersion {
+		return nil, fmt.Errorf("unsupported codebook version %d", v)
+	}
+	k := int(binary.LittleEndian.Uint32(header[8:]))
+	dim := int(binary.LittleEndian.Uint32(header[12:]))
+
+	data := make([]byte, 8*dim)
+	c := &Codebook{Codewords: make([][]float64, k)}
+	for j := range c.Codewords {
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("reading codeword %d: %w", j, err)
+		}
+		c.Codewords[j] = make([]float64, dim)
+		for i := range c.Codewords[j] {
+			c.Codewords[j][i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,38 @@
+package art
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestCodebookRoundTrip(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.2, 0.3, 0.4})
+	model.Fit([]float64{0.9, 0.8, 0.7, 0.6})
+
+	var buf bytes.Buffer
+	n, err := model.Codebook().WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo reported %d bytes, wrote %d", n, buf.Len())
+	}
+
+	c, err := ReadCodebook(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := model.Codebook().Codewords
+	if len(c.Codewords) != 2 || !slices.Equal(c.Codewords[0], want[0]) || !slices.Equal(c.Codewords[1], want[1]) {
+		t.Fatalf("expected codewords %v, got %v", want, c.Codewords)
+	}
+	if k, _ := c.Assign([]float64{0.8, 0.8, 0.8, 0.8}); k != 1 {
+		t.Errorf("expected codeword 1, got %d", k)
+	}
+}
@@ -0,0 +1,43 @@
+package art
+
+import "fmt"
+
+// WithCommitmentRate sets the learning rate committing new categories, separately from beta,
+// the rate recoding the existing ones: a new category learns the input from an uncommitted
+// all-ones prototype, w = rate * A + (1 - rate).
+// By default new categories copy the input (fast commitment, rate 1), so a slow beta
+// (e.g. 0.1) recodes the categories slowly, reducing noise sensitivity without slowing the initial learning:
+// the classic fast-commit slow-recode. A rate below 1 also commits new categories slowly,
+// they start larger than the input and still resonate with it.
+func WithCommitmentRate(rate float64) Option {
+	return func(f *fuzzyState) error {
+		if rate <= 0 || rate > 1 {
+			return fmt.Errorf("commitment rate must be between 0 and 1, got %f", rate)
+		}
+		f.commitmentRate = rate
+		if rate == 1 {
+			f.commitmentRate = 0
+		}
+		return nil
+	}
+}
+
+// CommitmentRate returns the learning rate committing new categories, see WithCommitmentRate.
+func (f *FuzzyARTOf[T]) CommitmentRate() float64 {
+	if f.commitmentRate == 0 {
+		return 1
+	}
+	return f.commitmentRate
+}
+
+// commitNewCategory creates a new category learning the complement-coded input A with the commitment rate.
+func (f *FuzzyARTOf[T]) commitNewCategory(A []T) int {
+	if f.commitmentRate == 0 {
+		return f.appendNewCategory(A)
+	}
+	w := make([]T, len(A))
+	for i, v := range A {
+		w[i] = T(f.commitmentRate*float64(v) + (1 - f.commitmentRate))
+	}
+	return f.appendNewCategory(w)
+}
@@ -0,0 +1,62 @@
+package art
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestCommitmentRate(t *testing.T) {
+	if _, err := NewFuzzyART(4, 0.5, 0.01, 1, WithCommitmentRate(0)); err == nil {
+		t.Error("expected an error with rate 0")
+	}
+
+	// fast commitment, slow recoding
+	model, err := NewFuzzyART(4, 0.8, 0.01, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.2, 0.6, 0.2, 0.6})
+	if want := []float64{0.2, 0.6, 0.2, 0.6, 0.8, 0.4, 0.8, 0.4}; !slices.Equal(model.w[0], want) || model.CommitmentRate() != 1 {
+		t.Errorf("expected the input copied, got %v", model.w[0])
+	}
+
+	slow, err := NewFuzzyART(4, 0.8, 0.01, 1, WithCommitmentRate(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer slow.Close()
+	if resonance, j := slow.Fit([]float64{0.2, 0.6, 0.2, 0.6}); j != 0 {
+		t.Fatalf("expected a new category, got %d (%f)", j, resonance)
+	}
+	want := []float64{0.6, 0.8, 0.6, 0.8, 0.9, 0.7, 0.9, 0.7}
+	for i, v := range slow.w[0] {
+		if v < want[i]-1e-12 || v > want[i]+1e-12 {
+			t.Fatalf("expected the prototype halfway to uncommitted, got %v", slow.w[0])
+		}
+	}
+	if resonance, j := slow.Predict([]float64{0.2, 0.6, 0.2, 0.6}, false); j != 0 || resonance != 1 {
+		t.Errorf("expected the committed input to resonate, got %d (%f)", j, resonance)
+	}
+
+	var buf bytes.Buffer
+	if err := slow.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFuzzyART(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+	decoded, err := FromProto(slow.ToProto())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Close()
+	for _, m := range []*FuzzyART{loaded, decoded, slow.Clone()} {
+		if m.CommitmentRate() != 0.5 {
+			t.Errorf("expected the commitment rate to be restored, got %f", m.CommitmentRate())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package art
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// ConcurrentFuzzyART makes a FuzzyART safe for concurrent use:
+// learning is serialized, while predictions without learning run in parallel with each other.
+type ConcurrentFuzzyART struct {
+	mu    sync.RWMutex
+	fuzzy *FuzzyART
+}
+
+// NewConcurrentFuzzyART wraps f, which must not be used directly anymore, except through Do.
+func NewConcurrentFuzzyART(f *FuzzyART) *ConcurrentFuzzyART {
+	return &ConcurrentFuzzyART{fuzzy: f}
+}
+
+// Fit works like FuzzyART.Fit, serialized with the other calls.
+func (c *ConcurrentFuzzyART) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fuzzy.Fit(a)
+}
+
+// Predict works like FuzzyART.Predict. Without learning (or while the model is frozen)
+// it only takes a read lock, so predictions run in parallel.
+// If the model has no categories yet, the category index is -1.
+// With TieBreakRandom, parallel predictions draw the tie keys from an unseeded source.
+func (c *ConcurrentFuzzyART) Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int) {
+	if learn {
+		c.mu.Lock()
+		if !c.fuzzy.frozen {
+			defer c.mu.Unlock()
+			return c.fuzzy.Predict(a, true)
+		}
+		c.mu.Unlock()
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	f := c.fuzzy
+	if f.tieBreak != TieBreakRandom || len(f.w) == 0 {
+		return f.Predict(a, false)
+	}
+	// the random source of the model isn't safe for concurrent use
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	p := f.predictOne(a, f.newVector(), make([]float64, len(f.w)), rng)
+	return p.Activation, p.Category
+}
+
+// PredictBatch works like FuzzyART.PredictBatch, in parallel with the other predictions.
+func (c *ConcurrentFuzzyART) PredictBatch(samples [][]float64) []Prediction {
+	// the random source of TieBreakRandom is drawn by PredictBatch
+	if c.fuzzy.tieBreak == TieBreakRandom {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	} else {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return c.fuzzy.PredictBatch(samples)
+}
+
+// Do calls fn with exclusive access to the model, for the methods not wrapped by ConcurrentFuzzyART.
+// fn must not retain f.
+func (c *ConcurrentFuzzyART) Do(fn func(f *FuzzyART)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fn(c.fuzzy)
+}
+
+// Close closes the model, waiting for the running calls.
+func (c *ConcurrentFuzzyART) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fuzzy.Close()
+}
@@ -0,0 +1,60 @@
+package art
+
+import (
+	"math/rand/v2"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentFuzzyART(t *testing.T) {
+	f, err := NewFuzzyART(4, 0.85, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := NewConcurrentFuzzyART(f)
+	defer model.Close()
+	var _ Model = model
+
+	if _, j := model.Predict([]float64{0.1, 0.2, 0.3, 0.4}, false); j != -1 {
+		t.Errorf("expected no category from an empty model, got %d", j)
+	}
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(uint64(g), 1))
+			for i := range 300 {
+				a := []float64{rng.Float64(), rng.Float64(), rng.Float64(), rng.Float64()}
+				switch i % 3 {
+				case 0:
+					model.Fit(a)
+				case 1:
+					model.Predict(a, false)
+				default:
+					model.PredictBatch([][]float64{a, a})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// parallel predictions match the sequential ones
+	model.Do(func(f *FuzzyART) {
+		if len(f.w) == 0 {
+			t.Fatal("expected the model to learn")
+		}
+	})
+	rng := rand.New(rand.NewPCG(9, 9))
+	for range 100 {
+		a := []float64{rng.Float64(), rng.Float64(), rng.Float64(), rng.Float64()}
+		activation, j := model.Predict(a, false)
+		var wantActivation float64
+		var want int
+		model.Do(func(f *FuzzyART) { wantActivation, want = f.Predict(a, false) })
+		if j != want || activation != wantActivation {
+			t.Errorf("expected category %d (%f), got %d (%f)", want, wantActivation, j, activation)
+		}
+	}
+}
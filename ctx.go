@@ -0,0 +1,107 @@
+package art
+
+import (
+	"context"
+	"time"
+)
+
+// FitCtx works like Fit, but stops between category batches once ctx is done,
+// so that the activation of a huge model can be bounded with a deadline.
+// When it stops, nothing is learned and it returns -1 and the context error.
+// It returns an *InputError if the Encoder of the model returns the wrong number of values.
+func (f *FuzzyARTOf[T]) FitCtx(ctx context.Context, a []float64) (categoryActivation float64, categoryIndex int, err error) {
+	return f.fitEncodedCtx(ctx, a, nil)
+}
+
+// fitEncodedCtx works like FitCtx, with A the encoding of a by the Encoder of the model,
+// or nil to encode a here.
+func (f *FuzzyARTOf[T]) fitEncodedCtx(ctx context.Context, a []float64, A []T) (categoryActivation float64, categoryIndex int, err error) {
+	if f.latency != nil {
+		defer f.latency.fit.observe(time.Now())
+	}
+	if A == nil && f.encoder != nil {
+		if A, err = f.encode(a); err != nil {
+			return 0, -1, err
+		}
+	}
+	if f.frozen {
+		return f.predictCtx(ctx, a, A)
+	}
+	if A == nil {
+		A = f.learningInput(a)
+	}
+	return f.learnCtx(ctx, A, f.beta)
+}
+
+// PredictCtx works like Predict, but stops between category batches once ctx is done.
+// When it stops, nothing is learned and it returns -1 and the context error.
+// It returns an *InputError if the Encoder of the model returns the wrong number of values.
+func (f *FuzzyARTOf[T]) PredictCtx(ctx context.Context, a []float64, learn bool) (categoryActivation float64, categoryIndex int, err error) {
+	return f.predictEncodedCtx(ctx, a, nil, learn)
+}
+
+// predictEncodedCtx works like PredictCtx, with A the encoding of a by the Encoder of the model,
+// or nil to encode a here.
+func (f *FuzzyARTOf[T]) predictEncodedCtx(ctx context.Context, a []float64, A []T, learn bool) (categoryActivation float64, categoryIndex int, err error) {
+	if f.latency != nil {
+		defer f.latency.predict.observe(time.Now())
+	}
+	if A == nil && f.encoder != nil {
+		if A, err = f.encode(a); err != nil {
+			return 0, -1, err
+		}
+	}
+	if !learn || f.frozen {
+		return f.predictCtx(ctx, a, A)
+	}
+	if A == nil {
+		A = f.learningInput(a)
+	}
+	return f.learnCtx(ctx, A, f.beta)
+}
+
+// learnCtx runs the learning cycle of Fit for the complement-coded input A, with learning rate beta.
+func (f *FuzzyARTOf[T]) learnCtx(ctx context.Context, A []T, beta float64) (categoryActivation float64, categoryIndex int, err error) {
+	if f.halfLife > 0 && f.age >= f.nextSweep {
+		f.evictDecayed()
+	}
+	if err := f.activateCategoriesCtx(ctx, A); err != nil {
+		return 0, -1, err
+	}
+	lastID := f.lastID
+	categoryActivation, categoryIndex = f.resonateOrReset(A, beta)
+	f.notifyHooks(categoryIndex, categoryActivation, lastID)
+	if f.checkpoint != nil {
+		f.checkpoint.learned(f)
+	}
+	return categoryActivation, categoryIndex, nil
+}
+
+// predictCtx returns the most active category for a, without learning.
+// A is the encoding of a by the Encoder of the model, or nil to encode a here.
+// The latency is recorded by the caller, as a fit or a prediction.
+func (f *FuzzyARTOf[T]) predictCtx(ctx context.Context, a []float64, A []T) (categoryActivation float64, categoryIndex int, err error) {
+	if len(f.w) == 0 {
+		return 0, -1, nil
+	}
+	x := valuesOf[T](a)
+	var best fuzzyActivation[T]
+	if f.tieBreak == TieBreakRandom {
+		if A == nil {
+			A = f.complementCode(a)
+		}
+		if err := f.activateCategoriesCtx(ctx, A); err != nil {
+			return 0, -1, err
+		}
+		best = *f.t[0]
+	} else {
+		// without an Encoder A is nil, and the complement coding is fused in the kernels
+		if A == nil {
+			A = f.encodedInput(a)
+		}
+		if best, err = f.winnerCtx(ctx, x, A); err != nil {
+			return 0, -1, err
+		}
+	}
+	return f.normalizedActivation(best.fiNorm, f.sampleNorm(x, A)), best.j, nil
+}
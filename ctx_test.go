@@ -0,0 +1,83 @@
+package art
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFitCtxCancelled(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	// more categories than a batch
+	for i := range 3 * model.batchSize {
+		v := float64(i) / float64(3*model.batchSize)
+		model.appendNewCategory([]float64{v, v, v, v, 1 - v, 1 - v, 1 - v, 1 - v})
+	}
+	before := len(model.w)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a := []float64{0.3, 0.3, 0.3, 0.3}
+	if _, j, err := model.FitCtx(ctx, a); !errors.Is(err, context.Canceled) || j != -1 {
+		t.Errorf("expected context.Canceled and category -1, got %v and %d", err, j)
+	}
+	if len(model.w) != before {
+		t.Errorf("expected nothing learned, got %d categories instead of %d", len(model.w), before)
+	}
+	for _, learn := range []bool{false, true} {
+		if _, j, err := model.PredictCtx(ctx, a, learn); !errors.Is(err, context.Canceled) || j != -1 {
+			t.Errorf("learn %t: expected context.Canceled and category -1, got %v and %d", learn, err, j)
+		}
+	}
+
+	activation, j, err := model.PredictCtx(context.Background(), a, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wantActivation, want := model.Predict(a, false); j != want || activation != wantActivation {
+		t.Errorf("expected category %d (%f), got %d (%f)", want, wantActivation, j, activation)
+	}
+}
+
+func TestBatchCtxCancelled(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 1, WithSeed(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	samples := make([][]float64, 200)
+	for i := range samples {
+		v := float64(i%20) / 20
+		samples[i] = []float64{v, 1 - v, v, 1 - v}
+	}
+	model.FitAll(samples, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := model.FitAllCtx(ctx, samples, 5)
+	if !errors.Is(err, context.Canceled) || len(report.Epochs) != 0 {
+		t.Errorf("expected context.Canceled and no epochs, got %v and %+v", err, report.Epochs)
+	}
+	predictions, err := model.PredictBatchCtx(ctx, samples)
+	if !errors.Is(err, context.Canceled) || len(predictions) != len(samples) {
+		t.Fatalf("expected context.Canceled and %d predictions, got %v and %d", len(samples), err, len(predictions))
+	}
+	for i, p := range predictions {
+		if p.Category != -1 {
+			t.Errorf("sample %d: expected no prediction, got %+v", i, p)
+		}
+	}
+
+	if predictions, err = model.PredictBatchCtx(context.Background(), samples); err != nil {
+		t.Fatal(err)
+	}
+	for i, a := range samples {
+		if _, j := model.Predict(a, false); predictions[i].Category != j {
+			t.Errorf("sample %d predicted %d, Predict returns %d", i, predictions[i].Category, j)
+		}
+	}
+}
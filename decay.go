@@ -0,0 +1,50 @@
+package art
+
+import (
+	"fmt"
+	"math"
+)
+
+// WithDecay makes the model forget the stale categories, so that it tracks concept drift:
+// the usage of every category, the number of samples it learned, decays exponentially with the given half-life
+// in learning steps (inputs learned by the model), and the categories whose decayed usage falls below minUsage
+// are evicted. New categories start with usage 1, so a minUsage of e.g. 0.1 evicts a category
+// that learned a single sample after a bit more than 3 half-lives without learning any other.
+// Evictions are checked before learning an input, once every half-life,
+// and shift the following categories down: use CategoryID to keep stable references.
+// The decayed usage is reported by CategoryStats. Loaded categories restart decaying from their count.
+func WithDecay(halfLife int, minUsage float64) Option {
+	return func(f *fuzzyState) error {
+		if halfLife <= 0 {
+			return fmt.Errorf("decay half-life must be positive, got %d", halfLife)
+		}
+		if minUsage <= 0 {
+			return fmt.Errorf("minimum usage must be positive, got %f", minUsage)
+		}
+		f.halfLife, f.minUsage = halfLife, minUsage
+		return nil
+	}
+}
+
+// decayedUsage returns the usage of category j at the current model age.
+func (f *FuzzyARTOf[T]) decayedUsage(j int) float64 {
+	c := &f.categories[j]
+	return c.usage * math.Exp2(-float64(f.age-c.usageAt)/float64(f.halfLife))
+}
+
+// evictDecayed removes the categories whose decayed usage is below minUsage.
+func (f *FuzzyARTOf[T]) evictDecayed() {
+	f.nextSweep = f.age + f.halfLife
+	evicted := make([]bool, len(f.w))
+	found := false
+	for j := range f.categories {
+		if f.decayedUsage(j) < f.minUsage {
+			evicted[j], found = true, true
+		}
+	}
+	if !found {
+		return
+	}
+	// errors of the metadata store are returned by the next metadata operation
+	f.compactMetadata(func(j int) bool { return !evicted[j] })
+}
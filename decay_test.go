@@ -0,0 +1,73 @@
+package art
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecayEvictsStaleCategories(t *testing.T) {
+	if _, err := NewFuzzyART(4, 0.9, 0.01, 1, WithDecay(0, 0.1)); err == nil {
+		t.Error("expected an error with half-life 0")
+	}
+	if _, err := NewFuzzyART(4, 0.9, 0.01, 1, WithDecay(10, 0)); err == nil {
+		t.Error("expected an error with minimum usage 0")
+	}
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithDecay(10, 0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	old := []float64{0.1, 0.1, 0.1, 0.1}
+	current := []float64{0.9, 0.9, 0.9, 0.9}
+	for range 5 {
+		model.Fit(old)
+	}
+	oldID := model.CategoryID(0)
+	if usage := model.CategoryStats(0).Usage; usage < 4 || usage > 5 {
+		t.Errorf("expected a usage between 4 and 5, got %f", usage)
+	}
+
+	// after the drift the old category decays below 0.5 in a bit more than 3 half-lives
+	for range 25 {
+		model.Fit(current)
+	}
+	if len(model.w) != 2 {
+		t.Fatalf("expected the old category to be kept a while, got %d categories", len(model.w))
+	}
+	for range 25 {
+		model.Fit(current)
+	}
+	if len(model.w) != 1 {
+		t.Fatalf("expected the old category to be evicted, got %d categories", len(model.w))
+	}
+	if _, ok := model.CategoryIndex(oldID); ok {
+		t.Error("expected the old category ID to be gone")
+	}
+	if _, j := model.Predict(current, false); j != 0 || model.CategoryStats(0).Usage < 1 {
+		t.Errorf("expected the current category to be kept, got %d", j)
+	}
+
+	var buf bytes.Buffer
+	if err := model.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFuzzyART(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+	decoded, err := FromProto(model.ToProto())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Close()
+	for _, m := range []*FuzzyART{loaded, decoded} {
+		if m.halfLife != 10 || m.minUsage != 0.5 {
+			t.Errorf("expected the decay to be restored, got %d and %f", m.halfLife, m.minUsage)
+		}
+		if usage := m.CategoryStats(0).Usage; usage != float64(model.Count(0)) {
+			t.Errorf("expected a loaded category to restart from its count, got %f", usage)
+		}
+	}
+}
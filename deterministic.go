@@ -0,0 +1,30 @@
+package art
+
+import "github.com/oblq/art/internal/simd"
+
+// WithDeterministic makes the results bit-identical across CPUs and worker counts,
+// computing the norms with the generic kernels instead of the native ones, whose vector lanes
+// sum the elements in a different order (and make results depend on the instruction set of the CPU).
+// The activations of the categories are always computed independently of each other,
+// and the per-batch winners are always reduced in category order, so the worker pool
+// and the category batches never change the winner; the generic kernels are slower on large inputs.
+// It is a runtime option, not saved with the model.
+func WithDeterministic(enabled bool) Option {
+	return func(f *fuzzyState) error {
+		f.deterministic = enabled
+		return nil
+	}
+}
+
+// Deterministic reports whether the model uses the deterministic kernels, see WithDeterministic.
+func (f *FuzzyARTOf[T]) Deterministic() bool {
+	return f.deterministic
+}
+
+// kernels returns the vector operations used by the model, on its weight type.
+func (f *FuzzyARTOf[T]) kernels() simd.ProviderOf[T] {
+	if f.deterministic {
+		return simd.GenericOf[T]()
+	}
+	return simd.SharedOf[T]()
+}
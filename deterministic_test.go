@@ -0,0 +1,65 @@
+package art
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/oblq/art/internal/simd"
+)
+
+func TestDeterministic(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	samples := make([][]float64, 300)
+	for i := range samples {
+		samples[i] = make([]float64, 20)
+		for k := range samples[i] {
+			samples[i][k] = rng.Float64()
+		}
+	}
+
+	fit := func(batchSize, workers int) (*FuzzyART, []int) {
+		model, err := NewFuzzyART(20, 0.6, 0.01, 0.5, WithDeterministic(true), WithTieBreak(TieBreakRandom), WithSeed(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		model.batchSize, model.workerPool = batchSize, make(chan struct{}, workers)
+		var assignments []int
+		for _, a := range samples {
+			_, j := model.Fit(a)
+			assignments = append(assignments, j)
+		}
+		return model, assignments
+	}
+
+	reference, want := fit(64, 4)
+	defer reference.Close()
+	if !reference.Deterministic() || len(reference.w) < 3 {
+		t.Fatalf("expected a deterministic model with a few categories, got %d", len(reference.w))
+	}
+	for _, c := range []struct{ batchSize, workers int }{{1, 1}, {3, 8}, {7, 2}, {1000, 1}} {
+		model, got := fit(c.batchSize, c.workers)
+		if !slices.Equal(got, want) || !slices.EqualFunc(model.w, reference.w, slices.Equal) {
+			t.Errorf("batch size %d, %d workers: expected the same categories and weights", c.batchSize, c.workers)
+		}
+		model.Close()
+	}
+
+	// the deterministic model computes the generic results whatever the shared provider
+	shared := simd.Shared
+	defer func() { simd.Shared = shared }()
+	simd.Shared = simd.Generic()
+	generic, err := NewFuzzyART(20, 0.6, 0.01, 0.5, WithTieBreak(TieBreakRandom), WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer generic.Close()
+	for i, a := range samples {
+		if _, j := generic.Fit(a); j != want[i] {
+			t.Fatalf("sample %d: the generic provider assigned category %d, the deterministic model %d", i, j, want[i])
+		}
+	}
+	if !slices.EqualFunc(generic.w, reference.w, slices.Equal) {
+		t.Error("expected the weights of the generic provider")
+	}
+}
@@ -0,0 +1,132 @@
+package art
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DriftAction is the adaptation applied by a DriftDetector when it detects a drift.
+type DriftAction int
+
+const (
+	// DriftNotify only reports the drift to DriftConfig.OnDrift.
+	DriftNotify DriftAction = iota
+	// DriftPrune removes the categories older than the window that learned a single sample, see Prune.
+	DriftPrune
+	// DriftRelaxVigilance lowers the vigilance by DriftConfig.VigilanceStep (down to 0),
+	// so that a noisier regime doesn't proliferate categories.
+	DriftRelaxVigilance
+	// DriftReset removes all the categories, so that the model starts learning the new regime from scratch, see Reset.
+	DriftReset
+)
+
+// DriftConfig configures a DriftDetector.
+type DriftConfig struct {
+	// Window is the number of recent samples over which the rate of category creation is measured.
+	Window int
+	// Threshold is the fraction of the window creating new categories, that is novel inputs,
+	// above which a drift is detected. A stable model creates few categories, e.g. 0.2 flags a drift
+	// when one sample in five doesn't match any category.
+	Threshold float64
+	Action    DriftAction
+	// VigilanceStep is subtracted from the vigilance by DriftRelaxVigilance.
+	VigilanceStep float64
+	// OnDrift is called after the action for every detected drift, optional.
+	OnDrift func(DriftEvent)
+}
+
+// DriftEvent describes a drift detected by a DriftDetector.
+type DriftEvent struct {
+	// Sample is the number of samples fitted by the detector, including the one detecting the drift.
+	Sample int
+	// Rate is the fraction of the window that created new categories.
+	Rate float64
+	// Categories is the number of categories after the action.
+	Categories int
+}
+
+// DriftDetector wraps a model learning a stream, monitoring the rolling rate of category creation
+// to detect concept drift and adapt the model automatically.
+// After a drift the window restarts empty, so the next one is detected at least a window later.
+type DriftDetector struct {
+	model  *FuzzyART
+	config DriftConfig
+	// created is the ring buffer of the window, true for the samples that created a category
+	created []bool
+	next    int
+	full    bool
+	// creations counts the true values of created
+	creations int
+	samples   int
+}
+
+// NewDriftDetector wraps model, which should then be trained through the detector.
+func NewDriftDetector(model *FuzzyART, config DriftConfig) (*DriftDetector, error) {
+	if config.Window <= 0 {
+		return nil, fmt.Errorf("drift window must be positive, got %d", config.Window)
+	}
+	if config.Threshold <= 0 || config.Threshold > 1 {
+		return nil, fmt.Errorf("drift threshold must be between 0 and 1, got %f", config.Threshold)
+	}
+	if config.Action == DriftRelaxVigilance && config.VigilanceStep <= 0 {
+		return nil, errors.New("vigilance step must be positive with DriftRelaxVigilance")
+	}
+	return &DriftDetector{model: model, config: config, created: make([]bool, config.Window)}, nil
+}
+
+// Fit works like FuzzyART.Fit, then applies the action of the configuration if it detects a drift.
+// The returned category index is the one of the sample before the action, which can remove or shift it.
+func (d *DriftDetector) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	lastID := d.model.lastID
+	categoryActivation, categoryIndex = d.model.Fit(a)
+	d.samples++
+	d.observe(d.model.lastID != lastID)
+	return categoryActivation, categoryIndex
+}
+
+// Rate returns the fraction of the current window that created new categories, 0 until the window is full.
+func (d *DriftDetector) Rate() float64 {
+	if !d.full {
+		return 0
+	}
+	return float64(d.creations) / float64(len(d.created))
+}
+
+// observe records whether the last sample created a category, detecting a drift.
+func (d *DriftDetector) observe(created bool) {
+	if d.created[d.next] {
+		d.creations--
+	}
+	d.created[d.next] = created
+	if created {
+		d.creations++
+	}
+	d.next++
+	if d.next == len(d.created) {
+		d.next, d.full = 0, true
+	}
+
+	rate := d.Rate()
+	if rate <= d.config.Threshold {
+		return
+	}
+	d.adapt()
+	clear(d.created)
+	d.next, d.full, d.creations = 0, false, 0
+	if d.config.OnDrift != nil {
+		d.config.OnDrift(DriftEvent{Sample: d.samples, Rate: rate, Categories: len(d.model.w)})
+	}
+}
+
+// adapt applies the action of the configuration.
+func (d *DriftDetector) adapt() {
+	f := d.model
+	switch d.config.Action {
+	case DriftPrune:
+		f.Prune(2, d.config.Window)
+	case DriftRelaxVigilance:
+		f.rho = max(f.rho-d.config.VigilanceStep, 0)
+	case DriftReset:
+		f.Reset()
+	}
+}
@@ -0,0 +1,67 @@
+package art
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestDriftDetector(t *testing.T) {
+	for _, action := range []DriftAction{DriftNotify, DriftPrune, DriftRelaxVigilance, DriftReset} {
+		model, err := NewFuzzyART(4, 0.95, 0.01, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var events []DriftEvent
+		d, err := NewDriftDetector(model, DriftConfig{
+			Window:        20,
+			Threshold:     0.5,
+			Action:        action,
+			VigilanceStep: 0.1,
+			OnDrift:       func(e DriftEvent) { events = append(events, e) },
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// a stable regime of three clusters
+		rng := rand.New(rand.NewPCG(1, 2))
+		for i := range 200 {
+			v := float64(i%3)/3 + 0.01*rng.Float64()
+			d.Fit([]float64{v, v, v, v})
+		}
+		if len(events) != 0 || d.Rate() > 0.5 {
+			t.Fatalf("%d: expected no drift in a stable regime, got %v", action, events)
+		}
+
+		// every input is novel
+		before := len(model.w)
+		for range 20 {
+			d.Fit([]float64{rng.Float64(), rng.Float64(), rng.Float64(), rng.Float64()})
+		}
+		if len(events) != 1 || events[0].Sample <= 200 {
+			t.Fatalf("%d: expected a drift after the stable regime, got %v", action, events)
+		}
+		if events[0].Rate <= 0.5 {
+			t.Errorf("%d: unexpected event %+v", action, events[0])
+		}
+		switch action {
+		case DriftPrune:
+			if events[0].Categories < before || events[0].Categories >= before+20 {
+				t.Errorf("expected the new categories to be kept and the old ones too, got %d", events[0].Categories)
+			}
+		case DriftRelaxVigilance:
+			if model.Rho() > 0.86 {
+				t.Errorf("expected the vigilance to be lowered, got %f", model.Rho())
+			}
+		case DriftReset:
+			if events[0].Categories != 0 {
+				t.Errorf("expected no categories after a reset, got %d", events[0].Categories)
+			}
+		}
+		model.Close()
+	}
+
+	if _, err := NewDriftDetector(nil, DriftConfig{Window: 10, Threshold: 0.2, Action: DriftRelaxVigilance}); err == nil {
+		t.Error("expected an error without a vigilance step")
+	}
+}
@@ -0,0 +1,228 @@
+package art
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+type ellipsoidActivation struct {
+	// ellipsoidal distance between the input and the category center
+	dist float64
+	// activation value, choice function value
+	activation float64
+	// index of the category
+	j int
+}
+
+// EllipsoidART implements Ellipsoid ART (Anagnostopoulos & Georgiopoulos, 2001).
+// Each category is a hyper-ellipsoid described by a center, the direction of its major axis and a radius.
+// The ratio between the minor and the major axes is fixed for all the categories,
+// the major axis is oriented by the second input learned by the category.
+// Inputs are used as they are (no complement coding).
+type EllipsoidART struct {
+	// Vigilance parameter - controls category granularity
+	// Range: 0.0 to 1.0
+	// Purpose: A category resonates when 1 - (R + max(R, d))/D >= rho,
+	// where R is the category radius and d the ellipsoidal distance between the input and the category center.
+	rho float64
+
+	// Choice parameter - influences category competition
+	// Recommended value: 0.01
+	// Range: > 0.0
+	alpha float64
+
+	// Learning rate - controls how fast centers and radii move toward the inputs
+	// Recommended value: 1.0
+	// Range: 0.0 to 1.0
+	beta float64
+
+	// Axis ratio - ratio between the minor axes and the major axis
+	// Range: 0.0 to 1.0, 1.0 makes EllipsoidART equivalent to HypersphereART.
+	mu float64
+
+	// Maximum distance between two inputs, e.g.: sqrt(M) for inputs in [0, 1].
+	d float64
+
+	// M is the number of features of the input, its dimensionality.
+	M int
+
+	// W stores the category centers.
+	W [][]float64
+
+	// Directions stores the unit vector of the major axis of every category,
+	// zero until the category learns its second input.
+	Directions [][]float64
+
+	// R stores the category radii, half the length of the major axis.
+	R []float64
+
+	// t is the activation list - stores category activations
+	t []*ellipsoidActivation
+}
+
+func NewEllipsoidART(inputLen int, rho, alpha, beta, mu, d float64) (*EllipsoidART, error) {
+	if rho < 0 || rho > 1 {
+		return nil, fmt.Errorf("vigilance parameter (rho) must be between 0 and 1, got %f", rho)
+	}
+	if alpha <= 0 {
+		return nil, fmt.Errorf("choice parameter (alpha) must be positive, got %f", alpha)
+	}
+	if beta <= 0 || beta > 1 {
+		return nil, fmt.Errorf("learning rate (beta) must be between 0 and 1, got %f", beta)
+	}
+	if mu <= 0 || mu > 1 {
+		return nil, fmt.Errorf("axis ratio (mu) must be between 0 and 1, got %f", mu)
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("maximum distance (d) must be positive, got %f", d)
+	}
+
+	return &EllipsoidART{
+		rho:        rho,
+		alpha:      alpha,
+		beta:       beta,
+		mu:         mu,
+		d:          d,
+		M:          inputLen,
+		W:          make([][]float64, 0),
+		Directions: make([][]float64, 0),
+		R:          make([]float64, 0),
+		t:          make([]*ellipsoidActivation, 0),
+	}, nil
+}
+
+// distance returns the ellipsoidal distance between the input and the center of category j:
+// (1/mu) * sqrt(|x - m|^2 - (1 - mu^2) * (d^T (x - m))^2).
+// Categories not oriented yet use the euclidean distance, since any input will lie on their major axis.
+func (e *EllipsoidART) distance(j int, a []float64) float64 {
+	m, dir := e.W[j], e.Directions[j]
+	var norm2, proj, dirNorm float64
+	for i := range a {
+		diff := a[i] - m[i]
+		norm2 += diff * diff
+		proj += dir[i] * diff
+		dirNorm += dir[i] * dir[i]
+	}
+	if dirNorm == 0 {
+		return math.Sqrt(norm2)
+	}
+	// rounding errors could make the argument slightly negative
+	return math.Sqrt(math.Max(0, norm2-(1-e.mu*e.mu)*proj*proj)) / e.mu
+}
+
+// activateCategories computes the choice function of every category
+// and sorts the activation list, the best matching category first.
+func (e *EllipsoidART) activateCategories(a []float64) {
+	for j := range e.W {
+		t := e.t[j]
+		t.j = j
+		t.dist = e.distance(j, a)
+		r := e.R[j]
+		t.activation = (e.d - r - math.Max(r, t.dist)) / (e.d - 2*r + e.alpha)
+	}
+
+	// Stable sort, in case of equal activation values older categories keep the priority.
+	slices.SortStableFunc(e.t, func(a, b *ellipsoidActivation) int {
+		if a.activation > b.activation {
+			return -1
+		}
+		if a.activation < b.activation {
+			return 1
+		}
+		return 0
+	})
+}
+
+// match returns the match function value for the given category.
+func (e *EllipsoidART) match(t *ellipsoidActivation) float64 {
+	r := e.R[t.j]
+	return 1 - (r+math.Max(r, t.dist))/e.d
+}
+
+func (e *EllipsoidART) appendNewCategory(a []float64) int {
+	e.W = append(e.W, slices.Clone(a))
+	e.Directions = append(e.Directions, make([]float64, len(a)))
+	e.R = append(e.R, 0)
+	e.t = append(e.t, &ellipsoidActivation{})
+	return len(e.W) - 1
+}
+
+// updateCategory orients the category on its second input,
+// then moves the center toward the input and enlarges the radius just enough to include it.
+func (e *EllipsoidART) updateCategory(t *ellipsoidActivation, a []float64) {
+	m, dir := e.W[t.j], e.Directions[t.j]
+
+	if e.R[t.j] == 0 && slices.Equal(dir, make([]float64, len(dir))) {
+		var norm float64
+		for i := range a {
+			norm += (a[i] - m[i]) * (a[i] - m[i])
+		}
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for i := range dir {
+				dir[i] = (a[i] - m[i]) / norm
+			}
+		}
+	}
+
+	r := e.R[t.j]
+	e.R[t.j] = r + e.beta/2*(math.Max(r, t.dist)-r)
+
+	if t.dist == 0 {
+		return
+	}
+	shift := e.beta / 2 * (1 - math.Min(r, t.dist)/t.dist)
+	for i := range m {
+		m[i] += shift * (a[i] - m[i])
+	}
+}
+
+// resonateOrReset searches the categories in activation order
+// for the first one passing the vigilance test and updates it,
+// if none passes a new category is created.
+func (e *EllipsoidART) resonateOrReset(a []float64) (maxResonance float64, categoryIndex int) {
+	for _, t := range e.t {
+		resonance := e.match(t)
+		if resonance >= e.rho {
+			e.updateCategory(t, a)
+			return resonance, t.j
+		}
+		maxResonance = math.Max(maxResonance, resonance)
+	}
+
+	categoryIndex = e.appendNewCategory(a)
+	return
+}
+
+// Fit implements the complete ART learning cycle.
+func (e *EllipsoidART) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	e.activateCategories(a)
+	return e.resonateOrReset(a)
+}
+
+// Predict implements the recognition process with optional learning.
+// It returns the match value and the index of the best matching category,
+// or -1 if the model has no categories yet.
+// If learn is true, it also updates the matching category.
+func (e *EllipsoidART) Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int) {
+	e.activateCategories(a)
+	if !learn {
+		if len(e.t) == 0 {
+			return 0, -1
+		}
+		return e.match(e.t[0]), e.t[0].j
+	}
+
+	return e.resonateOrReset(a)
+}
+
+// Volume returns the volume of category j relative to the unit hypersphere,
+// R * (mu*R)^(M-1), to compare the compactness of the categories with other geometries.
+func (e *EllipsoidART) Volume(j int) float64 {
+	r := e.R[j]
+	return r * math.Pow(e.mu*r, float64(e.M-1))
+}
+
+// Close is a no-op, it exists so that EllipsoidART can be swapped with FuzzyART.
+func (e *EllipsoidART) Close() {}
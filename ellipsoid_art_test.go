@@ -0,0 +1,89 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEllipsoidART(t *testing.T) {
+	model, err := NewEllipsoidART(2, 0.7, 0.01, 1, 0.5, math.Sqrt2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	// the second input orients the major axis along x,
+	// the center moves halfway and the radius covers both inputs
+	tests := []struct {
+		name string
+		a    []float64
+		want int
+	}{
+		{"first input", []float64{0.2, 0.2}, 0},
+		{"second input", []float64{0.3, 0.2}, 0},
+		{"distant input", []float64{0.9, 0.9}, 1},
+	}
+	for _, tt := range tests {
+		if _, j := model.Fit(tt.a); j != tt.want {
+			t.Errorf("%s: expected category %d, got %d", tt.name, tt.want, j)
+		}
+	}
+	if len(model.W) != 2 {
+		t.Fatalf("expected 2 categories, got %d", len(model.W))
+	}
+
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-12 }
+	if c, d := model.W[0], model.Directions[0]; !near(c[0], 0.25) || !near(c[1], 0.2) || d[0] != 1 || d[1] != 0 {
+		t.Errorf("expected center [0.25 0.2] and direction [1 0], got %v and %v", c, d)
+	}
+	if !near(model.R[0], 0.05) {
+		t.Errorf("expected radius 0.05, got %f", model.R[0])
+	}
+	if !near(model.Volume(0), 0.05*0.5*0.05) {
+		t.Errorf("expected volume %f, got %f", 0.05*0.5*0.05, model.Volume(0))
+	}
+
+	// the same offset is 1/mu times farther along the minor axis
+	if d := model.distance(0, []float64{0.3, 0.2}); !near(d, 0.05) {
+		t.Errorf("expected distance 0.05 along the major axis, got %f", d)
+	}
+	if d := model.distance(0, []float64{0.25, 0.25}); !near(d, 0.1) {
+		t.Errorf("expected distance 0.1 along the minor axis, got %f", d)
+	}
+
+	resonance, j := model.Predict([]float64{0.26, 0.2}, false)
+	if j != 0 || !near(resonance, 1-0.1/math.Sqrt2) {
+		t.Errorf("expected category 0 with resonance %f, got %d (%f)", 1-0.1/math.Sqrt2, j, resonance)
+	}
+	if len(model.W) != 2 {
+		t.Errorf("Predict without learning must not create categories, got %d", len(model.W))
+	}
+}
+
+func TestEllipsoidARTEmptyPredict(t *testing.T) {
+	model, err := NewEllipsoidART(2, 0.7, 0.01, 1, 0.5, math.Sqrt2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, j := model.Predict([]float64{0.5, 0.5}, false); j != -1 {
+		t.Errorf("empty model should return -1, got %d", j)
+	}
+}
+
+func TestNewEllipsoidARTErrors(t *testing.T) {
+	tests := []struct {
+		name                    string
+		rho, alpha, beta, mu, d float64
+	}{
+		{"rho", 1.1, 0.01, 1, 0.5, 1},
+		{"alpha", 0.7, 0, 1, 0.5, 1},
+		{"beta", 0.7, 0.01, 0, 0.5, 1},
+		{"mu", 0.7, 0.01, 1, 0, 1},
+		{"d", 0.7, 0.01, 1, 0.5, 0},
+	}
+	for _, tt := range tests {
+		if _, err := NewEllipsoidART(2, tt.rho, tt.alpha, tt.beta, tt.mu, tt.d); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package art
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/oblq/art/internal/npy"
+)
+
+// Embedder maps samples to fixed-length vectors of category activations,
+// so that a trained FuzzyART can be used as an unsupervised feature extractor
+// feeding downstream models (gradient-boosted trees, linear models, ...).
+// Vectors have one component per category, in category order.
+type Embedder struct {
+	model *FuzzyART
+
+	// TopK, if > 0, keeps only the k highest activations, the other components are set to zero.
+	TopK int
+
+	// Normalize scales every vector to unit L1 norm.
+	Normalize bool
+}
+
+func NewEmbedder(model *FuzzyART, topK int, normalize bool) *Embedder {
+	return &Embedder{model: model, TopK: topK, Normalize: normalize}
+}
+
+// Embed returns the activation vector of the sample.
+// Its length is the current number of categories of the model.
+func (e *Embedder) Embed(a []float64) []float64 {
+	v := e.model.activations(a)
+
+	if e.TopK > 0 && e.TopK < len(v) {
+		sorted := slices.Clone(v)
+		slices.Sort(sorted)
+		threshold := sorted[len(sorted)-e.TopK]
+		// keep exactly TopK components, older categories win ties
+		ties := e.TopK
+		for _, x := range v {
+			if x > threshold {
+				ties--
+			}
+		}
+		for j, x := range v {
+			switch {
+			case x > threshold:
+			case x == threshold && ties > 0:
+				ties--
+			default:
+				v[j] = 0
+			}
+		}
+	}
+
+	if e.Normalize {
+		var sum float64
+		for _, x := range v {
+			sum += x
+		}
+		if sum > 0 {
+			for j := range v {
+				v[j] /= sum
+			}
+		}
+	}
+
+	return v
+}
+
+// embedAll embeds all the samples, the vectors length is fixed
+// by the number of categories when the first sample is embedded.
+func (e *Embedder) embedAll(samples [][]float64) ([][]float64, int, error) {
+	vectors := make([][]float64, len(samples))
+	dim := -1
+	for i, a := range samples {
+		vectors[i] = e.Embed(a)
+		if dim == -1 {
+			dim = len(vectors[i])
+		} else if len(vectors[i]) != dim {
+			return nil, 0, fmt.Errorf("the model changed while embedding: sample %d has %d categories, expected %d",
+				i, len(vectors[i]), dim)
+		}
+	}
+	return vectors, max(dim, 0), nil
+}
+
+// WriteCSV writes the embedding of every sample as a CSV row,
+// preceded by a header with the column names c0, c1, ...
+func (e *Embedder) WriteCSV(w io.Writer, samples [][]float64) error {
+	vectors, dim, err := e.embedAll(samples)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	record := make([]string, dim)
+	for j := range record {
+		record[j] = "c" + strconv.Itoa(j)
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, v := range vectors {
+		for j, x := range v {
+			record[j] = strconv.FormatFloat(x, 'g', -1, 64)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteNPY writes the embeddings as a float64 NumPy array of shape (samples, categories).
+func (e *Embedder) WriteNPY(w io.Writer, samples [][]float64) error {
+	vectors, dim, err := e.embedAll(samples)
+	if err != nil {
+		return err
+	}
+
+	data := make([]float64, 0, len(vectors)*dim)
+	for _, v := range vectors {
+		data = append(data, v...)
+	}
+	return npy.WriteFloat64(w, data, len(vectors), dim)
+}
@@ -0,0 +1,103 @@
+package art
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/oblq/art/internal/npy"
+)
+
+// newEmbeddingModel returns a model with the categories of the inputs 0.1, 0.5 and 0.9.
+func newEmbeddingModel(t *testing.T) *FuzzyART {
+	t.Helper()
+	model, err := NewFuzzyART(1, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(model.Close)
+	for _, a := range []float64{0.1, 0.5, 0.9} {
+		model.Fit([]float64{a})
+	}
+	if model.CategoryCount() != 3 {
+		t.Fatalf("expected 3 categories, got %d", model.CategoryCount())
+	}
+	return model
+}
+
+func TestEmbedder(t *testing.T) {
+	model := newEmbeddingModel(t)
+
+	// the activations of 0.1 are |A ∧ w| / (alpha + |w|), and every |w| is 1
+	activations := []float64{1 / 1.01, 0.6 / 1.01, 0.2 / 1.01}
+	tests := []struct {
+		name      string
+		topK      int
+		normalize bool
+		want      []float64
+	}{
+		{"activations", 0, false, activations},
+		{"top 2", 2, false, []float64{activations[0], activations[1], 0}},
+		{"top 1 normalized", 1, true, []float64{1, 0, 0}},
+		{"normalized", 0, true, []float64{1 / 1.8, 0.6 / 1.8, 0.2 / 1.8}},
+		{"top k above the categories", 5, false, activations},
+	}
+	for _, tt := range tests {
+		got := NewEmbedder(model, tt.topK, tt.normalize).Embed([]float64{0.1})
+		if !slices.EqualFunc(got, tt.want, func(a, b float64) bool { return math.Abs(a-b) < 1e-12 }) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	// the equal activations of 0.5 with the categories of 0.1 and 0.9 are a tie, won by the older category
+	got := NewEmbedder(model, 2, false).Embed([]float64{0.5})
+	if got[0] == 0 || got[1] == 0 || got[2] != 0 {
+		t.Errorf("expected the older category to win the tie, got %v", got)
+	}
+}
+
+func TestEmbedderExport(t *testing.T) {
+	model := newEmbeddingModel(t)
+	e := NewEmbedder(model, 0, false)
+	samples := [][]float64{{0.1}, {0.5}, {0.9}}
+
+	var csvBuf bytes.Buffer
+	if err := e.WriteCSV(&csvBuf, samples); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&csvBuf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 || !slices.Equal(records[0], []string{"c0", "c1", "c2"}) {
+		t.Fatalf("expected a header and 3 rows, got %v", records)
+	}
+
+	var npyBuf bytes.Buffer
+	if err := e.WriteNPY(&npyBuf, samples); err != nil {
+		t.Fatal(err)
+	}
+	data, shape, err := npy.ReadFloat64(&npyBuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(shape, []int{3, 3}) {
+		t.Fatalf("expected shape [3 3], got %v", shape)
+	}
+
+	for i, a := range samples {
+		want := e.Embed(a)
+		for j, x := range want {
+			v, err := strconv.ParseFloat(records[i+1][j], 64)
+			if err != nil || v != x {
+				t.Errorf("CSV sample %d component %d: expected %g, got %q", i, j, x, records[i+1][j])
+			}
+			if data[i*3+j] != x {
+				t.Errorf("NPY sample %d component %d: expected %g, got %g", i, j, x, data[i*3+j])
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package art
+
+import (
+	"errors"
+	"slices"
+)
+
+// Encoder replaces the built-in complement coding of the inputs, see WithEncoder.
+type Encoder interface {
+	// Encode returns the encoded input, 2M values between 0 and 1 in the space of the category weights,
+	// from an input of the length expected by the encoder.
+	// The returned slice is owned by the model, which can store it as the weights of a new category.
+	Encode(a []float64) []float64
+}
+
+// ComplementCoded is an Encoder for inputs already complement coded by the pipeline:
+// inputs are 2M values, a followed by 1 - a, copied as they are.
+type ComplementCoded struct{}
+
+func (ComplementCoded) Encode(a []float64) []float64 {
+	return slices.Clone(a)
+}
+
+// EncoderFunc adapts a function to the Encoder interface.
+type EncoderFunc func(a []float64) []float64
+
+func (fn EncoderFunc) Encode(a []float64) []float64 {
+	return fn(a)
+}
+
+// WithEncoder replaces complement coding with enc, e.g. ComplementCoded for inputs
+// already complement coded, or an alternative normalization scheme.
+// Categories keep 2M weights, with M the input length of the constructor: enc must return 2M values between 0 and 1,
+// whatever the length of its inputs.
+// The vigilance test compares |A∧w| to the norm of the encoded input |A|, which complement coding keeps equal to M;
+// encoders should keep it constant too, or PredictWithin can skip the best category.
+// PredictWithError measures the error on the first M encoded values.
+// An encoder returning the wrong number of values makes TryFit, FitCtx and PredictCtx return an *InputError,
+// and Fit and Predict return the category index -1; the other methods panic.
+// The encoder is not saved with the model.
+func WithEncoder(enc Encoder) Option {
+	return func(f *fuzzyState) error {
+		if enc == nil {
+			return errors.New("encoder must not be nil")
+		}
+		f.encoder = enc
+		return nil
+	}
+}
+
+// encode applies the encoder, returning an *InputError if the encoded length is wrong
+// so that a wrong encoder doesn't corrupt the SIMD reads.
+// The encoding is converted to the weight type.
+func (f *FuzzyARTOf[T]) encode(a []float64) ([]T, error) {
+	A := f.encoder.Encode(a)
+	if len(A) != 2*f.M {
+		return nil, &InputError{Length: len(A), Expected: 2 * f.M, Feature: -1, Encoded: true}
+	}
+	return valuesOf[T](A), nil
+}
+
+// mustEncode works like encode, but panics on a wrong encoded length, for the methods without an error result.
+func (f *FuzzyARTOf[T]) mustEncode(a []float64) []T {
+	A, err := f.encode(a)
+	if err != nil {
+		panic(err)
+	}
+	return A
+}
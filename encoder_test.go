@@ -0,0 +1,89 @@
+package art
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestWithEncoderComplementCoded(t *testing.T) {
+	plain, err := NewFuzzyART(4, 0.8, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer plain.Close()
+	coded, err := NewFuzzyART(4, 0.8, 0.01, 0.5, WithEncoder(ComplementCoded{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer coded.Close()
+
+	for _, a := range [][]float64{{0.1, 0.2, 0.3, 0.4}, {0.9, 0.8, 0.9, 0.8}, {0.12, 0.2, 0.3, 0.4}, {0.5, 0.5, 0.1, 0.9}} {
+		A := []float64{a[0], a[1], a[2], a[3], 1 - a[0], 1 - a[1], 1 - a[2], 1 - a[3]}
+		_, want := plain.Fit(a)
+		if _, j := coded.Fit(A); j != want {
+			t.Fatalf("input %v: expected category %d, got %d", a, want, j)
+		}
+		A[0] = -1 // the model must not retain the input
+	}
+	if !slices.EqualFunc(plain.w, coded.w, slices.Equal) {
+		t.Errorf("expected the same weights, got %v and %v", plain.w, coded.w)
+	}
+
+	_, _, want := plain.PredictWithError([]float64{0.6, 0.6, 0.6, 0.6})
+	if _, _, e := coded.PredictWithError([]float64{0.6, 0.6, 0.6, 0.6, 0.4, 0.4, 0.4, 0.4}); e != want {
+		t.Errorf("expected reconstruction error %f, got %f", want, e)
+	}
+}
+
+func TestWithEncoderFunc(t *testing.T) {
+	// a 2 features input encoded as 4 features: duplicated and complement coded
+	enc := EncoderFunc(func(a []float64) []float64 {
+		return []float64{a[0], a[1], a[0], a[1], 1 - a[0], 1 - a[1], 1 - a[0], 1 - a[1]}
+	})
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithEncoder(enc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.2, 0.4})
+	if want := []float64{0.2, 0.4, 0.2, 0.4, 0.8, 0.6, 0.8, 0.6}; !slices.Equal(model.w[0], want) {
+		t.Errorf("expected weights %v, got %v", want, model.w[0])
+	}
+}
+
+func TestWithEncoderWrongLength(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithEncoder(EncoderFunc(func(a []float64) []float64 { return a })))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	a := []float64{0.1, 0.2, 0.3, 0.4}
+
+	// Fit and Predict have no error result
+	tests := []struct {
+		name     string
+		fit      func() (int, error)
+		hasError bool
+	}{
+		{"Fit", func() (int, error) { _, j := model.Fit(a); return j, nil }, false},
+		{"Predict", func() (int, error) { _, j := model.Predict(a, true); return j, nil }, false},
+		{"FitCtx", func() (int, error) { _, j, err := model.FitCtx(context.Background(), a); return j, err }, true},
+		{"PredictCtx", func() (int, error) { _, j, err := model.PredictCtx(context.Background(), a, false); return j, err }, true},
+		{"TryFit", func() (int, error) { _, j, err := model.TryFit(a); return j, err }, true},
+	}
+	for _, tt := range tests {
+		j, err := tt.fit()
+		if j != -1 {
+			t.Errorf("%s: expected category -1, got %d", tt.name, j)
+		}
+		var inputErr *InputError
+		if tt.hasError && (!errors.As(err, &inputErr) || !inputErr.Encoded || inputErr.Length != 4) {
+			t.Errorf("%s: expected an encoded input length error, got %v", tt.name, err)
+		}
+	}
+	if model.CategoryCount() != 0 {
+		t.Errorf("expected no categories, got %d", model.CategoryCount())
+	}
+}
@@ -0,0 +1,68 @@
+package art
+
+import "errors"
+
+// Evictor chooses the category evicted when the category cap is reached, see WithEvictor.
+type Evictor interface {
+	// Evict returns the index of the category to evict among the categories of f,
+	// which can be inspected with CategoryStats, and must not be modified.
+	Evict(f *FuzzyART) int
+}
+
+// EvictorFunc adapts a function to the Evictor interface.
+type EvictorFunc func(f *FuzzyART) int
+
+func (fn EvictorFunc) Evict(f *FuzzyART) int {
+	return fn(f)
+}
+
+// WithEvictor caps the number of categories to maxCategories, evicting the category chosen by e
+// to make room for a new one, as CapEvictLRU does with the least recently used category.
+// The evictor is not saved with the model, a loaded model evicts the least recently used category.
+func WithEvictor(maxCategories int, e Evictor) Option {
+	return func(f *fuzzyState) error {
+		if e == nil {
+			return errors.New("evictor must not be nil")
+		}
+		if err := WithMaxCategories(maxCategories, CapEvictLRU)(f); err != nil {
+			return err
+		}
+		f.evictor = e
+		return nil
+	}
+}
+
+// evict removes the category chosen by the eviction policy, before creating a new one.
+func (f *FuzzyARTOf[T]) evict() {
+	var victim int
+	switch {
+	case f.evictor != nil:
+		// the evictors only run on a FuzzyART, see initWeights
+		victim = f.evictor.Evict(any(f).(*FuzzyART))
+		if victim < 0 || victim >= len(f.w) {
+			panic("evictor returned an invalid category index")
+		}
+	case f.capStrategy == CapEvictSmallest:
+		victim = f.leastUsed(func(a, b *category) bool { return a.count < b.count })
+	default:
+		victim = f.leastUsed(nil)
+	}
+	// errors of the metadata store are returned by the next metadata operation
+	f.compactMetadata(func(j int) bool { return j != victim })
+}
+
+// leastUsed returns the category for which less returns true against all the others,
+// the least recently used one in case of ties (the oldest one if they were used at the same time).
+func (f *FuzzyARTOf[T]) leastUsed(less func(a, b *category) bool) int {
+	victim := 0
+	for j := 1; j < len(f.categories); j++ {
+		c, v := &f.categories[j], &f.categories[victim]
+		if less != nil && less(v, c) {
+			continue
+		}
+		if (less != nil && less(c, v)) || c.lastUsed.Before(v.lastUsed) {
+			victim = j
+		}
+	}
+	return victim
+}
@@ -0,0 +1,79 @@
+package art
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCapEviction(t *testing.T) {
+	points := [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.4, 0.4, 0.4, 0.4}, {0.7, 0.7, 0.7, 0.7}}
+	fill := func(opt Option) *FuzzyART {
+		model, err := NewFuzzyART(4, 0.95, 0.01, 1, opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, a := range points {
+			model.Fit(a)
+			time.Sleep(time.Millisecond)
+		}
+		// category 0 is the most recently used and the largest, category 1 the least recently used
+		model.Fit(points[2])
+		time.Sleep(time.Millisecond)
+		model.Fit(points[0])
+		model.Fit(points[0])
+		return model
+	}
+	novel := []float64{0.95, 0.95, 0.95, 0.95}
+
+	for _, tc := range []struct {
+		name    string
+		opt     Option
+		evicted int
+	}{
+		{"lru", WithMaxCategories(3, CapEvictLRU), 1},
+		{"smallest", WithMaxCategories(3, CapEvictSmallest), 1},
+		{"evictor", WithEvictor(3, EvictorFunc(func(f *FuzzyART) int { return 2 })), 2},
+	} {
+		model := fill(tc.opt)
+		ids := []uint64{model.CategoryID(0), model.CategoryID(1), model.CategoryID(2)}
+		if _, j := model.Fit(novel); j != 2 || len(model.w) != 3 {
+			t.Fatalf("%s: expected the new category at index 2 of 3, got %d of %d", tc.name, j, len(model.w))
+		}
+		if _, ok := model.CategoryIndex(ids[tc.evicted]); ok {
+			t.Errorf("%s: expected category %d to be evicted", tc.name, tc.evicted)
+		}
+		if _, j := model.Predict(novel, false); j != 2 {
+			t.Errorf("%s: expected the novel input to match the new category, got %d", tc.name, j)
+		}
+		model.Close()
+	}
+
+	// with equal counts CapEvictSmallest falls back to the least recently used
+	model, err := NewFuzzyART(4, 0.95, 0.01, 1, WithMaxCategories(2, CapEvictSmallest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit(points[0])
+	time.Sleep(time.Millisecond)
+	model.Fit(points[1])
+	id := model.CategoryID(1)
+	model.Fit(novel)
+	if j, ok := model.CategoryIndex(id); !ok || j != 0 {
+		t.Errorf("expected the most recent category to be kept, got %d", j)
+	}
+
+	var buf bytes.Buffer
+	if err := model.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFuzzyART(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+	if loaded.capStrategy != CapEvictSmallest {
+		t.Errorf("expected the cap strategy to be restored, got %d", loaded.capStrategy)
+	}
+}
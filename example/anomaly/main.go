@@ -0,0 +1,74 @@
+// Command anomaly detects anomalies in a synthetic stream of sensor readings,
+// scoring every reading with its reconstruction error before learning it,
+// and exits with an error if an injected anomaly is missed or a normal reading is flagged.
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+
+	"github.com/oblq/art"
+)
+
+const (
+	sensors       = 4
+	warmupSteps   = 2000
+	streamSteps   = 5000
+	anomalyEvery  = 500
+	anomalyOffset = 0.35
+	threshold     = 0.15
+)
+
+// reading returns the normalized sensor values at step t: slow out of phase oscillations plus noise.
+func reading(rng *rand.Rand, t int) []float64 {
+	a := make([]float64, sensors)
+	for i := range a {
+		a[i] = 0.5 + 0.1*math.Sin(float64(t)/50+float64(i)) + 0.01*rng.NormFloat64()
+	}
+	return a
+}
+
+func main() {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	model, err := art.NewFuzzyART(sensors, 0.85, 0.01, 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer model.Close()
+
+	for t := range warmupSteps {
+		model.Fit(reading(rng, t))
+	}
+
+	var detected, missed, falseAlarms int
+	for t := warmupSteps; t < warmupSteps+streamSteps; t++ {
+		a := reading(rng, t)
+		injected := t%anomalyEvery == 0
+		if injected {
+			a[rng.IntN(sensors)] += anomalyOffset
+		}
+
+		_, _, score := model.PredictWithError(a)
+		flagged := score > threshold
+		switch {
+		case injected && flagged:
+			detected++
+		case injected:
+			missed++
+		case flagged:
+			falseAlarms++
+		default:
+			// only normal readings are learned, so that anomalies don't become categories
+			model.Fit(a)
+		}
+	}
+
+	fmt.Printf("Learned categories: %d\n", model.CategoryCount())
+	fmt.Printf("Anomalies detected: %d, missed: %d, false alarms: %d\n", detected, missed, falseAlarms)
+	if missed > 0 || falseAlarms > 0 {
+		log.Fatal("anomaly detection failed")
+	}
+}
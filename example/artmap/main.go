@@ -0,0 +1,61 @@
+// Command artmap trains a Simplified Fuzzy ARTMAP classifier on synthetic gaussian blobs
+// and exits with an error if the test accuracy is below the expected one.
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand/v2"
+
+	"github.com/oblq/art"
+)
+
+const (
+	classes         = 3
+	features        = 4
+	trainPerClass   = 300
+	testPerClass    = 100
+	minimumAccuracy = 0.9
+)
+
+// blob returns a sample of class c, a gaussian blob clamped to [0, 1].
+func blob(rng *rand.Rand, c int) []float64 {
+	a := make([]float64, features)
+	for i := range a {
+		center := 0.2 + 0.3*float64((c+i)%classes)
+		a[i] = min(max(center+0.05*rng.NormFloat64(), 0), 1)
+	}
+	return a
+}
+
+func main() {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	model, err := art.NewSFAM(features, 0.75, 0.01, 1, 0.001)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer model.Close()
+
+	for range trainPerClass {
+		for c := range classes {
+			model.Fit(blob(rng, c), c)
+		}
+	}
+
+	correct := 0
+	for range testPerClass {
+		for c := range classes {
+			if label, _ := model.Predict(blob(rng, c)); label == c {
+				correct++
+			}
+		}
+	}
+
+	accuracy := float64(correct) / (classes * testPerClass)
+	fmt.Printf("Learned categories: %d\n", model.CategoryCount())
+	fmt.Printf("Accuracy: %.1f%%\n", accuracy*100)
+	if accuracy < minimumAccuracy {
+		log.Fatalf("accuracy below %.0f%%", minimumAccuracy*100)
+	}
+}
@@ -0,0 +1,99 @@
+// Command checkpoint simulates a crash in the middle of a streaming training run,
+// resumes it from the latest checkpoint and exits with an error if the result
+// differs from the one of an uninterrupted run.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"math/rand/v2"
+	"os"
+	"slices"
+
+	"github.com/oblq/art"
+)
+
+const (
+	features        = 4
+	samples         = 10000
+	crashAt         = 7345
+	checkpointEvery = 1000
+)
+
+func stream() [][]float64 {
+	rng := rand.New(rand.NewPCG(1, 2))
+	data := make([][]float64, samples)
+	for i := range data {
+		data[i] = make([]float64, features)
+		for j := range data[i] {
+			data[i][j] = rng.Float64()
+		}
+	}
+	return data
+}
+
+func newModel(opts ...art.Option) *art.FuzzyART {
+	model, err := art.NewFuzzyART(features, 0.8, 0.01, 1, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return model
+}
+
+func main() {
+	dir := flag.String("dir", "", "checkpoint directory, a temporary one by default")
+	flag.Parse()
+	if *dir == "" {
+		tmp, err := os.MkdirTemp("", "art-checkpoint")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer os.RemoveAll(tmp)
+		*dir = tmp
+	}
+	data := stream()
+
+	// the first run crashes, losing everything learned after the latest checkpoint
+	model, resumed, err := art.ResumeFuzzyART(*dir, checkpointEvery)
+	if errors.Is(err, fs.ErrNotExist) {
+		model = newModel(art.WithCheckpoint(*dir, checkpointEvery))
+	} else if err != nil {
+		log.Fatal(err)
+	}
+	for _, a := range data[resumed:crashAt] {
+		model.Fit(a)
+	}
+	if err := model.CheckpointErr(); err != nil {
+		log.Fatal(err)
+	}
+	model.Close()
+	fmt.Printf("Crashed after %d samples\n", crashAt)
+
+	model, resumed, err = art.ResumeFuzzyART(*dir, checkpointEvery)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer model.Close()
+	fmt.Printf("Resumed from the checkpoint after %d samples\n", resumed)
+	for _, a := range data[resumed:] {
+		model.Fit(a)
+	}
+
+	reference := newModel()
+	defer reference.Close()
+	for _, a := range data {
+		reference.Fit(a)
+	}
+
+	fmt.Printf("Learned categories: %d (uninterrupted run: %d)\n", model.CategoryCount(), reference.CategoryCount())
+	same := model.CategoryCount() == reference.CategoryCount()
+	for j := 0; same && j < model.CategoryCount(); j++ {
+		same = slices.Equal(model.Weights(j), reference.Weights(j))
+	}
+	if !same {
+		log.Fatal("the resumed run differs from the uninterrupted one")
+	}
+}
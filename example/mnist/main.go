@@ -19,12 +19,12 @@ const (
 )
 
 func main() {
-	trainData, err := dataset.GetData("../testdata/mnist_train.csv", TRAIN_SAMPLES_PER_DIGIT, false)
+	trainData, err := dataset.GetData("../../testdata/mnist_train.csv", TRAIN_SAMPLES_PER_DIGIT, false)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	testData, err := dataset.GetData("../testdata/mnist_test.csv", TEST_SAMPLES_PER_DIGIT, false)
+	testData, err := dataset.GetData("../../testdata/mnist_test.csv", TEST_SAMPLES_PER_DIGIT, false)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -35,14 +35,14 @@ func main() {
 	}
 	defer model.Close()
 
-	test(trainData, testData, model.Fit, model.Predict)
-	fmt.Printf("Learned categories: %d\n", len(model.W))
+	test(trainData, testData, model.FitAll, model.Predict)
+	fmt.Printf("Learned categories: %d\n", model.CategoryCount())
 }
 
 func test(
 	trainData,
 	testData map[string][][]float64,
-	fitFunc func([]float64) (float64, int),
+	fitAllFunc func([][]float64, int) art.Report,
 	predictFunc func([]float64, bool) (float64, int),
 ) {
 	startTime := time.Now()
@@ -50,29 +50,28 @@ func test(
 	category2Digit := make(map[int]int)
 
 	epochs := 1
-	totalSamples := 0
+	var samples [][]float64
+	var digits []int
 	for d := range 10 {
-		totalSamples += len(trainData[strconv.Itoa(d)])
+		for _, sample := range trainData[strconv.Itoa(d)] {
+			samples = append(samples, sample)
+			digits = append(digits, d)
+		}
 	}
 
-	fmt.Println("Training progress:")
-	pb := progress_bar.New(epochs*totalSamples, progressBarWidth)
-
-	for e := 0; e < epochs; e++ {
-		for d := range 10 {
-			digitData := trainData[strconv.Itoa(d)]
-			for i := range digitData {
-				_, k := fitFunc(digitData[i])
-				if _, ok := category2Digit[k]; !ok {
-					category2Digit[k] = d
-				}
-				pb.Increment()
-			}
+	fmt.Printf("Training with the %s SIMD backend...\n", art.SIMDBackend())
+	report := fitAllFunc(samples, epochs)
+	for i, k := range report.Assignments {
+		if _, ok := category2Digit[k]; !ok {
+			category2Digit[k] = digits[i]
 		}
 	}
+	for e, stats := range report.Epochs {
+		fmt.Printf("Epoch %d: %d changed assignments, %d categories\n", e+1, stats.Changed, stats.Categories)
+	}
 
 	trainingTime := time.Since(startTime)
-	fmt.Printf("\nTraining completed in %s\n", trainingTime.Round(time.Second))
+	fmt.Printf("Training completed in %s\n", trainingTime.Round(time.Second))
 
 	testStartTime := time.Now()
 
@@ -0,0 +1,152 @@
+// Command serving exposes a FuzzyART model over a JSON REST API:
+//
+//	POST /fit      {"input": [...]} learns the input
+//	POST /predict  {"input": [...]} predicts without learning
+//	GET  /metrics  latency metrics in the Prometheus text format
+//
+// With -addr it serves until interrupted, otherwise it starts on a random local port,
+// exercises the API with a few requests and exits with an error if they fail.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/oblq/art"
+)
+
+const features = 4
+
+type request struct {
+	Input []float64 `json:"input"`
+}
+
+type response struct {
+	Category   int     `json:"category"`
+	Activation float64 `json:"activation"`
+}
+
+// server serializes the access to the model, which is not safe for concurrent use.
+type server struct {
+	mu    sync.Mutex
+	model *art.FuzzyART
+}
+
+func (s *server) handle(learn bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(req.Input) != features {
+			http.Error(w, fmt.Sprintf("input must have %d features, got %d", features, len(req.Input)), http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		var resp response
+		switch {
+		case learn:
+			resp.Activation, resp.Category = s.model.Fit(req.Input)
+		case s.model.CategoryCount() > 0:
+			resp.Activation, resp.Category = s.model.Predict(req.Input, false)
+		default:
+			resp.Category = -1
+		}
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func main() {
+	addr := flag.String("addr", "", "listen address, e.g. :8080")
+	flag.Parse()
+
+	model, err := art.NewFuzzyART(features, 0.9, 0.01, 1, art.WithLatencyStats())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer model.Close()
+
+	s := &server{model: model}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /fit", s.handle(true))
+	mux.HandleFunc("POST /predict", s.handle(false))
+	mux.Handle("GET /metrics", model.PrometheusHandler("art"))
+
+	if *addr != "" {
+		log.Fatal(http.ListenAndServe(*addr, mux))
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	go http.Serve(listener, mux)
+	if err := selfTest("http://" + listener.Addr().String()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// selfTest learns two inputs and checks that they are predicted back.
+func selfTest(baseURL string) error {
+	inputs := [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}}
+	categories := make([]int, len(inputs))
+	for i, a := range inputs {
+		resp, err := post(baseURL+"/fit", a)
+		if err != nil {
+			return err
+		}
+		categories[i] = resp.Category
+	}
+	for i, a := range inputs {
+		resp, err := post(baseURL+"/predict", a)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Predicted %v: category %d, activation %.4f\n", a, resp.Category, resp.Activation)
+		if resp.Category != categories[i] {
+			return fmt.Errorf("expected category %d, got %d", categories[i], resp.Category)
+		}
+	}
+
+	resp, err := http.Get(baseURL + "/metrics")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	metrics, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Metrics:\n%s", metrics)
+	return nil
+}
+
+func post(u string, a []float64) (response, error) {
+	body, err := json.Marshal(request{Input: a})
+	if err != nil {
+		return response{}, err
+	}
+	resp, err := http.Post(u, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return response{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return response{}, fmt.Errorf("%s answered %s", u, resp.Status)
+	}
+	var r response
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	return r, err
+}
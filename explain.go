@@ -0,0 +1,36 @@
+package art
+
+// Explain returns the contribution of every dimension of the coded input to the resonance of a with category j:
+// min(A[k], Weights(j)[k]) / |A|, so that the contributions sum to the resonance of the vigilance test.
+// With complement coding, dimension i < M is feature i and dimension M+i its complement:
+// the dimensions where the sample falls outside the category contribute less than the prototype, see ExplainDeficit.
+func (f *FuzzyARTOf[T]) Explain(a []float64, j int) []float64 {
+	A := f.complementCode(a)
+	contributions := make([]float64, len(A))
+	aNorm := f.inputNorm(A)
+	if aNorm == 0 {
+		return contributions
+	}
+	for k, v := range A {
+		contributions[k] = float64(min(v, f.w[j][k])) / aNorm
+	}
+	return contributions
+}
+
+// ExplainDeficit returns the deficit of the coded input with respect to the prototype of category j on every dimension:
+// (Weights(j)[k] - min(A[k], Weights(j)[k])) / |A|, how much the category would have to grow to cover the input,
+// all zeros for an input inside the category. With complement coding, a deficit on dimension i < M means that feature i
+// is below the lower bound of the category hyper-rectangle by deficit*|A|, a deficit on dimension M+i that it is above
+// the upper bound. Explain and ExplainDeficit sum to Weights(j)[k] / |A| on every dimension.
+func (f *FuzzyARTOf[T]) ExplainDeficit(a []float64, j int) []float64 {
+	A := f.complementCode(a)
+	deficits := make([]float64, len(A))
+	aNorm := f.inputNorm(A)
+	if aNorm == 0 {
+		return deficits
+	}
+	for k, v := range A {
+		deficits[k] = float64(f.w[j][k]-min(v, f.w[j][k])) / aNorm
+	}
+	return deficits
+}
@@ -0,0 +1,40 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.5, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.2, 0.2, 0.5, 0.5})
+	model.Fit([]float64{0.4, 0.2, 0.5, 0.5})
+
+	// feature 0 above the upper bound 0.4, feature 1 below the lower bound 0.2
+	a := []float64{0.6, 0.1, 0.5, 0.5}
+	contributions, deficits := model.Explain(a, 0), model.ExplainDeficit(a, 0)
+	if len(contributions) != 8 || len(deficits) != 8 {
+		t.Fatalf("expected 8 dimensions, got %d and %d", len(contributions), len(deficits))
+	}
+	resonance, _ := model.Predict(a, false)
+	var sum, deficit float64
+	for k := range contributions {
+		sum += contributions[k]
+		deficit += deficits[k]
+	}
+	if math.Abs(sum-resonance) > 1e-12 {
+		t.Errorf("expected contributions summing to the resonance %f, got %f", resonance, sum)
+	}
+	if wNorm := model.categories[0].wNorm / 4; math.Abs(sum+deficit-wNorm) > 1e-12 {
+		t.Errorf("expected contributions and deficits summing to the category norm %f, got %f", wNorm, sum+deficit)
+	}
+	for k, want := range []float64{0, 0.1, 0, 0, 0.2, 0, 0, 0} {
+		if math.Abs(deficits[k]*4-want) > 1e-12 {
+			t.Errorf("dimension %d: expected deficit %f, got %f", k, want, deficits[k]*4)
+		}
+	}
+}
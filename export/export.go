@@ -0,0 +1,94 @@
+// Package export converts the categories learned by a FuzzyART back to the original feature space,
+// and writes the prototypes of image-shaped inputs as grayscale PNGs for visual inspection.
+package export
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/oblq/art"
+)
+
+// Prototype decodes the complement-coded weights w, of length 2M,
+// to the center of the category hyper-rectangle in the original feature space.
+func Prototype(w []float64) []float64 {
+	m := len(w) / 2
+	p := make([]float64, m)
+	for i := range p {
+		p[i] = (w[i] + 1 - w[i+m]) / 2
+	}
+	return p
+}
+
+// Image returns the prototype of the complement-coded weights w as a width×height grayscale image,
+// the features being the pixels in row-major order, 0 black and 1 white.
+func Image(w []float64, width, height int) (*image.Gray, error) {
+	if len(w) != 2*width*height {
+		return nil, fmt.Errorf("weights must be %d for a %dx%d image, got %d", 2*width*height, width, height, len(w))
+	}
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i, v := range Prototype(w) {
+		img.SetGray(i%width, i/width, gray(v))
+	}
+	return img, nil
+}
+
+func gray(v float64) color.Gray {
+	return color.Gray{Y: uint8(math.Round(255 * min(max(v, 0), 1)))}
+}
+
+// Grid returns the prototypes of all the categories of the model tiled in a single image,
+// columns per row with a one pixel black border, in category order.
+func Grid(model *art.FuzzyART, width, height, columns int) (*image.Gray, error) {
+	if columns <= 0 {
+		return nil, fmt.Errorf("columns must be positive, got %d", columns)
+	}
+	rows := (model.CategoryCount() + columns - 1) / columns
+	grid := image.NewGray(image.Rect(0, 0, columns*(width+1)+1, rows*(height+1)+1))
+	for j, w := range model.AllWeights() {
+		img, err := Image(w, width, height)
+		if err != nil {
+			return nil, fmt.Errorf("category %d: %w", j, err)
+		}
+		x0, y0 := 1+(j%columns)*(width+1), 1+(j/columns)*(height+1)
+		for y := range height {
+			copy(grid.Pix[(y0+y)*grid.Stride+x0:], img.Pix[y*img.Stride:y*img.Stride+width])
+		}
+	}
+	return grid, nil
+}
+
+// WritePNGs writes the prototype of every category of the model to dir,
+// as category-<index>.png, creating dir if needed.
+func WritePNGs(model *art.FuzzyART, dir string, width, height int) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for j, w := range model.AllWeights() {
+		img, err := Image(w, width, height)
+		if err != nil {
+			return fmt.Errorf("category %d: %w", j, err)
+		}
+		if err := writePNG(filepath.Join(dir, fmt.Sprintf("category-%05d.png", j)), img); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writePNG(path string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oblq/art"
+)
+
+func TestWritePNGs(t *testing.T) {
+	model, err := art.NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0, 1, 1, 0})
+	model.Fit([]float64{1, 1, 0, 0})
+
+	dir := t.TempDir()
+	if err := WritePNGs(model, dir, 2, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "category-00000.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []uint32{0, 0xffff, 0xffff, 0} {
+		if r, _, _, _ := img.At(i%2, i/2).RGBA(); r != want {
+			t.Errorf("pixel %d: expected %#x, got %#x", i, want, r)
+		}
+	}
+
+	grid, err := Grid(model, 2, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := grid.Bounds(); b.Dx() != 7 || b.Dy() != 4 {
+		t.Errorf("expected a 7x4 grid, got %dx%d", b.Dx(), b.Dy())
+	}
+	if v := grid.GrayAt(4, 1).Y; v != 255 {
+		t.Errorf("expected the first pixel of category 1 to be white, got %d", v)
+	}
+
+	if err := WritePNGs(model, dir, 3, 3); err == nil {
+		t.Error("expected an error for a mismatching image size")
+	}
+}
@@ -0,0 +1,104 @@
+package art
+
+import "fmt"
+
+// FALCON implements a Fusion Architecture for Learning, COgnition, and Navigation (Tan, 2004):
+// a three-channel FusionART learning (state, action, reward) triples,
+// usable as the memory of a simple reinforcement learning agent.
+// Actions are one-hot coded, rewards are in [0, 1].
+// To select an action the state is presented with every action allowed
+// and the maximum reward as the goal, so that the categories
+// which led to high rewards in similar states win the competition.
+type FALCON struct {
+	fusion  *FusionART
+	actions int
+}
+
+// NewFALCON creates a FALCON for states of stateLen features and the given number of actions,
+// with a vigilance for every channel and the choice parameter and learning rate shared by all the channels.
+func NewFALCON(stateLen, actions int, rhoState, rhoAction, rhoReward, alpha, beta float64) (*FALCON, error) {
+	if actions < 2 {
+		return nil, fmt.Errorf("actions must be at least 2, got %d", actions)
+	}
+
+	fusion, err := NewFusionART([]FusionChannel{
+		{InputLen: stateLen, Rho: rhoState, Alpha: alpha, Beta: beta, Gamma: 1.0 / 3},
+		{InputLen: actions, Rho: rhoAction, Alpha: alpha, Beta: beta, Gamma: 1.0 / 3},
+		{InputLen: 1, Rho: rhoReward, Alpha: alpha, Beta: beta, Gamma: 1.0 / 3},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FALCON{fusion: fusion, actions: actions}, nil
+}
+
+func (f *FALCON) oneHot(action int) []float64 {
+	if action < 0 || action >= f.actions {
+		panic(fmt.Sprintf("action must be between 0 and %d, got %d", f.actions-1, action))
+	}
+	a := make([]float64, f.actions)
+	a[action] = 1
+	return a
+}
+
+// Learn learns the reward obtained performing the action in the given state,
+// it returns the index of the learning category.
+func (f *FALCON) Learn(state []float64, action int, reward float64) (categoryIndex int) {
+	if reward < 0 || reward > 1 {
+		panic(fmt.Sprintf("reward must be between 0 and 1, got %f", reward))
+	}
+	_, categoryIndex = f.fusion.Fit([][]float64{state, f.oneHot(action), {reward}})
+	return categoryIndex
+}
+
+// SelectAction returns the action expected to give the highest reward in the given state,
+// and the index of the category it was read from,
+// or -1, -1 if no category knows any action, in which case the agent should explore.
+func (f *FALCON) SelectAction(state []float64) (action int, categoryIndex int) {
+	allActions := make([]float64, f.actions)
+	for i := range allActions {
+		allActions[i] = 1
+	}
+
+	fusion := f.fusion
+	fusion.activateCategories(fusion.complementCode([][]float64{state, allActions, {1}}))
+	if len(fusion.t) == 0 {
+		return -1, -1
+	}
+
+	categoryIndex = fusion.t[0].j
+	// the action part of the weights, before the complement
+	w := fusion.W[categoryIndex][1][:f.actions]
+	action = -1
+	for i, v := range w {
+		if v > 0 && (action == -1 || v > w[action]) {
+			action = i
+		}
+	}
+	if action == -1 {
+		return -1, -1
+	}
+	return action, categoryIndex
+}
+
+// Reward returns the reward expected performing the action in the given state,
+// read from the best matching category, or -1 if the model has no categories yet.
+// Rewards of generalized categories are the middle of the learned reward range.
+func (f *FALCON) Reward(state []float64, action int) float64 {
+	fusion := f.fusion
+	fusion.activateCategories(fusion.complementCode([][]float64{state, f.oneHot(action), {0.5}}))
+	if len(fusion.t) == 0 {
+		return -1
+	}
+
+	w := fusion.W[fusion.t[0].j][2]
+	return (w[0] + 1 - w[1]) / 2
+}
+
+// CategoryCount returns the number of categories.
+func (f *FALCON) CategoryCount() int {
+	return len(f.fusion.W)
+}
+
+// Close is a no-op, it exists for symmetry with FuzzyART.
+func (f *FALCON) Close() {}
@@ -0,0 +1,43 @@
+package art
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestFALCONSelectAction(t *testing.T) {
+	model, err := NewFALCON(2, 2, 0.8, 1, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	if action, _ := model.SelectAction([]float64{0.5, 0.5}); action != -1 {
+		t.Errorf("empty model should return -1, got %d", action)
+	}
+
+	// action 0 is rewarded on the left half, action 1 on the right half
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 500 {
+		x := rng.Float64()
+		state := []float64{x, 0.5}
+		action := rng.IntN(2)
+		reward := 0.0
+		if (x < 0.5) == (action == 0) {
+			reward = 1
+		}
+		model.Learn(state, action, reward)
+	}
+
+	for _, c := range []struct {
+		x      float64
+		action int
+	}{{0.1, 0}, {0.3, 0}, {0.7, 1}, {0.9, 1}} {
+		if action, _ := model.SelectAction([]float64{c.x, 0.5}); action != c.action {
+			t.Errorf("state %.1f: expected action %d, got %d", c.x, c.action, action)
+		}
+	}
+	if r := model.Reward([]float64{0.1, 0.5}, 1); r > 0.5 {
+		t.Errorf("expected a low reward, got %f", r)
+	}
+}
@@ -0,0 +1,81 @@
+package art
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithFeatureNames attaches a name to every input feature,
+// so that rules and explanations refer to features by name instead of by index.
+func WithFeatureNames(names []string) Option {
+	return func(f *fuzzyState) error {
+		if len(names) != f.M {
+			return fmt.Errorf("feature names must be %d, got %d", f.M, len(names))
+		}
+		seen := make(map[string]struct{}, len(names))
+		for _, name := range names {
+			if name == "" {
+				return fmt.Errorf("feature names must not be empty")
+			}
+			if _, ok := seen[name]; ok {
+				return fmt.Errorf("feature names must be unique, got %q twice", name)
+			}
+			seen[name] = struct{}{}
+		}
+		f.featureNames = append([]string(nil), names...)
+		return nil
+	}
+}
+
+// FeatureNames returns the names of the input features, nil if they were not set.
+func (f *FuzzyARTOf[T]) FeatureNames() []string {
+	return append([]string(nil), f.featureNames...)
+}
+
+// featureName returns the name of feature i, or x<i> when names were not set.
+func (f *FuzzyARTOf[T]) featureName(i int) string {
+	if f.featureNames != nil {
+		return f.featureNames[i]
+	}
+	return fmt.Sprintf("x%d", i)
+}
+
+// Rule describes category j as a conjunction of interval conditions on the input features,
+// one for every feature the category hyper-rectangle constrains, e.g.:
+//
+//	0.1 <= age <= 0.35 AND income >= 0.6
+//
+// A category covering the whole input space is described as "true".
+func (f *FuzzyARTOf[T]) Rule(j int) string {
+	lowers, uppers := f.Prototype(j)
+	var conditions []string
+	for i := range f.M {
+		lower, upper := lowers[i], uppers[i]
+		name := f.featureName(i)
+		switch {
+		case lower > 0 && upper < 1:
+			conditions = append(conditions, fmt.Sprintf("%.3g <= %s <= %.3g", lower, name, upper))
+		case lower > 0:
+			conditions = append(conditions, fmt.Sprintf("%s >= %.3g", name, lower))
+		case upper < 1:
+			conditions = append(conditions, fmt.Sprintf("%s <= %.3g", name, upper))
+		}
+	}
+	if len(conditions) == 0 {
+		return "true"
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// Prototype returns the bounds of the hyper-rectangle of category j in the original feature space,
+// decoding the complement-coded weights: lower[i] <= a[i] <= upper[i] for every input the category learned.
+// A category that learned a single input has equal bounds. The weights of a model using WithEncoder
+// are not complement-coded, they can't be decoded.
+func (f *FuzzyARTOf[T]) Prototype(j int) (lower, upper []float64) {
+	w := f.w[j]
+	lower, upper = make([]float64, f.M), make([]float64, f.M)
+	for i := range f.M {
+		lower[i], upper[i] = float64(w[i]), 1-float64(w[i+f.M])
+	}
+	return lower, upper
+}
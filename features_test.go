@@ -0,0 +1,44 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRule(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.5, 0.01, 1, WithFeatureNames([]string{"age", "income", "score", "tenure"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	model.Fit([]float64{0.2, 0.6, 0, 0})
+	model.Fit([]float64{0.4, 1, 0, 0})
+
+	want := "0.2 <= age <= 0.4 AND income >= 0.6 AND score <= 0 AND tenure <= 0"
+	if got := model.Rule(0); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := NewFuzzyART(4, 0.5, 0.01, 1, WithFeatureNames([]string{"a", "b"})); err == nil {
+		t.Error("mismatched feature names should be rejected")
+	}
+}
+
+func TestPrototype(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.5, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.2, 0.5, 0.25, 1})
+	model.Fit([]float64{0.4, 0.5, 0.75, 0})
+
+	lower, upper := model.Prototype(0)
+	wantLower, wantUpper := []float64{0.2, 0.5, 0.25, 0}, []float64{0.4, 0.5, 0.75, 1}
+	for i := range 4 {
+		if math.Abs(lower[i]-wantLower[i]) > 1e-12 || math.Abs(upper[i]-wantUpper[i]) > 1e-12 {
+			t.Errorf("feature %d: expected bounds [%f, %f], got [%f, %f]", i, wantLower[i], wantUpper[i], lower[i], upper[i])
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package art
+
+import (
+	"context"
+	"time"
+)
+
+// EpochStats describes an epoch of FitAll.
+type EpochStats struct {
+	// Changed is the number of samples assigned to a different category than in the previous epoch,
+	// all the samples in the first epoch.
+	Changed int
+	// NewCategories is the increase of the number of categories during the epoch.
+	NewCategories int
+	// Categories is the number of categories at the end of the epoch.
+	Categories int
+	// MeanActivation is the mean category activation returned by Fit.
+	MeanActivation float64
+	Duration       time.Duration
+}
+
+// Report is the result of FitAll.
+type Report struct {
+	// Epochs holds the statistics of every completed epoch.
+	Epochs []EpochStats
+	// Converged is true when the last epoch didn't change any assignment.
+	Converged bool
+	// Assignments holds the category of every sample in the last epoch, indexed as the samples.
+	Assignments []int
+}
+
+// FitAll fits the samples for up to epochs epochs, in a different random order every epoch
+// (reproducible with WithSeed), stopping early when an epoch doesn't change the category of any sample.
+// With slow learning (beta < 1) the weights can still be moving when the assignments converge.
+// The vigilance schedule set by WithVigilanceSchedule, if any, sets the vigilance before every sample,
+// FitAll doesn't stop early while the vigilance is still changing.
+func (f *FuzzyARTOf[T]) FitAll(samples [][]float64, epochs int) Report {
+	report, _ := f.FitAllCtx(context.Background(), samples, epochs)
+	return report
+}
+
+// FitAllCtx works like FitAll, but stops once ctx is done, between samples or between
+// the category batches of a sample, returning the epochs completed so far and the context error.
+// The samples fitted in the interrupted epoch are learned, but not reported.
+func (f *FuzzyARTOf[T]) FitAllCtx(ctx context.Context, samples [][]float64, epochs int) (Report, error) {
+	var report Report
+	if len(samples) == 0 {
+		return report, nil
+	}
+
+	order := make([]int, len(samples))
+	for i := range order {
+		order[i] = i
+	}
+	report.Assignments = make([]int, len(samples))
+	for i := range report.Assignments {
+		report.Assignments[i] = -1
+	}
+
+	var sampleIdx int
+	for range epochs {
+		start := time.Now()
+		rho := f.rho
+		before := len(f.w)
+		f.random().Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+		stats := EpochStats{}
+		for _, i := range order {
+			if f.schedule != nil {
+				f.scheduleVigilance(sampleIdx)
+				sampleIdx++
+			}
+			activation, j, err := f.FitCtx(ctx, samples[i])
+			if err != nil {
+				return report, err
+			}
+			if report.Assignments[i] != j {
+				report.Assignments[i] = j
+				stats.Changed++
+			}
+			stats.MeanActivation += activation
+		}
+		stats.MeanActivation /= float64(len(samples))
+		stats.Categories = len(f.w)
+		stats.NewCategories = max(stats.Categories-before, 0)
+		stats.Duration = time.Since(start)
+		report.Epochs = append(report.Epochs, stats)
+
+		if stats.Changed == 0 && f.rho == rho {
+			report.Converged = true
+			break
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,57 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFitAllConverges(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 1, WithSeed(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	samples := [][]float64{
+		{0.1, 0.1, 0.1, 0.1}, {0.12, 0.1, 0.11, 0.1}, {0.9, 0.9, 0.9, 0.9},
+		{0.88, 0.9, 0.91, 0.9}, {0.5, 0.1, 0.9, 0.5}, {0.52, 0.1, 0.88, 0.5},
+	}
+	report := model.FitAll(samples, 10)
+	if !report.Converged || len(report.Epochs) < 2 || len(report.Epochs) == 10 {
+		t.Fatalf("expected convergence before 10 epochs, got %+v", report)
+	}
+	first, last := report.Epochs[0], report.Epochs[len(report.Epochs)-1]
+	if first.Changed != len(samples) || first.NewCategories != first.Categories || last.Changed != 0 || last.NewCategories != 0 {
+		t.Errorf("unexpected epoch stats, first %+v, last %+v", first, last)
+	}
+	if last.Categories != len(model.w) || len(report.Assignments) != len(samples) {
+		t.Errorf("report doesn't match the model: %+v", report)
+	}
+	for i, a := range samples {
+		if _, j := model.Predict(a, false); j != report.Assignments[i] {
+			t.Errorf("sample %d assigned to %d, predicted %d", i, report.Assignments[i], j)
+		}
+	}
+}
+
+func TestFitAllSeed(t *testing.T) {
+	samples := make([][]float64, 40)
+	for i := range samples {
+		v := float64(i%10) / 10
+		samples[i] = []float64{v, 1 - v, v / 2, float64(i) / 40}
+	}
+	fit := func() [][]float64 {
+		model, err := NewFuzzyART(4, 0.85, 0.01, 0.5, WithSeed(3))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer model.Close()
+		if report := model.FitAll(samples, 1); len(report.Epochs) != 1 {
+			t.Fatalf("expected 1 epoch, got %d", len(report.Epochs))
+		}
+		return model.w
+	}
+	if !slices.EqualFunc(fit(), fit(), slices.Equal) {
+		t.Error("expected the same weights with the same seed")
+	}
+}
@@ -0,0 +1,54 @@
+package art
+
+import "unsafe"
+
+// Float is the constraint of the weight type of a model or a Predictor, see FuzzyARTOf.
+type Float interface {
+	~float32 | ~float64
+}
+
+// isFloat64 reports whether T stores float64 values, like the inputs and the persisted weights.
+func isFloat64[T Float]() bool {
+	return unsafe.Sizeof(T(0)) == 8
+}
+
+// valuesOf returns a as values of T: a itself, with its capacity, when T stores float64 values,
+// otherwise a rounded copy, e.g. the raw inputs of a float32 model.
+func valuesOf[T Float](a []float64) []T {
+	if isFloat64[T]() {
+		return unsafe.Slice((*T)(unsafe.Pointer(unsafe.SliceData(a))), cap(a))[:len(a)]
+	}
+	if a == nil {
+		return nil
+	}
+	v := make([]T, len(a))
+	for i, x := range a {
+		v[i] = T(x)
+	}
+	return v
+}
+
+// float64s works like valuesOf the other way around: v itself when T stores float64 values,
+// otherwise a copy, so the result must not be written to update v.
+func float64s[T Float](v []T) []float64 {
+	if isFloat64[T]() {
+		return unsafe.Slice((*float64)(unsafe.Pointer(unsafe.SliceData(v))), cap(v))[:len(v)]
+	}
+	if v == nil {
+		return nil
+	}
+	a := make([]float64, len(v))
+	for i, x := range v {
+		a[i] = float64(x)
+	}
+	return a
+}
+
+// cloneFloat64s returns a copy of v as float64 values, e.g. the weights of a category returned to the caller.
+func cloneFloat64s[T Float](v []T) []float64 {
+	a := make([]float64, len(v))
+	for i, x := range v {
+		a[i] = float64(x)
+	}
+	return a
+}
@@ -0,0 +1,175 @@
+package art
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"path/filepath"
+	"slices"
+	"testing"
+	"unsafe"
+)
+
+func TestValuesOf(t *testing.T) {
+	a := make([]float64, 3, 8)
+	a[0], a[1], a[2] = 0.1, 0.5, 1
+	if v := valuesOf[float64](a); unsafe.SliceData(v) != unsafe.SliceData(a) || cap(v) != cap(a) {
+		t.Error("expected the float64 values to alias the input")
+	}
+	if w := float64s(a); unsafe.SliceData(w) != unsafe.SliceData(a) {
+		t.Error("expected the float64 weights to alias the row")
+	}
+	v := valuesOf[float32](a)
+	if !slices.Equal(v, []float32{0.1, 0.5, 1}) {
+		t.Errorf("expected the rounded values, got %v", v)
+	}
+	if w := float64s(v); !slices.Equal(w, []float64{float64(float32(0.1)), 0.5, 1}) {
+		t.Errorf("expected the float32 values as float64, got %v", w)
+	}
+	if valuesOf[float32](nil) != nil || float64s[float32](nil) != nil {
+		t.Error("expected nil for nil")
+	}
+}
+
+func TestFuzzyARTOfFloat32(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	samples := make([][]float64, 500)
+	for i := range samples {
+		samples[i] = make([]float64, 10)
+		for k := range samples[i] {
+			samples[i][k] = rng.Float64()
+		}
+	}
+
+	exact, err := NewFuzzyART(10, 0.7, 0.01, 0.5, WithDeterministic(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer exact.Close()
+	compact, err := NewFuzzyARTOf[float32](10, 0.7, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer compact.Close()
+
+	mismatches := 0
+	for i, a := range samples[:400] {
+		activation, j := exact.Fit(a)
+		got, k := compact.Fit(a)
+		if k != j {
+			mismatches++
+		} else if math.Abs(got-activation) > 1e-5 {
+			t.Errorf("sample %d resonance %f by the float32 model, %f by the float64 one", i, got, activation)
+		}
+	}
+	if mismatches > 0 || compact.CategoryCount() != exact.CategoryCount() {
+		t.Fatalf("expected the float32 model to learn as the float64 one, got %d mismatches and %d categories, expected %d",
+			mismatches, compact.CategoryCount(), exact.CategoryCount())
+	}
+	for j := range exact.CategoryCount() {
+		for k, v := range compact.Weights(j) {
+			if w := exact.Weights(j)[k]; math.Abs(v-w) > 1e-5 {
+				t.Fatalf("category %d weight %d is %f, expected %f", j, k, v, w)
+			}
+		}
+	}
+
+	// the rows are half the size of the float64 ones, and padded to the float32 vector width
+	if size := unsafe.Sizeof(compact.w[0][0]); size != 4 {
+		t.Errorf("expected 4-byte weights, got %d", size)
+	}
+	if stride := compact.rowStride(); stride != 32 {
+		t.Errorf("expected a stride of 32 float32 values, got %d", stride)
+	}
+
+	predictions := compact.PredictBatch(samples)
+	for i, a := range samples {
+		activation, j := exact.Predict(a, false)
+		got, k := compact.Predict(a, false)
+		if k != j || predictions[i].Category != k || math.Abs(got-activation) > 1e-5 {
+			t.Errorf("sample %d predicted %d (%f) by the float32 model, %d (%f) by the float64 one", i, k, got, j, activation)
+		}
+	}
+}
+
+func TestLoadFuzzyARTOf(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.2, 0.3, 0.4}, {0.9, 0.8, 0.7, 0.6}, {0.15, 0.2, 0.35, 0.4}} {
+		model.Fit(a)
+	}
+	var buf bytes.Buffer
+	if err := model.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadFuzzyARTOf[float32](bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+	if loaded.CategoryCount() != model.CategoryCount() {
+		t.Fatalf("expected %d categories, got %d", model.CategoryCount(), loaded.CategoryCount())
+	}
+	for j := range model.CategoryCount() {
+		want := make([]float64, 0, 8)
+		for _, v := range model.Weights(j) {
+			want = append(want, float64(float32(v)))
+		}
+		if got := loaded.Weights(j); !slices.Equal(got, want) {
+			t.Errorf("category %d: expected the rounded weights %v, got %v", j, want, got)
+		}
+		if got := loaded.Count(j); got != model.Count(j) {
+			t.Errorf("category %d: expected count %d, got %d", j, model.Count(j), got)
+		}
+	}
+	if _, j := loaded.Predict([]float64{0.12, 0.2, 0.32, 0.4}, false); j != 0 {
+		t.Errorf("expected category 0, got %d", j)
+	}
+
+	// the float32 model saves float64 weights, loaded back exactly
+	buf.Reset()
+	if err := loaded.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadFuzzyART(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reloaded.Close()
+	for j := range loaded.CategoryCount() {
+		if !slices.Equal(reloaded.Weights(j), loaded.Weights(j)) {
+			t.Errorf("category %d: expected %v, got %v", j, loaded.Weights(j), reloaded.Weights(j))
+		}
+	}
+}
+
+func TestFuzzyARTOfFloat64Options(t *testing.T) {
+	lru := EvictorFunc(func(f *FuzzyART) int { return 0 })
+	if _, err := NewFuzzyARTOf[float32](2, 0.9, 0.01, 1, WithEvictor(2, lru)); err == nil {
+		t.Error("expected an error for an evictor on float32 weights")
+	}
+	path := filepath.Join(t.TempDir(), "weights.farw")
+	if _, err := NewFuzzyARTOf[float32](2, 0.9, 0.01, 1, WithMmapWeights(path)); err == nil {
+		t.Error("expected an error for memory-mapped float32 weights")
+	}
+
+	// the float32 models support the other options
+	model, err := NewFuzzyARTOf[float32](2, 0.9, 0.01, 1, WithMaxCategories(2, CapEvictLRU), WithTieBreak(TieBreakRandom), WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1}, {0.5, 0.5}, {0.9, 0.9}} {
+		model.Fit(a)
+	}
+	if model.CategoryCount() != 2 {
+		t.Errorf("expected the cap of 2 categories, got %d", model.CategoryCount())
+	}
+	if _, j := model.Predict([]float64{0.9, 0.9}, false); j != 1 {
+		t.Errorf("expected the newest category, got %d", j)
+	}
+}
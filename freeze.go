@@ -0,0 +1,15 @@
+package art
+
+// SetFrozen switches the model to pure inference and back:
+// while frozen, Fit and Predict with learning work like Predict without learning,
+// no weights are updated and no categories are created.
+// If the model has no categories, the category index is -1.
+// Explicit changes of the categories (e.g. Prune or DeleteCategory) are still applied.
+func (f *FuzzyARTOf[T]) SetFrozen(frozen bool) {
+	f.frozen = frozen
+}
+
+// Frozen reports whether learning is disabled, see SetFrozen.
+func (f *FuzzyARTOf[T]) Frozen() bool {
+	return f.frozen
+}
@@ -0,0 +1,43 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetFrozen(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	model.SetFrozen(true)
+	if _, j := model.Fit([]float64{0.1, 0.1, 0.1, 0.1}); j != -1 || len(model.w) != 0 {
+		t.Fatalf("expected a frozen empty model not to learn, got category %d", j)
+	}
+
+	model.SetFrozen(false)
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	w := slices.Clone(model.w[0])
+
+	model.SetFrozen(true)
+	if !model.Frozen() {
+		t.Error("expected the model to be frozen")
+	}
+	model.Fit([]float64{0.12, 0.1, 0.1, 0.1})
+	model.Predict([]float64{0.9, 0.9, 0.9, 0.9}, true)
+	model.FitLabeled([]float64{0.1, 0.1, 0.1, 0.1}, "a")
+	if len(model.w) != 1 || !slices.Equal(model.w[0], w) || model.Count(0) != 1 || model.Label(0) != "" {
+		t.Fatalf("expected a frozen model not to learn, got %d categories %v", len(model.w), model.w)
+	}
+	if resonance, j := model.Fit([]float64{0.1, 0.1, 0.1, 0.1}); j != 0 || resonance != 1 {
+		t.Errorf("expected a frozen model to predict category 0 with resonance 1, got %d (%f)", j, resonance)
+	}
+
+	model.SetFrozen(false)
+	model.Predict([]float64{0.9, 0.9, 0.9, 0.9}, true)
+	if len(model.w) != 2 {
+		t.Errorf("expected the unfrozen model to learn, got %d categories", len(model.w))
+	}
+}
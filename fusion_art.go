@@ -0,0 +1,229 @@
+package art
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/oblq/art/internal/simd"
+)
+
+// FusionChannel describes an input channel of FusionART.
+type FusionChannel struct {
+	// InputLen is the number of features of the channel.
+	InputLen int
+
+	// Rho is the vigilance of the channel, every channel must pass its own vigilance test.
+	Rho float64
+
+	// Alpha is the choice parameter of the channel.
+	Alpha float64
+
+	// Beta is the learning rate of the channel.
+	Beta float64
+
+	// Gamma is the contribution of the channel to the choice function.
+	// Gammas are usually chosen to sum to 1.
+	Gamma float64
+}
+
+type fusionActivation struct {
+	// fuzzy intersection of every channel
+	fi [][]float64
+	// L1 norm of the fuzzy intersection of every channel
+	fiNorm []float64
+	// activation value, choice function value
+	activation float64
+	// index of the category
+	j int
+}
+
+// FusionART implements Fusion ART (Tan et al., 2007), a multi-channel Fuzzy ART.
+// Every channel is complement coded and has its own vigilance, choice parameter,
+// learning rate and contribution to the choice function,
+// so that heterogeneous inputs (e.g.: pixels + metadata) are learned in a single resonance cycle.
+type FusionART struct {
+	channels []FusionChannel
+
+	// W stores the category prototypes, W[j][k] holds the complement-coded weights of category j channel k.
+	W [][][]float64
+
+	// t is the activation list - stores category activations
+	t []*fusionActivation
+}
+
+func NewFusionART(channels []FusionChannel) (*FusionART, error) {
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("at least one channel is required")
+	}
+	for k, c := range channels {
+		if c.InputLen <= 0 {
+			return nil, fmt.Errorf("channel %d: input length must be positive, got %d", k, c.InputLen)
+		}
+		if c.Rho < 0 || c.Rho > 1 {
+			return nil, fmt.Errorf("channel %d: vigilance parameter (rho) must be between 0 and 1, got %f", k, c.Rho)
+		}
+		if c.Alpha <= 0 {
+			return nil, fmt.Errorf("channel %d: choice parameter (alpha) must be positive, got %f", k, c.Alpha)
+		}
+		if c.Beta <= 0 || c.Beta > 1 {
+			return nil, fmt.Errorf("channel %d: learning rate (beta) must be between 0 and 1, got %f", k, c.Beta)
+		}
+		if c.Gamma < 0 {
+			return nil, fmt.Errorf("channel %d: contribution parameter (gamma) must not be negative, got %f", k, c.Gamma)
+		}
+	}
+
+	return &FusionART{
+		channels: slices.Clone(channels),
+		W:        make([][][]float64, 0),
+		t:        make([]*fusionActivation, 0),
+	}, nil
+}
+
+// Channels returns the channels configuration.
+func (f *FusionART) Channels() []FusionChannel {
+	return slices.Clone(f.channels)
+}
+
+// complementCode complement codes every channel.
+func (f *FusionART) complementCode(channels [][]float64) [][]float64 {
+	if len(channels) != len(f.channels) {
+		panic(fmt.Sprintf("expected %d channels, got %d", len(f.channels), len(channels)))
+	}
+
+	A := make([][]float64, len(channels))
+	for k, a := range channels {
+		if len(a) != f.channels[k].InputLen {
+			panic(fmt.Sprintf("channel %d: expected %d features, got %d", k, f.channels[k].InputLen, len(a)))
+		}
+		A[k] = simd.MakeAligned(2 * len(a))
+		complementCodeTo(A[k], a)
+	}
+	return A
+}
+
+// activateCategories computes the choice function T_j = sum_k gamma_k * |A_k ^ w_jk| / (alpha_k + |w_jk|)
+// and sorts the activation list, the best matching category first.
+func (f *FusionART) activateCategories(A [][]float64) {
+	for j, w := range f.W {
+		t := f.t[j]
+		t.j = j
+		t.activation = 0
+		for k, c := range f.channels {
+			var wNorm float64
+			t.fiNorm[k], wNorm = simd.Shared.FuzzyIntersectionNorm(A[k], w[k], t.fi[k])
+			t.activation += c.Gamma * t.fiNorm[k] / (c.Alpha + wNorm)
+		}
+	}
+
+	// Stable sort, in case of equal activation values older categories keep the priority.
+	slices.SortStableFunc(f.t, func(a, b *fusionActivation) int {
+		if a.activation > b.activation {
+			return -1
+		}
+		if a.activation < b.activation {
+			return 1
+		}
+		return 0
+	})
+}
+
+// resonance returns the gamma-weighted mean of the channel resonances,
+// and whether every channel passes its own vigilance test.
+func (f *FusionART) resonance(t *fusionActivation, aNorms []float64) (resonance float64, ok bool) {
+	ok = true
+	var gammas float64
+	for k, c := range f.channels {
+		m := f.normalizedActivation(t.fiNorm[k], aNorms[k])
+		if m < c.Rho {
+			ok = false
+		}
+		resonance += c.Gamma * m
+		gammas += c.Gamma
+	}
+	if gammas > 0 {
+		resonance /= gammas
+	}
+	return resonance, ok
+}
+
+// normalizedActivation returns the ratio of the fuzzy intersection L1 norm to the input vector L1 norm.
+func (f *FusionART) normalizedActivation(fiNorm, aNorm float64) float64 {
+	if fiNorm == 0 && aNorm == 0 {
+		return 1
+	}
+
+	return fiNorm / aNorm
+}
+
+func (f *FusionART) appendNewCategory(A [][]float64) int {
+	t := &fusionActivation{
+		fi:     make([][]float64, len(A)),
+		fiNorm: make([]float64, len(A)),
+	}
+	for k := range A {
+		t.fi[k] = simd.MakeAligned(len(A[k]))
+	}
+
+	f.W = append(f.W, A)
+	f.t = append(f.t, t)
+	return len(f.W) - 1
+}
+
+func (f *FusionART) aNorms(A [][]float64) []float64 {
+	norms := make([]float64, len(A))
+	for k := range A {
+		norms[k] = simd.Shared.SumFloat64(A[k])
+	}
+	return norms
+}
+
+// resonateOrReset searches the categories in activation order
+// for the first one passing the vigilance test of every channel and updates it,
+// if none passes a new category is created.
+func (f *FusionART) resonateOrReset(A [][]float64) (maxResonance float64, categoryIndex int) {
+	aNorms := f.aNorms(A)
+
+	for _, t := range f.t {
+		resonance, ok := f.resonance(t, aNorms)
+		if ok {
+			for k, c := range f.channels {
+				simd.Shared.UpdateFuzzyWeights(f.W[t.j][k], t.fi[k], c.Beta)
+			}
+			return resonance, t.j
+		}
+		maxResonance = math.Max(maxResonance, resonance)
+	}
+
+	categoryIndex = f.appendNewCategory(A)
+	return
+}
+
+// Fit implements the complete ART learning cycle on all the channels at once.
+// It returns the gamma-weighted mean of the channel resonances and the category index.
+func (f *FusionART) Fit(channels [][]float64) (categoryActivation float64, categoryIndex int) {
+	A := f.complementCode(channels)
+	f.activateCategories(A)
+	return f.resonateOrReset(A)
+}
+
+// Predict implements the recognition process with optional learning.
+// It returns the gamma-weighted mean of the channel resonances and the index of the best matching category,
+// or -1 if the model has no categories yet.
+func (f *FusionART) Predict(channels [][]float64, learn bool) (categoryActivation float64, categoryIndex int) {
+	A := f.complementCode(channels)
+	f.activateCategories(A)
+	if !learn {
+		if len(f.t) == 0 {
+			return 0, -1
+		}
+		resonance, _ := f.resonance(f.t[0], f.aNorms(A))
+		return resonance, f.t[0].j
+	}
+
+	return f.resonateOrReset(A)
+}
+
+// Close is a no-op, it exists for symmetry with FuzzyART.
+func (f *FusionART) Close() {}
@@ -0,0 +1,118 @@
+package art
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestFusionARTChannelVigilance(t *testing.T) {
+	// channel 0 is strict, channel 1 accepts any input
+	model, err := NewFusionART([]FusionChannel{
+		{InputLen: 2, Rho: 0.9, Alpha: 0.01, Beta: 1, Gamma: 0.5},
+		{InputLen: 1, Rho: 0, Alpha: 0.01, Beta: 1, Gamma: 0.5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	tests := []struct {
+		name     string
+		channels [][]float64
+		want     int
+	}{
+		{"first input", [][]float64{{0.1, 0.1}, {0.25}}, 0},
+		{"channel 1 differs", [][]float64{{0.1, 0.1}, {0.75}}, 0},
+		{"channel 0 differs", [][]float64{{0.9, 0.9}, {0.1}}, 1},
+		{"close to category 1", [][]float64{{0.88, 0.9}, {0.5}}, 1},
+	}
+	for _, tt := range tests {
+		if _, j := model.Fit(tt.channels); j != tt.want {
+			t.Errorf("%s: expected category %d, got %d", tt.name, tt.want, j)
+		}
+	}
+	if len(model.W) != 2 {
+		t.Errorf("expected 2 categories, got %d", len(model.W))
+	}
+	// fast learning on channel 1 learned the fuzzy AND of 0.25 and 0.75
+	if want := []float64{0.25, 0.25}; !slices.Equal(model.W[0][1], want) {
+		t.Errorf("expected channel 1 weights %v, got %v", want, model.W[0][1])
+	}
+
+	if _, j := model.Predict([][]float64{{0.5, 0.5}, {0.5}}, true); j != 2 {
+		t.Errorf("an input failing the channel 0 vigilance should create category 2, got %d", j)
+	}
+}
+
+func TestFusionARTGamma(t *testing.T) {
+	// category 0 matches the query on channel 0, category 1 on channel 1
+	categories := [][][]float64{{{0.1}, {0.9}}, {{0.9}, {0.1}}}
+	query := [][]float64{{0.1}, {0.1}}
+
+	tests := []struct {
+		gammas    [2]float64
+		want      int
+		resonance float64
+	}{
+		{[2]float64{1, 0}, 0, 1},
+		{[2]float64{0, 1}, 1, 1},
+		{[2]float64{0.8, 0.2}, 0, 0.8 + 0.2*0.2},
+	}
+	for _, tt := range tests {
+		model, err := NewFusionART([]FusionChannel{
+			{InputLen: 1, Rho: 0.9, Alpha: 0.01, Beta: 1, Gamma: tt.gammas[0]},
+			{InputLen: 1, Rho: 0.9, Alpha: 0.01, Beta: 1, Gamma: tt.gammas[1]},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, c := range categories {
+			if _, got := model.Fit(c); got != j {
+				t.Fatalf("gammas %v: expected category %d, got %d", tt.gammas, j, got)
+			}
+		}
+
+		resonance, j := model.Predict(query, false)
+		if j != tt.want || math.Abs(resonance-tt.resonance) > 1e-9 {
+			t.Errorf("gammas %v: expected category %d with resonance %f, got %d (%f)", tt.gammas, tt.want, tt.resonance, j, resonance)
+		}
+		if len(model.W) != 2 {
+			t.Errorf("gammas %v: Predict without learning must not create categories, got %d", tt.gammas, len(model.W))
+		}
+	}
+}
+
+func TestFusionARTEmptyPredict(t *testing.T) {
+	model, err := NewFusionART([]FusionChannel{{InputLen: 1, Rho: 0.5, Alpha: 0.01, Beta: 1, Gamma: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, j := model.Predict([][]float64{{0.5}}, false); j != -1 {
+		t.Errorf("empty model should return -1, got %d", j)
+	}
+}
+
+func TestNewFusionARTErrors(t *testing.T) {
+	valid := FusionChannel{InputLen: 1, Rho: 0.5, Alpha: 0.01, Beta: 1, Gamma: 1}
+	tests := []struct {
+		name   string
+		modify func(c *FusionChannel)
+	}{
+		{"input length", func(c *FusionChannel) { c.InputLen = 0 }},
+		{"rho", func(c *FusionChannel) { c.Rho = 1.1 }},
+		{"alpha", func(c *FusionChannel) { c.Alpha = 0 }},
+		{"beta", func(c *FusionChannel) { c.Beta = 0 }},
+		{"gamma", func(c *FusionChannel) { c.Gamma = -1 }},
+	}
+	for _, tt := range tests {
+		c := valid
+		tt.modify(&c)
+		if _, err := NewFusionART([]FusionChannel{valid, c}); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+	if _, err := NewFusionART(nil); err == nil {
+		t.Error("expected an error without channels")
+	}
+}
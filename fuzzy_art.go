@@ -1,18 +1,21 @@
 package art
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"math/rand/v2"
 	"runtime"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/oblq/art/internal/simd"
 )
 
-type fuzzyActivation struct {
+type fuzzyActivation[T Float] struct {
 	// fuzzy intersection
-	fi []float64
+	fi []T
 	// L1 norm of the fuzzy intersection
 	fiNorm float64
 	// L1 norm of the relative category weights
@@ -21,9 +24,70 @@ type fuzzyActivation struct {
 	activation float64
 	// index of the category weights
 	j int
+	// random key used by TieBreakRandom
+	tieKey uint64
 }
 
-type FuzzyART struct {
+// category stores the per-category state kept alongside the weights, w[j] <-> categories[j].
+type category struct {
+	// id is the stable identifier of the category, see CategoryID
+	id uint64
+	// L1 norm of the category weights, kept up to date on every weights update
+	wNorm float64
+	// number of samples learned by the category
+	count int
+	// optional label, see ImportClusters and FitLabeled
+	label string
+	// last time the category learned an input, see ArchiveUnused
+	lastUsed time.Time
+	// created is the time the category was created, see CategoryStats
+	created time.Time
+	// rho is the vigilance of the category, 0 for the vigilance of the model, see SetCategoryVigilance
+	rho float64
+	// usage is the decayed sample count at model age usageAt, see WithDecay
+	usage   float64
+	usageAt int
+	// born is the model age when the category was created, see Prune
+	born int
+	// votes counts the labels of the learned samples, nil until the first FitLabeled
+	votes map[string]int
+	// shared marks weights shared with a snapshot, copied before being written, see Snapshot
+	shared bool
+}
+
+// FuzzyARTOf is a Fuzzy ART model storing its weights, and computing the fuzzy intersections, as values of T:
+// a FuzzyARTOf[float32] halves the memory of the weights of a large model and runs the float32 kernels
+// of the SIMD backend, which hold twice the values per vector register of the float64 ones.
+// The inputs, the activations and the persisted weights are float64 in any case, float32 rounds the weights
+// and the norms (about 7 significant digits), which can flip the winner near ties.
+// The memory-mapped weights, the Evictor of WithEvictor and the wrappers of a *FuzzyART, e.g. ConcurrentFuzzyART,
+// require float64 weights.
+type FuzzyARTOf[T Float] struct {
+	fuzzyState
+
+	// w is the weight matrix - stores category prototypes, see Weights
+	w [][]T
+
+	// t is the activation list - stores category activations
+	t []*fuzzyActivation[T]
+	// fiNorms is the buffer of the fuzzy intersection norms of activateCategories
+	fiNorms []T
+	// input is the buffer of the complement-coded input of the learning cycle, see learningInput
+	input []T
+	// norm is the buffer of the fused kernel computing inputNorm
+	norm [1]T
+	// slab is the storage of the rows of the last categories, see newRow
+	slab []T
+
+	// scratch pools the buffers of Predict without learning, see winner.
+	scratch sync.Pool
+}
+
+// FuzzyART is the Fuzzy ART model with float64 weights.
+type FuzzyART = FuzzyARTOf[float64]
+
+// fuzzyState holds the state of a FuzzyARTOf that doesn't depend on the weight type, set by the options.
+type fuzzyState struct {
 	workerPool chan struct{}
 	batchSize  int
 	wg         sync.WaitGroup
@@ -64,35 +128,154 @@ type FuzzyART struct {
 	// M is the number of features of the input, its dimensionality.
 	M int
 
-	// W is the weight matrix - stores category prototypes
-	W [][]float64
+	// categories stores the per-category state, parallel to w
+	categories []category
 
-	// t is the activation list - stores category activations
-	t []*fuzzyActivation
+	// coarse is the coarse index, category indexes sorted by upper bound of their activation,
+	// rebuilt on demand when dirty, see PredictWithin.
+	coarse      []int
+	coarseDirty bool
+
+	// featureNames are the optional names of the input features, see WithFeatureNames.
+	featureNames []string
+
+	// signature identifies the preprocessing pipeline of the inputs, see WithInputSignature.
+	signature InputSignature
+
+	// metadata is the optional per-category metadata store, see WithMetadataStore.
+	metadata MetadataStore
+	// metadataErr is the first error returned by the store while deleting the metadata of removed categories,
+	// returned by the next metadata operation.
+	metadataErr error
+
+	// maxCategories is the optional category cap, 0 means unlimited, see WithMaxCategories.
+	maxCategories int
+	capStrategy   CapStrategy
+	// evictor replaces the eviction policy of the cap, nil unless WithEvictor is used.
+	evictor Evictor
+
+	// latency tracks Fit and Predict latencies, nil unless WithLatencyStats is used.
+	latency *latencyStats
+
+	// tieBreak is the policy choosing among categories with equal activations, see WithTieBreak.
+	tieBreak TieBreak
+	// seed of rng, see WithSeed
+	seed uint64
+	// rng is the random source of the randomized features, created on first use.
+	rng *rand.Rand
+
+	// priorStrength weights the choice function by category usage, see WithUsagePrior.
+	priorStrength float64
+
+	// checkpoint periodically writes the model, nil unless WithCheckpoint is used.
+	checkpoint *checkpointer
+
+	// matchTracking enables match tracking in FitLabeled, raising the vigilance by epsilon, see WithMatchTracking.
+	matchTracking bool
+	epsilon       float64
+
+	// commitmentRate is the learning rate committing new categories, 0 for fast commitment, see WithCommitmentRate.
+	commitmentRate float64
+
+	// schedule sets the vigilance of FitAll, nil unless WithVigilanceSchedule is used.
+	schedule VigilanceSchedule
+
+	// encoder replaces complement coding, nil unless WithEncoder is used.
+	encoder Encoder
+
+	// frozen disables learning, see SetFrozen.
+	frozen bool
+
+	// deterministic computes the norms with the sequential kernels, see WithDeterministic.
+	deterministic bool
+
+	// lastID is the last category ID assigned, see CategoryID.
+	lastID uint64
+
+	// age counts the learning steps, inputs learned or committed to new categories, see Prune.
+	age int
+
+	// halfLife is the half-life of the category usage in learning steps, 0 disables the decay, see WithDecay.
+	halfLife int
+	minUsage float64
+	// nextSweep is the model age of the next eviction of the decayed categories
+	nextSweep int
+
+	// hooks observe the training, see AddHooks.
+	hooks []Hooks
+	// learnedSamples counts the inputs learned since the hooks were added, see Hooks.OnSample.
+	learnedSamples int
+	// history records the category of the learned inputs, nil unless WithAssignmentHistory is used.
+	history []Assignment
+
+	// mmap backs the rows of w with a memory-mapped file at mmapPath, nil unless WithMmapWeights is used.
+	mmap     *mmapWeights
+	mmapPath string
+
+	// compactions counts the shifts of the category rows (compact, RestoreMatching),
+	// so that the wrappers tracking rows (e.g. Primary) detect them.
+	compactions int
+}
+
+func NewFuzzyART(inputLen int, rho float64, alpha float64, beta float64, opts ...Option) (*FuzzyART, error) {
+	return NewFuzzyARTOf[float64](inputLen, rho, alpha, beta, opts...)
 }
 
-func NewFuzzyART(inputLen int, rho float64, alpha float64, beta float64) (*FuzzyART, error) {
+// NewFuzzyARTOf works like NewFuzzyART for a model with weights of type T, e.g. NewFuzzyARTOf[float32].
+func NewFuzzyARTOf[T Float](inputLen int, rho float64, alpha float64, beta float64, opts ...Option) (*FuzzyARTOf[T], error) {
+	f := new(FuzzyARTOf[T])
+	if err := f.init(inputLen, rho, alpha, beta, opts...); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// init validates the hyperparameters and resets f to an empty model.
+func (f *FuzzyARTOf[T]) init(inputLen int, rho float64, alpha float64, beta float64, opts ...Option) error {
 	if rho < 0 || rho > 1 {
-		return nil, fmt.Errorf("vigilance parameter (rho) must be between 0 and 1, got %f", rho)
+		return fmt.Errorf("vigilance parameter (rho) must be between 0 and 1, got %f", rho)
 	}
 	if alpha <= 0 {
-		return nil, fmt.Errorf("choice parameter (alpha) must be positive, got %f", alpha)
+		return fmt.Errorf("choice parameter (alpha) must be positive, got %f", alpha)
 	}
 	if beta <= 0 || beta > 1 {
-		return nil, fmt.Errorf("learning rate (beta) must be between 0 and 1, got %f", beta)
+		return fmt.Errorf("learning rate (beta) must be between 0 and 1, got %f", beta)
 	}
 
-	return &FuzzyART{
-		workerPool: make(chan struct{}, runtime.NumCPU()),
-		batchSize:  64,
-		wg:         sync.WaitGroup{},
-		rho:        rho,
-		alpha:      alpha,
-		beta:       beta,
-		M:          inputLen,
-		W:          make([][]float64, 0),
-		t:          make([]*fuzzyActivation, 0),
-	}, nil
+	*f = FuzzyARTOf[T]{
+		fuzzyState: fuzzyState{
+			workerPool: make(chan struct{}, runtime.NumCPU()),
+			batchSize:  64,
+			wg:         sync.WaitGroup{},
+			rho:        rho,
+			alpha:      alpha,
+			beta:       beta,
+			M:          inputLen,
+			categories: make([]category, 0),
+		},
+		w: make([][]T, 0),
+		t: make([]*fuzzyActivation[T], 0),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&f.fuzzyState); err != nil {
+			return err
+		}
+	}
+
+	return f.initWeights()
+}
+
+// initWeights sets up the storage of the weights once the options are applied,
+// rejecting the options that require float64 weights.
+func (f *FuzzyARTOf[T]) initWeights() error {
+	if _, ok := any(f).(*FuzzyART); !ok && f.evictor != nil {
+		return fmt.Errorf("evictors require float64 weights, got %T", T(0))
+	}
+	if f.mmapPath != "" {
+		return f.mapWeights()
+	}
+	return nil
 }
 
 // complementCode creates complement-coded representation of input vector.
@@ -100,40 +283,80 @@ func NewFuzzyART(inputLen int, rho float64, alpha float64, beta float64) (*Fuzzy
 // to prevent the "category proliferation problem."
 // Complement coding achieve normalization while preserving amplitude information.
 // Inputs preprocessed in complement coding are automatically normalized.
-func (f *FuzzyART) complementCode(a []float64) []float64 {
-	A := make([]float64, len(a)*2)
+// The encoder set by WithEncoder, if any, replaces complement coding.
+func (f *FuzzyARTOf[T]) complementCode(a []float64) []T {
+	if f.encoder != nil {
+		return f.mustEncode(a)
+	}
+	A := simd.MakeAlignedOf[T](len(a) * 2)
+	complementCodeTo(A, a)
+	return A
+}
+
+// complementCodeTo stores the complement coding of a, [a, 1-a], in A.
+func complementCodeTo[T Float](A []T, a []float64) {
 	for i, v := range a {
-		A[i] = v
-		A[i+len(a)] = 1 - v
+		A[i] = T(v)
+		A[i+len(a)] = T(1 - v)
 	}
+}
 
-	return A
+// learningInput works like complementCode, but without an Encoder it complement-codes a
+// in a buffer of the model reused by every learning cycle, overwritten by the next call:
+// the learning cycle only reads the input, and copies it in the row of a new category.
+// The activation uses a through the fused kernel anyway, see intersectionNorms.
+func (f *FuzzyARTOf[T]) learningInput(a []float64) []T {
+	if f.encoder != nil {
+		return f.mustEncode(a)
+	}
+	if len(f.input) != 2*f.M {
+		f.input = f.newVector()
+	}
+	complementCodeTo(f.input, a)
+	return f.input
 }
 
 // activateCategories implements the recognition field functionality
 // by computing activation values for each category based on the input vector.
 // The sorting process also implicitly handles lateral inhibition by prioritizing
 // the category with the highest activation, thereby inhibiting others.
-func (f *FuzzyART) activateCategories(A []float64) {
+func (f *FuzzyARTOf[T]) activateCategories(A []T) {
+	f.activateCategoriesCtx(context.Background(), A)
+}
+
+// activateCategoriesCtx works like activateCategories, but stops spawning category batches
+// once ctx is done, returning its error. The activation list is then incomplete.
+func (f *FuzzyARTOf[T]) activateCategoriesCtx(ctx context.Context, A []T) error {
+	if len(f.fiNorms) < len(f.w) {
+		f.fiNorms = make([]T, len(f.w), cap(f.w))
+	}
+	// a single batch is processed by the calling goroutine, without allocating one
+	if len(f.w) <= f.batchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		f.categoryChoice(A, 0, len(f.w))
+		f.sortCategoriesByActivation()
+		return nil
+	}
+
 	categoryChoice := func(startIndex, endIndex int) {
 		defer func() {
 			// release the worker
 			<-f.workerPool
 			f.wg.Done()
 		}()
-
-		for i, w := range f.W[startIndex:endIndex] {
-			t := f.t[startIndex+i]
-			t.j = startIndex + i
-			t.fiNorm, t.wNorm = simd.Shared.FuzzyIntersectionNorm(A, w, t.fi)
-			t.activation = t.fiNorm / (f.alpha + t.wNorm)
-		}
+		f.categoryChoice(A, startIndex, endIndex)
 	}
 
-	for jStart := 0; jStart < len(f.W); jStart += f.batchSize {
+	for jStart := 0; jStart < len(f.w); jStart += f.batchSize {
+		if err := ctx.Err(); err != nil {
+			f.wg.Wait()
+			return err
+		}
 		jEnd := jStart + f.batchSize
-		if jEnd > len(f.W) {
-			jEnd = len(f.W)
+		if jEnd > len(f.w) {
+			jEnd = len(f.w)
 		}
 
 		f.wg.Add(1)
@@ -146,13 +369,38 @@ func (f *FuzzyART) activateCategories(A []float64) {
 
 	f.wg.Wait()
 	f.sortCategoriesByActivation()
+	return nil
 }
 
-func (f *FuzzyART) sortCategoriesByActivation() {
-	slices.SortFunc(f.t, func(a, b *fuzzyActivation) int {
-		// In case of equal activation values, sort by category index,
-		// because older categories must have the priority.
+// categoryChoice computes the activation of the categories startIndex to endIndex.
+func (f *FuzzyARTOf[T]) categoryChoice(A []T, startIndex, endIndex int) {
+	if startIndex == endIndex {
+		return
+	}
+	fiNorms := f.fiNorms[startIndex:endIndex]
+	f.intersectionNorms(A, startIndex, fiNorms, f.t[startIndex].fi)
+	for i, fiNorm := range fiNorms {
+		t := f.t[startIndex+i]
+		t.j = startIndex + i
+		t.fiNorm, t.wNorm = float64(fiNorm), f.categories[t.j].wNorm
+		t.activation = t.fiNorm / (f.alpha + t.wNorm) * f.usagePrior(t.j)
+	}
+}
+
+func (f *FuzzyARTOf[T]) sortCategoriesByActivation() {
+	if f.tieBreak == TieBreakRandom {
+		for _, t := range f.t {
+			t.tieKey = f.random().Uint64()
+		}
+	}
+
+	slices.SortFunc(f.t, func(a, b *fuzzyActivation[T]) int {
 		if a.activation == b.activation {
+			if c := f.breakTie(a, b); c != 0 {
+				return c
+			}
+			// By default, or when the tie-break policy ties too, sort by category index,
+			// because older categories must have the priority.
 			if a.j < b.j {
 				return -1
 			} else {
@@ -167,7 +415,7 @@ func (f *FuzzyART) sortCategoriesByActivation() {
 }
 
 // normalizedActivation returns the ratio of the fuzzy intersection L1 norm to the input vector L1 norm.
-func (f *FuzzyART) normalizedActivation(fiNorm, aNorm float64) float64 {
+func (f *FuzzyARTOf[T]) normalizedActivation(fiNorm, aNorm float64) float64 {
 	if fiNorm == 0 && aNorm == 0 {
 		return 1
 	}
@@ -175,12 +423,81 @@ func (f *FuzzyART) normalizedActivation(fiNorm, aNorm float64) float64 {
 	return fiNorm / aNorm
 }
 
-func (f *FuzzyART) appendNewCategory(A []float64) int {
-	f.W = append(f.W, A)
-	f.t = append(f.t, &fuzzyActivation{
-		fi: make([]float64, len(f.W[0])),
+func (f *FuzzyARTOf[T]) appendNewCategory(A []T) int {
+	if f.mmap != nil {
+		A = f.appendRow(A)
+	} else {
+		row := f.newRow()
+		copy(row, A)
+		A = row[:len(A)]
+	}
+	f.w = append(f.w, A)
+	f.t = append(f.t, &fuzzyActivation[T]{
+		fi: simd.MakeAlignedOf[T](len(f.w[0])),
 	})
-	return len(f.W) - 1
+	f.lastID++
+	now := time.Now()
+	f.categories = append(f.categories, category{id: f.lastID, wNorm: float64(f.kernels().SumFloat64(A)), count: 1, lastUsed: now, created: now, born: f.age, usage: 1, usageAt: f.age})
+	f.age++
+	f.coarseDirty = true
+	return len(f.w) - 1
+}
+
+// learn updates the weights of category j toward the fuzzy intersection fi with learning rate beta.
+func (f *FuzzyARTOf[T]) learn(j int, fi []T, beta float64) {
+	f.unshare(j)
+	f.kernels().UpdateFuzzyWeights(f.w[j], fi, T(beta))
+	f.categories[j].wNorm = float64(f.kernels().SumFloat64(f.w[j]))
+	f.categories[j].count++
+	f.categories[j].lastUsed = time.Now()
+	if f.halfLife > 0 {
+		f.categories[j].usage, f.categories[j].usageAt = f.decayedUsage(j)+1, f.age
+	}
+	f.age++
+	f.coarseDirty = true
+}
+
+// setWeights overwrites the weights of category j.
+func (f *FuzzyARTOf[T]) setWeights(j int, w []T) {
+	f.unshare(j)
+	copy(f.w[j], w)
+	f.categories[j].wNorm = float64(f.kernels().SumFloat64(f.w[j]))
+	f.coarseDirty = true
+}
+
+// compact removes the categories for which keep returns false,
+// preserving the order of the remaining ones.
+// It returns the index remapping: old index -> new index, -1 for removed categories.
+func (f *FuzzyARTOf[T]) compact(keep func(j int) bool) (remap []int) {
+	f.compactions++
+	remap = make([]int, len(f.w))
+	n := 0
+	for j, w := range f.w {
+		if !keep(j) {
+			remap[j] = -1
+			continue
+		}
+		if f.mmap != nil {
+			// rows alias the mapping in order, move the weights instead
+			copy(f.w[n], w)
+		} else {
+			f.w[n] = w
+		}
+		f.categories[n] = f.categories[j]
+		remap[j] = n
+		n++
+	}
+
+	clear(f.w[n:])
+	f.w = f.w[:n]
+	if f.mmap != nil {
+		f.mmap.setCount(n)
+	}
+	f.categories = f.categories[:n]
+	f.coarseDirty = true
+	// activations are recomputed on every input, any n of them will do
+	f.t = f.t[:n]
+	return remap
 }
 
 // resonateOrReset implements the resonance or reset logic.
@@ -190,47 +507,122 @@ func (f *FuzzyART) appendNewCategory(A []float64) int {
 // and the next best category is tested,
 // continuing until a suitable category is found or all are exhausted
 // in which case a new category is created.
-func (f *FuzzyART) resonateOrReset(A []float64) (maxResonance float64, categoryIndex int) {
-	aNorm := simd.Shared.SumFloat64(A)
+// The category learns with learning rate beta.
+func (f *FuzzyARTOf[T]) resonateOrReset(A []T, beta float64) (maxResonance float64, categoryIndex int) {
+	aNorm := f.inputNorm(A)
 
 	for _, t := range f.t {
 		resonance := f.normalizedActivation(t.fiNorm, aNorm)
-		if resonance >= f.rho {
-			simd.Shared.UpdateFuzzyWeights(f.W[t.j], t.fi, f.beta)
+		if resonance >= f.vigilance(t.j) {
+			f.learnInput(t.j, A, t.fi, beta)
 			return resonance, t.j
 		}
 		maxResonance = math.Max(maxResonance, resonance)
 	}
 
+	if f.maxCategories > 0 && len(f.w) >= f.maxCategories {
+		return f.resolveCap(A, aNorm, beta)
+	}
+
 	// If no category meets the vigilance criterion, create a new category.
 	// Fast commitment option, directly copy the input vector as the new category.
-	categoryIndex = f.appendNewCategory(A)
+	categoryIndex = f.commitNewCategory(A)
 	return
 }
 
+// trackMatch implements the resonance or reset logic with match tracking.
+// Categories passing the vigilance test are offered to accept,
+// when a category is refused the vigilance is raised just above its resonance (by epsilon),
+// so that the search continues only among the categories matching the input better.
+// If no category is accepted a new one is created, matching the input with resonance 1.
+// The raised vigilance only lasts for the current input.
+func (f *FuzzyARTOf[T]) trackMatch(A []T, epsilon float64, accept func(j int) bool) (resonance float64, categoryIndex int, created bool) {
+	aNorm := f.inputNorm(A)
+	// raised is the tracked vigilance, once a category is refused
+	raised, tracking := 0.0, false
+
+	for _, t := range f.t {
+		resonance = f.normalizedActivation(t.fiNorm, aNorm)
+		rho := f.vigilance(t.j)
+		if tracking {
+			rho = max(raised, f.categories[t.j].rho)
+		}
+		if resonance < rho {
+			continue
+		}
+		if accept(t.j) {
+			f.learnInput(t.j, A, t.fi, f.beta)
+			return resonance, t.j, false
+		}
+		raised, tracking = resonance+epsilon, true
+	}
+
+	return 1, f.commitNewCategory(A), true
+}
+
 // Fit implements the complete ART learning cycle.
-func (f *FuzzyART) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
-	A := f.complementCode(a)
-	f.activateCategories(A)
-	return f.resonateOrReset(A)
+func (f *FuzzyARTOf[T]) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	categoryActivation, categoryIndex, _ = f.FitCtx(context.Background(), a)
+	return categoryActivation, categoryIndex
 }
 
 // Predict implements the recognition process with optional learning.
 // It returns the category activation value and the index of the best matching category.
 // If learn is true, it also updates the weights of the matching category.
-func (f *FuzzyART) Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int) {
-	A := f.complementCode(a)
-	f.activateCategories(A)
-	if !learn {
-		aNorm := simd.Shared.SumFloat64(A)
-		activation := f.t[0]
-		categoryActivation = f.normalizedActivation(activation.fiNorm, aNorm)
-		return categoryActivation, activation.j
+// Without learning, Predict uses per-call buffers and can run concurrently with other predictions
+// without learning, except with TieBreakRandom. If the model has no categories yet, the category index is -1.
+func (f *FuzzyARTOf[T]) Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int) {
+	categoryActivation, categoryIndex, _ = f.PredictCtx(context.Background(), a, learn)
+	return categoryActivation, categoryIndex
+}
+
+// activations returns the choice function value of every category for the input, indexed by category.
+func (f *FuzzyARTOf[T]) activations(a []float64) []float64 {
+	f.activateCategories(f.complementCode(a))
+	out := make([]float64, len(f.t))
+	for _, t := range f.t {
+		out[t.j] = t.activation
 	}
+	return out
+}
 
-	return f.resonateOrReset(A)
+// reconstructionError returns the L1 distance, in the original feature space,
+// between the input and the hyper-rectangle described by the complement-coded weights w,
+// that is the distance between the input and its nearest point in the category.
+// Inputs inside the hyper-rectangle have zero error.
+func (f *FuzzyARTOf[T]) reconstructionError(a, w []float64) float64 {
+	var e float64
+	for i, v := range a {
+		lower, upper := w[i], 1-w[i+len(a)]
+		if v < lower {
+			e += lower - v
+		} else if v > upper {
+			e += v - upper
+		}
+	}
+	return e
 }
 
-func (f *FuzzyART) Close() {
+// PredictWithError works like Predict without learning, and also returns
+// the L1 reconstruction error between the input and the winning prototype in the original space.
+// The error can be used directly as an anomaly score, or to rank the most atypical members of a category.
+// If the model has no categories yet, the category index is -1 and the error is M.
+func (f *FuzzyARTOf[T]) PredictWithError(a []float64) (categoryActivation float64, categoryIndex int, reconstructionError float64) {
+	if len(f.w) == 0 {
+		return 0, -1, float64(f.M)
+	}
+
+	categoryActivation, categoryIndex = f.Predict(a, false)
+	if f.encoder != nil {
+		// the error is measured on the first half of the encoded input, the input itself with complement coding
+		a = float64s(f.mustEncode(a)[:f.M])
+	}
+	return categoryActivation, categoryIndex, f.reconstructionError(a, float64s(f.w[categoryIndex]))
+}
+
+func (f *FuzzyARTOf[T]) Close() {
 	close(f.workerPool)
+	if f.mmap != nil {
+		f.mmap.close()
+	}
 }
@@ -0,0 +1,45 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPredictWithError(t *testing.T) {
+	model, err := NewFuzzyART(2, 0.5, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	if _, j, e := model.PredictWithError([]float64{0.3, 0.3}); j != -1 || e != 2 {
+		t.Errorf("expected category -1 and error M = 2 on an empty model, got %d and %f", j, e)
+	}
+
+	// with fast learning the category grows to the box [0.2, 0.4] x [0.2, 0.4]
+	model.Fit([]float64{0.2, 0.2})
+	model.Fit([]float64{0.4, 0.4})
+	if model.CategoryCount() != 1 {
+		t.Fatalf("expected 1 category, got %d", model.CategoryCount())
+	}
+
+	tests := []struct {
+		name string
+		a    []float64
+		want float64
+	}{
+		{"inside", []float64{0.3, 0.3}, 0},
+		{"corner", []float64{0.4, 0.2}, 0},
+		{"above", []float64{0.5, 0.3}, 0.1},
+		{"outside on both axes", []float64{0.1, 0.6}, 0.3},
+	}
+	for _, tt := range tests {
+		_, j, e := model.PredictWithError(tt.a)
+		if j != 0 || math.Abs(e-tt.want) > 1e-12 {
+			t.Errorf("%s: expected category 0 with error %f, got %d with %f", tt.name, tt.want, j, e)
+		}
+	}
+	if model.CategoryCount() != 1 {
+		t.Errorf("PredictWithError must not learn, got %d categories", model.CategoryCount())
+	}
+}
@@ -2,4 +2,8 @@ module github.com/oblq/art
 
 go 1.23.0
 
-require golang.org/x/sys v0.31.0
+require (
+	go.etcd.io/bbolt v1.4.0
+	golang.org/x/sys v0.31.0
+	golang.org/x/term v0.30.0
+)
@@ -0,0 +1,116 @@
+package art
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/oblq/art/internal/hdf5"
+)
+
+// The HDF5 model layout written by WriteHDF5 has three datasets in the root group:
+//
+//	/weights  [categories, 2M]  complement-coded prototype rows
+//	/count    [categories]      samples learned by each category
+//	/params   [3]               rho, alpha, beta
+//
+// It can be read in Python with h5py, e.g. h5py.File("model.h5")["weights"][:].
+
+// WriteHDF5 writes the model weights, category counts and parameters in an HDF5 file.
+// Other options and the category state beyond the counts are not written, use Save for a complete copy.
+func (f *FuzzyARTOf[T]) WriteHDF5(w io.Writer) error {
+	weights := make([]float64, 0, len(f.w)*2*f.M)
+	for _, wj := range f.w {
+		weights = append(weights, float64s(wj)...)
+	}
+	count := make([]float64, len(f.categories))
+	for j, c := range f.categories {
+		count[j] = float64(c.count)
+	}
+	return hdf5.Write(w,
+		hdf5.Dataset{Name: "weights", Shape: []int{len(f.w), 2 * f.M}, Data: weights},
+		hdf5.Dataset{Name: "count", Shape: []int{len(f.w)}, Data: count},
+		hdf5.Dataset{Name: "params", Shape: []int{3}, Data: []float64{f.rho, f.alpha, f.beta}},
+	)
+}
+
+// ReadHDF5FuzzyART rebuilds a model from an HDF5 file in the layout of WriteHDF5.
+// The count dataset is optional, so that prototypes computed elsewhere can be imported,
+// without it every category counts one sample.
+func ReadHDF5FuzzyART(r io.ReaderAt, opts ...Option) (*FuzzyART, error) {
+	params, err := hdf5.ReadDataset(r, "params")
+	if err != nil {
+		return nil, fmt.Errorf("reading HDF5 parameters: %w", err)
+	}
+	if len(params.Data) != 3 {
+		return nil, fmt.Errorf("HDF5 params must be rho, alpha and beta, got %d values", len(params.Data))
+	}
+	weights, err := hdf5.ReadDataset(r, "weights")
+	if err != nil {
+		return nil, fmt.Errorf("reading HDF5 weights: %w", err)
+	}
+	if len(weights.Shape) != 2 || weights.Shape[1] == 0 || weights.Shape[1]%2 != 0 {
+		return nil, fmt.Errorf("HDF5 weights must be a categories x 2M array, got shape %v", weights.Shape)
+	}
+	count, err := hdf5.ReadDataset(r, "count")
+	if err != nil && !errors.Is(err, hdf5.ErrNotFound) {
+		return nil, fmt.Errorf("reading HDF5 counts: %w", err)
+	}
+	if err == nil && len(count.Data) != weights.Shape[0] {
+		return nil, fmt.Errorf("expected the count of %d categories, got %d", weights.Shape[0], len(count.Data))
+	}
+
+	inputLen := weights.Shape[1] / 2
+	f, err := NewFuzzyART(inputLen, params.Data[0], params.Data[1], params.Data[2], opts...)
+	if err != nil {
+		return nil, err
+	}
+	for j := range weights.Shape[0] {
+		w := make([]float64, 2*inputLen)
+		copy(w, weights.Data[j*2*inputLen:])
+		f.appendNewCategory(w)
+		if count.Data != nil {
+			f.categories[j].count = int(count.Data[j])
+		}
+	}
+	return f, nil
+}
+
+// ReadHDF5Samples reads a dataset of samples, e.g. "train/images", as rows of the first dimension,
+// flattening the others: an MNIST-like [N, 28, 28] array returns N samples of 784 features.
+// Integer values are converted as they are, inputs must be normalized to [0, 1] before fitting them.
+func ReadHDF5Samples(r io.ReaderAt, path string) ([][]float64, error) {
+	ds, err := hdf5.ReadDataset(r, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(ds.Shape) == 0 {
+		return nil, fmt.Errorf("HDF5 dataset %q must have at least one dimension, got a scalar", path)
+	}
+	n := ds.Shape[0]
+	samples := make([][]float64, n)
+	if n == 0 {
+		return samples, nil
+	}
+	dim := len(ds.Data) / n
+	for i := range samples {
+		samples[i] = ds.Data[i*dim : (i+1)*dim : (i+1)*dim]
+	}
+	return samples, nil
+}
+
+// WriteHDF5Samples writes the samples as a [N, M] float64 dataset in the root group of an HDF5 file.
+func WriteHDF5Samples(w io.Writer, name string, samples [][]float64) error {
+	var dim int
+	if len(samples) > 0 {
+		dim = len(samples[0])
+	}
+	data := make([]float64, 0, len(samples)*dim)
+	for i, s := range samples {
+		if len(s) != dim {
+			return fmt.Errorf("sample %d must be %d features, got %d", i, dim, len(s))
+		}
+		data = append(data, s...)
+	}
+	return hdf5.Write(w, hdf5.Dataset{Name: name, Shape: []int{len(samples), dim}, Data: data})
+}
@@ -0,0 +1,107 @@
+package art
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/oblq/art/internal/hdf5"
+)
+
+func TestHDF5RoundTrip(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, v := range []float64{0.1, 0.15, 0.9, 0.12} {
+		model.Fit([]float64{v, v, v, v})
+	}
+
+	var buf bytes.Buffer
+	if err := model.WriteHDF5(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadHDF5FuzzyART(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+
+	if loaded.M != 4 || loaded.rho != 0.8 || loaded.alpha != 0.01 || loaded.beta != 0.5 || len(loaded.w) != len(model.w) {
+		t.Fatalf("loaded model differs")
+	}
+	for j := range model.w {
+		if !slices.Equal(loaded.w[j], model.w[j]) || loaded.Count(j) != model.Count(j) {
+			t.Errorf("category %d differs", j)
+		}
+	}
+}
+
+func TestReadHDF5FuzzyARTWithoutCount(t *testing.T) {
+	var buf bytes.Buffer
+	err := hdf5.Write(&buf,
+		hdf5.Dataset{Name: "weights", Shape: []int{1, 4}, Data: []float64{0.2, 0.3, 0.7, 0.6}},
+		hdf5.Dataset{Name: "params", Shape: []int{3}, Data: []float64{0.7, 0.01, 1}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := ReadHDF5FuzzyART(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	if len(model.w) != 1 || model.M != 2 {
+		t.Fatalf("unexpected model: %d categories, M %d", len(model.w), model.M)
+	}
+
+	buf.Reset()
+	if err := hdf5.Write(&buf, hdf5.Dataset{Name: "weights", Shape: []int{1, 4}, Data: make([]float64, 4)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadHDF5FuzzyART(bytes.NewReader(buf.Bytes())); !errors.Is(err, hdf5.ErrNotFound) {
+		t.Errorf("expected a not found error without params, got %v", err)
+	}
+}
+
+func TestHDF5Samples(t *testing.T) {
+	samples := [][]float64{{0, 0.5, 1}, {0.25, 0.75, 0.1}}
+	var buf bytes.Buffer
+	if err := WriteHDF5Samples(&buf, "x_train", samples); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadHDF5Samples(bytes.NewReader(buf.Bytes()), "x_train")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.EqualFunc(got, samples, slices.Equal) {
+		t.Errorf("expected %v, got %v", samples, got)
+	}
+
+	if err := WriteHDF5Samples(&buf, "x", [][]float64{{1, 2}, {3}}); err == nil {
+		t.Error("expected an error writing samples of different lengths")
+	}
+}
+
+func TestReadHDF5SamplesFlattens(t *testing.T) {
+	images := make([]float64, 3*2*2)
+	for i := range images {
+		images[i] = float64(i * 20)
+	}
+	var buf bytes.Buffer
+	if err := hdf5.Write(&buf, hdf5.Dataset{Name: "images", Shape: []int{3, 2, 2}, Data: images}); err != nil {
+		t.Fatal(err)
+	}
+	samples, err := ReadHDF5Samples(bytes.NewReader(buf.Bytes()), "/images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 3 || !slices.Equal(samples[2], []float64{160, 180, 200, 220}) {
+		t.Errorf("unexpected samples %v", samples)
+	}
+	if _, err := ReadHDF5Samples(bytes.NewReader(buf.Bytes()), "labels"); !errors.Is(err, hdf5.ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
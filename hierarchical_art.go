@@ -0,0 +1,117 @@
+package art
+
+import "fmt"
+
+// HierarchicalART stacks FuzzyART layers in the style of SMART (Bartfai, 1994).
+// The finest layer clusters the inputs, every coarser layer clusters the prototypes
+// (hyper-rectangle centers) of the layer below, so that every input gets a cluster assignment
+// at every granularity level: coarser layers have lower vigilance and group similar fine categories.
+type HierarchicalART struct {
+	// layers from the coarsest to the finest
+	layers []*FuzzyART
+
+	// parents[l][j] is the category of layer l-1 grouping category j of layer l
+	parents [][]int
+}
+
+// NewHierarchicalART creates a hierarchy with a layer for every vigilance value,
+// rhos must be strictly increasing: from the coarsest layer to the finest one.
+func NewHierarchicalART(inputLen int, rhos []float64, alpha, beta float64) (*HierarchicalART, error) {
+	if len(rhos) == 0 {
+		return nil, fmt.Errorf("at least one vigilance value is required")
+	}
+
+	h := &HierarchicalART{parents: make([][]int, len(rhos))}
+	for l, rho := range rhos {
+		if l > 0 && rho <= rhos[l-1] {
+			h.Close()
+			return nil, fmt.Errorf("vigilance values must be strictly increasing, got %f after %f", rho, rhos[l-1])
+		}
+		layer, err := NewFuzzyART(inputLen, rho, alpha, beta)
+		if err != nil {
+			h.Close()
+			return nil, err
+		}
+		h.layers = append(h.layers, layer)
+	}
+	return h, nil
+}
+
+// Levels returns the number of layers.
+func (h *HierarchicalART) Levels() int {
+	return len(h.layers)
+}
+
+// Layer returns the FuzzyART of level l, 0 is the coarsest.
+func (h *HierarchicalART) Layer(l int) *FuzzyART {
+	return h.layers[l]
+}
+
+// prototype returns the center of the hyper-rectangle of category j.
+func prototype[T Float](f *FuzzyARTOf[T], j int) []float64 {
+	w := f.w[j]
+	p := make([]float64, f.M)
+	for i := range p {
+		p[i] = (float64(w[i]) + 1 - float64(w[i+f.M])) / 2
+	}
+	return p
+}
+
+// Fit learns the input on the finest layer, then learns the updated prototype
+// of the resonating category on every coarser layer.
+// It returns the category of every level, from the coarsest to the finest.
+func (h *HierarchicalART) Fit(a []float64) []int {
+	assignments := make([]int, len(h.layers))
+	input := a
+	for l := len(h.layers) - 1; l >= 0; l-- {
+		_, j := h.layers[l].Fit(input)
+		assignments[l] = j
+		if l < len(h.layers)-1 {
+			// the category learned on the finer layer moves under the resonating one
+			child := assignments[l+1]
+			for len(h.parents[l+1]) <= child {
+				h.parents[l+1] = append(h.parents[l+1], -1)
+			}
+			h.parents[l+1][child] = j
+		}
+		input = prototype(h.layers[l], j)
+	}
+	return assignments
+}
+
+// Predict returns the category of every level for the input, from the coarsest to the finest,
+// following the hierarchy from the best matching category of the finest layer.
+// If the model has no categories yet, all the levels are -1.
+func (h *HierarchicalART) Predict(a []float64) []int {
+	assignments := make([]int, len(h.layers))
+	finest := h.layers[len(h.layers)-1]
+	if len(finest.w) == 0 {
+		for l := range assignments {
+			assignments[l] = -1
+		}
+		return assignments
+	}
+
+	_, assignments[len(h.layers)-1] = finest.Predict(a, false)
+	for l := len(h.layers) - 1; l > 0; l-- {
+		assignments[l-1] = h.parents[l][assignments[l]]
+	}
+	return assignments
+}
+
+// Children returns the categories of level l+1 grouped by category j of level l.
+func (h *HierarchicalART) Children(l, j int) []int {
+	var children []int
+	for child, parent := range h.parents[l+1] {
+		if parent == j {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+func (h *HierarchicalART) Close() {
+	for _, layer := range h.layers {
+		layer.Close()
+	}
+}
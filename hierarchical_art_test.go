@@ -0,0 +1,42 @@
+package art
+
+import "testing"
+
+func TestHierarchicalART(t *testing.T) {
+	model, err := NewHierarchicalART(4, []float64{0.5, 0.95}, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	inputs := [][]float64{
+		{0.1, 0.1, 0.1, 0.1},
+		{0.2, 0.2, 0.2, 0.2},
+		{0.9, 0.9, 0.9, 0.9},
+	}
+	for _, a := range inputs {
+		model.Fit(a)
+	}
+
+	if n := len(model.Layer(1).w); n != 3 {
+		t.Fatalf("finest layer should have 3 categories, got %d", n)
+	}
+	if n := len(model.Layer(0).w); n != 2 {
+		t.Fatalf("coarsest layer should have 2 categories, got %d", n)
+	}
+
+	a, b, c := model.Predict(inputs[0]), model.Predict(inputs[1]), model.Predict(inputs[2])
+	if a[1] == b[1] || a[0] != b[0] {
+		t.Errorf("close inputs should differ on the finest level only, got %v and %v", a, b)
+	}
+	if c[0] == a[0] {
+		t.Errorf("distant input should differ on the coarsest level, got %v and %v", c, a)
+	}
+	if children := model.Children(0, a[0]); len(children) != 2 {
+		t.Errorf("expected 2 children, got %v", children)
+	}
+
+	if _, err := NewHierarchicalART(4, []float64{0.9, 0.5}, 0.01, 1); err == nil {
+		t.Error("decreasing vigilance values should be rejected")
+	}
+}
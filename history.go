@@ -0,0 +1,80 @@
+package art
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// Assignment records the category a training sample was learned by, see WithAssignmentHistory.
+type Assignment struct {
+	// Category is the ID of the category, see CategoryID: it stays valid when indexes change.
+	Category uint64
+	// Resonance is the resonance of the sample with the category, as returned by Fit.
+	Resonance float64
+	// Created is true when the sample created the category.
+	Created bool
+}
+
+// WithAssignmentHistory records the category of every sample learned by the model, in learning order
+// (FitMiniBatch learns the samples matching a category before the others), so that clustering metrics
+// and confusion matrices can be computed on the training set without predicting it again.
+// Samples predicted by a frozen model are not recorded. The history is not saved with the model, nor copied by Clone.
+func WithAssignmentHistory() Option {
+	return func(f *fuzzyState) error {
+		f.history = make([]Assignment, 0)
+		return nil
+	}
+}
+
+// AssignmentHistory returns the assignments recorded since WithAssignmentHistory or the last ResetAssignmentHistory,
+// nil if the history is disabled. The slice must not be modified.
+func (f *FuzzyARTOf[T]) AssignmentHistory() []Assignment {
+	return f.history
+}
+
+// AssignmentIndexes returns the current index of the category of every recorded assignment,
+// -1 for the categories removed since (e.g. by Prune or a category cap).
+func (f *FuzzyARTOf[T]) AssignmentIndexes() []int {
+	indexes := make([]int, len(f.history))
+	for i, a := range f.history {
+		j, ok := f.CategoryIndex(a.Category)
+		if !ok {
+			j = -1
+		}
+		indexes[i] = j
+	}
+	return indexes
+}
+
+// ResetAssignmentHistory clears the recorded assignments, e.g. before a new epoch, keeping the history enabled.
+func (f *FuzzyARTOf[T]) ResetAssignmentHistory() {
+	if f.history != nil {
+		f.history = f.history[:0]
+	}
+}
+
+// WriteAssignmentsCSV writes the recorded assignments as CSV rows, preceded by the header
+// sample, category_id, category, resonance, created: sample is the learning order of the sample
+// and category its current index, as returned by AssignmentIndexes.
+func (f *FuzzyARTOf[T]) WriteAssignmentsCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"sample", "category_id", "category", "resonance", "created"}); err != nil {
+		return err
+	}
+	for i, j := range f.AssignmentIndexes() {
+		a := f.history[i]
+		record := []string{
+			strconv.Itoa(i),
+			strconv.FormatUint(a.Category, 10),
+			strconv.Itoa(j),
+			strconv.FormatFloat(a.Resonance, 'g', -1, 64),
+			strconv.FormatBool(a.Created),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,47 @@
+package art
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAssignmentHistory(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithAssignmentHistory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	samples := [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}, {0.11, 0.1, 0.1, 0.1}, {0.5, 0.5, 0.5, 0.5}}
+	for _, a := range samples {
+		model.Fit(a)
+	}
+	history := model.AssignmentHistory()
+	if len(history) != len(samples) || !history[0].Created || history[2].Created || history[2].Category != history[0].Category {
+		t.Fatalf("unexpected history %+v", history)
+	}
+
+	if _, err := model.DeleteCategory(1); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := model.AssignmentIndexes(), []int{0, -1, 0, 1}; !slices.Equal(got, want) {
+		t.Errorf("expected the category indexes %v after the deletion, got %v", want, got)
+	}
+
+	var b strings.Builder
+	if err := model.WriteAssignmentsCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 5 || lines[0] != "sample,category_id,category,resonance,created" || !strings.HasPrefix(lines[2], "1,2,-1,") {
+		t.Errorf("unexpected CSV:\n%s", b.String())
+	}
+
+	model.ResetAssignmentHistory()
+	model.SetFrozen(true)
+	model.Fit(samples[0])
+	if history := model.AssignmentHistory(); history == nil || len(history) != 0 {
+		t.Errorf("expected an empty history, got %v", history)
+	}
+}
@@ -0,0 +1,65 @@
+package art
+
+import "errors"
+
+// Hooks observe the training of a model, see WithHooks.
+// They are called synchronously by Fit, Predict with learning and FitLabeled, after the input is learned,
+// and must not modify the model. Training can be stopped early by cancelling the context of FitAllCtx from a hook.
+type Hooks interface {
+	// OnCategoryCreated is called when a new category j is committed to the input.
+	OnCategoryCreated(j int)
+	// OnResonance is called when the existing category j learns the input, resonating with value.
+	OnResonance(j int, value float64)
+	// OnSample is called for every learned input, n is the number of inputs learned since the hooks were added.
+	OnSample(n int)
+}
+
+// NopHooks implements Hooks doing nothing, it can be embedded to observe only some of the events.
+type NopHooks struct{}
+
+func (NopHooks) OnCategoryCreated(int)    {}
+func (NopHooks) OnResonance(int, float64) {}
+func (NopHooks) OnSample(int)             {}
+
+// WithHooks registers hooks observing the training, see AddHooks.
+func WithHooks(h Hooks) Option {
+	return func(f *fuzzyState) error {
+		if h == nil {
+			return errors.New("hooks must not be nil")
+		}
+		f.hooks = append(f.hooks, h)
+		return nil
+	}
+}
+
+// AddHooks registers hooks observing the training, e.g. on a loaded model.
+// Hooks are called in the order they were added. They are not saved with the model, nor copied by Clone.
+func (f *FuzzyARTOf[T]) AddHooks(h Hooks) {
+	f.hooks = append(f.hooks, h)
+}
+
+// notifyHooks calls the hooks after category j learned an input with resonance,
+// lastID is the last category ID before the input.
+// It also records the assignment, see WithAssignmentHistory.
+func (f *FuzzyARTOf[T]) notifyHooks(j int, resonance float64, lastID uint64) {
+	if len(f.hooks) == 0 && f.history == nil {
+		return
+	}
+	// the input created the category if it's the newest one, a category cap can merge it right away
+	created := f.lastID != lastID && f.categories[j].id == f.lastID
+	if f.history != nil {
+		f.history = append(f.history, Assignment{Category: f.categories[j].id, Resonance: resonance, Created: created})
+	}
+	if len(f.hooks) == 0 {
+		return
+	}
+	f.learnedSamples++
+	for _, h := range f.hooks {
+		if created {
+			h.OnCategoryCreated(j)
+		} else {
+			h.OnResonance(j, resonance)
+		}
+		h.OnSample(f.learnedSamples)
+	}
+}
@@ -0,0 +1,82 @@
+package art
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type recordingHooks struct {
+	created   []int
+	resonance map[int]int
+	samples   []int
+}
+
+func (h *recordingHooks) OnCategoryCreated(j int) { h.created = append(h.created, j) }
+
+func (h *recordingHooks) OnResonance(j int, value float64) {
+	if value < 0.9 {
+		panic("resonance below the vigilance")
+	}
+	h.resonance[j]++
+}
+
+func (h *recordingHooks) OnSample(n int) { h.samples = append(h.samples, n) }
+
+func TestHooks(t *testing.T) {
+	hooks := &recordingHooks{resonance: make(map[int]int)}
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithHooks(hooks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	model.Fit([]float64{0.9, 0.9, 0.9, 0.9})
+	model.Fit([]float64{0.11, 0.1, 0.1, 0.1})
+	model.Predict([]float64{0.9, 0.9, 0.9, 0.9}, false)
+	model.Predict([]float64{0.9, 0.89, 0.9, 0.9}, true)
+
+	if len(hooks.created) != 2 || hooks.created[0] != 0 || hooks.created[1] != 1 {
+		t.Errorf("expected categories 0 and 1 created, got %v", hooks.created)
+	}
+	if hooks.resonance[0] != 1 || hooks.resonance[1] != 1 {
+		t.Errorf("expected a resonance of both categories, got %v", hooks.resonance)
+	}
+	if len(hooks.samples) != 4 || hooks.samples[3] != 4 {
+		t.Errorf("expected 4 learned samples, got %v", hooks.samples)
+	}
+}
+
+type stopAfter struct {
+	NopHooks
+	n      int
+	cancel context.CancelFunc
+}
+
+func (h stopAfter) OnSample(n int) {
+	if n == h.n {
+		h.cancel()
+	}
+}
+
+func TestHooksEarlyStopping(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.AddHooks(stopAfter{n: 15, cancel: cancel})
+
+	samples := make([][]float64, 10)
+	for i := range samples {
+		v := float64(i) / 10
+		samples[i] = []float64{v, v, 1 - v, v}
+	}
+	report, err := model.FitAllCtx(ctx, samples, 5)
+	if !errors.Is(err, context.Canceled) || len(report.Epochs) != 1 {
+		t.Errorf("expected training stopped in the second epoch, got %v and %d epochs", err, len(report.Epochs))
+	}
+}
@@ -0,0 +1,185 @@
+package art
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+type hypersphereActivation struct {
+	// euclidean distance between the input and the category center
+	dist float64
+	// activation value, choice function value
+	activation float64
+	// index of the category
+	j int
+}
+
+// HypersphereART implements Hypersphere ART (Anagnostopoulos & Georgiopoulos, 2000).
+// Each category is a hypersphere described by a center and a radius,
+// inputs are used as they are (no complement coding).
+// It shares the Fit/Predict signatures of FuzzyART, so the two models are interchangeable.
+type HypersphereART struct {
+	// Vigilance parameter - controls category granularity
+	// Range: 0.0 to 1.0
+	// Purpose: A category resonates when 1 - max(R, d)/rBar >= rho,
+	// where R is the category radius and d the distance between the input and the category center.
+	// Adjustment:
+	// Increase rho to make the model more selective, creating more (smaller) categories.
+	// Decrease rho to allow more generalization, creating fewer (larger) categories.
+	rho float64
+
+	// Choice parameter - influences category competition
+	// Recommended value: 0.01
+	// Range: > 0.0
+	alpha float64
+
+	// Learning rate - controls how fast centers and radii move toward the inputs
+	// Recommended value: 1.0
+	// Range: 0.0 to 1.0
+	beta float64
+
+	// Radial extent upper bound, the maximum radius a category can reach.
+	// It should be at least half the diameter of the data, e.g.: sqrt(M)/2 for inputs in [0, 1].
+	rBar float64
+
+	// M is the number of features of the input, its dimensionality.
+	M int
+
+	// W stores the category centers.
+	W [][]float64
+
+	// R stores the category radii.
+	R []float64
+
+	// t is the activation list - stores category activations
+	t []*hypersphereActivation
+}
+
+func NewHypersphereART(inputLen int, rho, alpha, beta, rBar float64) (*HypersphereART, error) {
+	if rho < 0 || rho > 1 {
+		return nil, fmt.Errorf("vigilance parameter (rho) must be between 0 and 1, got %f", rho)
+	}
+	if alpha <= 0 {
+		return nil, fmt.Errorf("choice parameter (alpha) must be positive, got %f", alpha)
+	}
+	if beta <= 0 || beta > 1 {
+		return nil, fmt.Errorf("learning rate (beta) must be between 0 and 1, got %f", beta)
+	}
+	if rBar <= 0 {
+		return nil, fmt.Errorf("radial extent (rBar) must be positive, got %f", rBar)
+	}
+
+	return &HypersphereART{
+		rho:   rho,
+		alpha: alpha,
+		beta:  beta,
+		rBar:  rBar,
+		M:     inputLen,
+		W:     make([][]float64, 0),
+		R:     make([]float64, 0),
+		t:     make([]*hypersphereActivation, 0),
+	}, nil
+}
+
+func euclideanDistance(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// activateCategories computes the choice function of every category
+// and sorts the activation list, the best matching category first.
+func (h *HypersphereART) activateCategories(a []float64) {
+	for j, w := range h.W {
+		t := h.t[j]
+		t.j = j
+		t.dist = euclideanDistance(a, w)
+		t.activation = (h.rBar - math.Max(h.R[j], t.dist)) / (h.rBar - h.R[j] + h.alpha)
+	}
+
+	// Stable sort, in case of equal activation values older categories keep the priority.
+	slices.SortStableFunc(h.t, func(a, b *hypersphereActivation) int {
+		if a.activation > b.activation {
+			return -1
+		}
+		if a.activation < b.activation {
+			return 1
+		}
+		return 0
+	})
+}
+
+// match returns the match function value for the given category,
+// 1 for a perfect match, 0 when the category would exceed rBar.
+func (h *HypersphereART) match(t *hypersphereActivation) float64 {
+	return 1 - math.Max(h.R[t.j], t.dist)/h.rBar
+}
+
+func (h *HypersphereART) appendNewCategory(a []float64) int {
+	h.W = append(h.W, slices.Clone(a))
+	h.R = append(h.R, 0)
+	h.t = append(h.t, &hypersphereActivation{})
+	return len(h.W) - 1
+}
+
+// updateCategory moves the category center toward the input
+// and enlarges its radius just enough to include it.
+func (h *HypersphereART) updateCategory(t *hypersphereActivation, a []float64) {
+	r := h.R[t.j]
+	h.R[t.j] = r + h.beta/2*(math.Max(r, t.dist)-r)
+
+	if t.dist == 0 {
+		return
+	}
+	w := h.W[t.j]
+	shift := h.beta / 2 * (1 - math.Min(r, t.dist)/t.dist)
+	for i := range w {
+		w[i] += shift * (a[i] - w[i])
+	}
+}
+
+// resonateOrReset searches the categories in activation order
+// for the first one passing the vigilance test and updates it,
+// if none passes a new category is created.
+func (h *HypersphereART) resonateOrReset(a []float64) (maxResonance float64, categoryIndex int) {
+	for _, t := range h.t {
+		resonance := h.match(t)
+		if resonance >= h.rho {
+			h.updateCategory(t, a)
+			return resonance, t.j
+		}
+		maxResonance = math.Max(maxResonance, resonance)
+	}
+
+	categoryIndex = h.appendNewCategory(a)
+	return
+}
+
+// Fit implements the complete ART learning cycle.
+func (h *HypersphereART) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	h.activateCategories(a)
+	return h.resonateOrReset(a)
+}
+
+// Predict implements the recognition process with optional learning.
+// It returns the match value and the index of the best matching category,
+// or -1 if the model has no categories yet.
+// If learn is true, it also updates the matching category.
+func (h *HypersphereART) Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int) {
+	h.activateCategories(a)
+	if !learn {
+		if len(h.t) == 0 {
+			return 0, -1
+		}
+		return h.match(h.t[0]), h.t[0].j
+	}
+
+	return h.resonateOrReset(a)
+}
+
+// Close is a no-op, it exists so that HypersphereART can be swapped with FuzzyART.
+func (h *HypersphereART) Close() {}
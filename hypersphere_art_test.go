@@ -0,0 +1,46 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHypersphereART(t *testing.T) {
+	model, err := NewHypersphereART(2, 0.8, 0.01, 1, math.Sqrt(2)/2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	_, j0 := model.Fit([]float64{0.1, 0.1})
+	_, j1 := model.Fit([]float64{0.12, 0.1})
+	_, j2 := model.Fit([]float64{0.9, 0.9})
+
+	if j0 != 0 || j1 != 0 {
+		t.Errorf("close inputs should share category 0, got %d and %d", j0, j1)
+	}
+	if j2 != 1 {
+		t.Errorf("distant input should create category 1, got %d", j2)
+	}
+	if model.R[0] == 0 {
+		t.Error("category 0 radius should have grown")
+	}
+
+	resonance, j := model.Predict([]float64{0.11, 0.1}, false)
+	if j != 0 || resonance < 0.8 {
+		t.Errorf("expected category 0 with resonance >= 0.8, got %d (%f)", j, resonance)
+	}
+	if len(model.W) != 2 {
+		t.Errorf("Predict without learning must not create categories, got %d", len(model.W))
+	}
+}
+
+func TestHypersphereARTEmptyPredict(t *testing.T) {
+	model, err := NewHypersphereART(2, 0.8, 0.01, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, j := model.Predict([]float64{0.5, 0.5}, false); j != -1 {
+		t.Errorf("empty model should return -1, got %d", j)
+	}
+}
@@ -0,0 +1,84 @@
+package art
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ImportClusters converts the output of an offline clustering job (k-means, HDBSCAN...)
+// into categories, so that the model can take over the online maintenance of an existing clustering.
+// Every centroid becomes a hyper-rectangle centered on it, growing with the cluster size
+// up to the largest size still passing the vigilance test: a total width of M * (1 - rho).
+// Single-sample clusters become point categories, as if the centroid was learned.
+// counts and labels are optional (nil), otherwise they must have an entry for every centroid.
+// The hyper-rectangles are complement-coded, so models using WithEncoder can't import clusters.
+// Imports exceeding the category cap (see WithMaxCategories) are rejected, whatever the cap strategy.
+func (f *FuzzyARTOf[T]) ImportClusters(centroids [][]float64, counts []int, labels []string) error {
+	if f.encoder != nil {
+		return errors.New("cannot import clusters into a model with an encoder, categories are built complement-coded")
+	}
+	if counts != nil && len(counts) != len(centroids) {
+		return fmt.Errorf("counts must be %d, got %d", len(centroids), len(counts))
+	}
+	if labels != nil && len(labels) != len(centroids) {
+		return fmt.Errorf("labels must be %d, got %d", len(centroids), len(labels))
+	}
+	if f.maxCategories > 0 && len(f.w)+len(centroids) > f.maxCategories {
+		return fmt.Errorf("category cap of %d exceeded: %d categories plus %d clusters", f.maxCategories, len(f.w), len(centroids))
+	}
+	for c, centroid := range centroids {
+		if len(centroid) != f.M {
+			return fmt.Errorf("centroid %d length must be %d, got %d", c, f.M, len(centroid))
+		}
+		for i, v := range centroid {
+			if math.IsNaN(v) || v < 0 || v > 1 {
+				return fmt.Errorf("centroid %d feature %d must be between 0 and 1, got %f", c, i, v)
+			}
+		}
+		if counts != nil && counts[c] < 1 {
+			return fmt.Errorf("cluster %d count must be positive, got %d", c, counts[c])
+		}
+	}
+
+	for c, centroid := range centroids {
+		count := 1
+		if counts != nil {
+			count = counts[c]
+		}
+		halfWidth := (1 - f.rho) / 2 * (1 - 1/float64(count))
+
+		w := make([]T, 2*f.M)
+		for i, v := range centroid {
+			w[i] = T(max(0, v-halfWidth))
+			w[i+f.M] = T(1 - min(1, v+halfWidth))
+		}
+		j := f.appendNewCategory(w)
+		f.categories[j].count = count
+		if labels != nil {
+			f.categories[j].label = labels[c]
+		}
+	}
+	return nil
+}
+
+// InitCategories seeds an empty model with known prototypes, e.g. k-means centroids or the cluster centers
+// of a previous run, to avoid the category churn of a cold start: every prototype becomes a point category
+// (complement-coded) that learned one sample, as if Fit created it.
+// Unlike ImportClusters, it doesn't grow the categories with the cluster sizes, so it doesn't need them.
+func (f *FuzzyARTOf[T]) InitCategories(prototypes [][]float64) error {
+	if len(f.w) > 0 {
+		return fmt.Errorf("categories can only be initialized on an empty model, got %d categories", len(f.w))
+	}
+	return f.ImportClusters(prototypes, nil, nil)
+}
+
+// Label returns the label of category j, empty if it has none.
+func (f *FuzzyARTOf[T]) Label(j int) string {
+	return f.categories[j].label
+}
+
+// Count returns the number of samples learned by category j.
+func (f *FuzzyARTOf[T]) Count(j int) int {
+	return f.categories[j].count
+}
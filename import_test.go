@@ -0,0 +1,101 @@
+package art
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestImportClusters(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	centroids := [][]float64{{0.2, 0.2, 0.2, 0.2}, {0.8, 0.8, 0.8, 0.8}}
+	if err := model.ImportClusters(centroids, []int{100, 1}, []string{"low", "high"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if model.Label(0) != "low" || model.Count(0) != 100 {
+		t.Errorf("unexpected category 0: %q, %d", model.Label(0), model.Count(0))
+	}
+	// near the centroid, inside the imported hyper-rectangle
+	resonance, j := model.Predict([]float64{0.25, 0.2, 0.15, 0.2}, false)
+	if j != 0 || resonance < 0.8 {
+		t.Errorf("expected category 0 passing the vigilance test, got %d (%f)", j, resonance)
+	}
+	if _, j := model.Fit([]float64{0.8, 0.8, 0.8, 0.8}); j != 1 || model.Count(1) != 2 {
+		t.Errorf("expected category 1 with 2 samples, got %d (%d)", j, model.Count(1))
+	}
+
+	for _, v := range []float64{2, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if err := model.ImportClusters([][]float64{{v, 0, 0, 0}}, nil, nil); err == nil {
+			t.Errorf("centroid feature %f should be rejected", v)
+		}
+	}
+	if len(model.w) != 2 {
+		t.Errorf("rejected imports should add no category, got %d", len(model.w))
+	}
+}
+
+func TestImportClustersCap(t *testing.T) {
+	model, err := NewFuzzyART(2, 0.9, 0.01, 1, WithMaxCategories(2, CapEvictLRU))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	model.Fit([]float64{0.5, 0.5})
+	if err := model.ImportClusters([][]float64{{0.1, 0.1}, {0.9, 0.9}}, nil, nil); err == nil {
+		t.Error("expected an error importing clusters over the category cap")
+	}
+	if len(model.w) != 1 {
+		t.Errorf("expected 1 category, got %d", len(model.w))
+	}
+	if err := model.ImportClusters([][]float64{{0.1, 0.1}}, nil, nil); err != nil {
+		t.Errorf("imports within the cap should be accepted: %v", err)
+	}
+}
+
+func TestInitCategories(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	prototypes := [][]float64{{0.2, 0.2, 0.2, 0.2}, {0.8, 0.7, 0.8, 0.7}}
+	if err := model.InitCategories(prototypes); err != nil {
+		t.Fatal(err)
+	}
+	if len(model.w) != 2 || !slices.Equal(model.w[1], model.complementCode(prototypes[1])) || model.Count(1) != 1 {
+		t.Fatalf("expected 2 complement-coded point categories, got %v", model.w)
+	}
+	if resonance, j := model.Predict(prototypes[1], false); j != 1 || resonance != 1 {
+		t.Errorf("expected the prototype in category 1 with resonance 1, got %d (%f)", j, resonance)
+	}
+	if _, j := model.Fit([]float64{0.22, 0.2, 0.21, 0.2}); j != 0 || len(model.w) != 2 {
+		t.Errorf("expected a close sample to learn category 0, got %d with %d categories", j, len(model.w))
+	}
+
+	if err := model.InitCategories(prototypes); err == nil {
+		t.Error("expected an error initializing a model with categories")
+	}
+}
+
+func TestImportClustersEncoder(t *testing.T) {
+	model, err := NewFuzzyART(2, 0.9, 0.01, 1, WithEncoder(ComplementCoded{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	if err := model.ImportClusters([][]float64{{0.1, 0.2}}, nil, nil); err == nil {
+		t.Error("expected an error importing clusters into a model with an encoder")
+	}
+	if len(model.w) != 0 {
+		t.Errorf("expected no category, got %d", len(model.w))
+	}
+}
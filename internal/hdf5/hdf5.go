@@ -0,0 +1,66 @@
+// Package hdf5 reads and writes numeric datasets in the root group of HDF5 files,
+// without depending on the HDF5 C library.
+//
+// Files are written in the oldest format (superblock version 0, version 1 object headers
+// and a symbol table root group), readable by every HDF5 version.
+// Reading supports superblock versions 0 to 3, symbol table and compact link storage groups,
+// contiguous and compact layouts, little-endian integers and floats, converted to float64.
+// Chunked (e.g. compressed) datasets and dense link storage are not supported.
+package hdf5
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Dataset is an n-dimensional array in C order.
+type Dataset struct {
+	Name  string
+	Shape []int
+	Data  []float64
+}
+
+var signature = []byte("\x89HDF\r\n\x1a\n")
+
+// undefined is the undefined address.
+const undefined = ^uint64(0)
+
+// object header message types
+const (
+	msgNil          = 0x0000
+	msgDataspace    = 0x0001
+	msgLinkInfo     = 0x0002
+	msgDatatype     = 0x0003
+	msgFillValue    = 0x0005
+	msgLink         = 0x0006
+	msgLayout       = 0x0008
+	msgContinuation = 0x0010
+	msgSymbolTable  = 0x0011
+)
+
+// ErrNotFound is returned when a dataset path doesn't exist in the file.
+var ErrNotFound = errors.New("hdf5: not found")
+
+// ErrUnsupported is returned for valid HDF5 features this package doesn't implement.
+var ErrUnsupported = errors.New("hdf5: unsupported feature")
+
+func unsupported(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrUnsupported, fmt.Sprintf(format, args...))
+}
+
+func validate(ds Dataset) error {
+	n := 1
+	for _, d := range ds.Shape {
+		if d < 0 {
+			return fmt.Errorf("hdf5: dataset %q has negative dimension in shape %v", ds.Name, ds.Shape)
+		}
+		n *= d
+	}
+	if n != len(ds.Data) {
+		return fmt.Errorf("hdf5: dataset %q shape %v doesn't match %d values", ds.Name, ds.Shape, len(ds.Data))
+	}
+	if ds.Name == "" {
+		return errors.New("hdf5: empty dataset name")
+	}
+	return nil
+}
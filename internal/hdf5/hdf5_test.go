@@ -0,0 +1,82 @@
+package hdf5
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestReadLibHDF5(t *testing.T) {
+	ints := make([]float64, 4*6*8*10)
+	for i := range ints {
+		ints[i] = float64(i)
+	}
+	tests := []struct {
+		file, path string
+		shape      []int
+		data       []float64
+		// tolerance is the conversion error allowed, for float32 values
+		tolerance float64
+	}{
+		{"tints4dims.h5", "FourDimInts", []int{4, 6, 8, 10}, ints, 0},
+		{"tfpformat.h5", "double", []int{6}, []float64{-0.1234567, 0.1234567, 0, 0, 0, 0}, 0},
+		{"tfpformat.h5", "float", []int{6}, []float64{-0.1234567, 0.1234567, 0, 0, 0, 0}, 1e-8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file+"/"+tt.path, func(t *testing.T) {
+			f, err := os.Open("testdata/" + tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			ds, err := ReadDataset(f, tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(ds.Shape, tt.shape) {
+				t.Fatalf("expected shape %v, got %v", tt.shape, ds.Shape)
+			}
+			if len(ds.Data) != len(tt.data) {
+				t.Fatalf("expected %d values, got %d", len(tt.data), len(ds.Data))
+			}
+			for i, want := range tt.data {
+				if math.Abs(ds.Data[i]-want) > tt.tolerance {
+					t.Errorf("value %d: expected %g, got %g", i, want, ds.Data[i])
+				}
+			}
+		})
+	}
+
+	f, err := os.Open("testdata/tfpformat.h5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := ReadDataset(f, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	datasets := []Dataset{
+		{Name: "weights", Shape: []int{2, 3}, Data: []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6}},
+		{Name: "counts", Shape: []int{2}, Data: []float64{3, 1}},
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, datasets...); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range datasets {
+		got, err := ReadDataset(bytes.NewReader(buf.Bytes()), want.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got.Shape, want.Shape) || !slices.Equal(got.Data, want.Data) {
+			t.Errorf("dataset %s: expected %v %v, got %v %v", want.Name, want.Shape, want.Data, got.Shape, got.Data)
+		}
+	}
+}
@@ -0,0 +1,508 @@
+package hdf5
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// maxHeaderMessages bounds the messages read from an object header, against corrupted files.
+const maxHeaderMessages = 1 << 16
+
+// file reads the structures of an HDF5 file, all addresses are relative to base.
+type file struct {
+	r    io.ReaderAt
+	base uint64
+	root uint64
+}
+
+func (f *file) read(address uint64, n int) ([]byte, error) {
+	if address == undefined {
+		return nil, errors.New("hdf5: read at undefined address")
+	}
+	b := make([]byte, n)
+	if _, err := f.r.ReadAt(b, int64(f.base+address)); err != nil {
+		return nil, fmt.Errorf("hdf5: reading %d bytes at %d: %w", n, address, err)
+	}
+	return b, nil
+}
+
+// open finds the superblock, at 0 or at a power of two multiple of 512 when the file has a user block.
+func open(r io.ReaderAt) (*file, error) {
+	for base := int64(0); ; base = max(512, 2*base) {
+		header := make([]byte, 9)
+		if _, err := r.ReadAt(header, base); err != nil {
+			return nil, errors.New("hdf5: not an HDF5 file")
+		}
+		if !bytes.Equal(header[:8], signature) {
+			continue
+		}
+
+		f := &file{r: r}
+		switch version := header[8]; version {
+		case 0, 1:
+			sb := make([]byte, superblockSize+4)
+			n, err := r.ReadAt(sb, base)
+			if n < superblockSize && err != nil {
+				return nil, fmt.Errorf("hdf5: reading superblock: %w", err)
+			}
+			if sb[13] != 8 || sb[14] != 8 {
+				return nil, unsupported("%d bytes offsets and %d bytes lengths", sb[13], sb[14])
+			}
+			offset := 24
+			if version == 1 {
+				offset += 4
+			}
+			// root symbol table entry: name offset, object header address
+			f.root = binary.LittleEndian.Uint64(sb[offset+32+8:])
+		case 2, 3:
+			sb := make([]byte, 48)
+			if _, err := r.ReadAt(sb, base); err != nil {
+				return nil, fmt.Errorf("hdf5: reading superblock: %w", err)
+			}
+			if sb[9] != 8 || sb[10] != 8 {
+				return nil, unsupported("%d bytes offsets and %d bytes lengths", sb[9], sb[10])
+			}
+			f.root = binary.LittleEndian.Uint64(sb[36:])
+		default:
+			return nil, unsupported("superblock version %d", version)
+		}
+		// like the HDF5 library, addresses are relative to the superblock regardless of the base address field
+		f.base = uint64(base)
+		return f, nil
+	}
+}
+
+// messages reads the messages of the object header at address, following continuations.
+func (f *file) messages(address uint64) ([]message, error) {
+	prefix, err := f.read(address, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	type block struct {
+		address, size uint64
+	}
+	var messages []message
+	var blocks []block
+	if string(prefix[:4]) == "OHDR" {
+		flags := prefix[5]
+		offset := uint64(6)
+		if flags&0x20 != 0 {
+			offset += 16 // times
+		}
+		if flags&0x10 != 0 {
+			offset += 4 // attribute phase change
+		}
+		width := 1 << (flags & 3)
+		sizeBytes, err := f.read(address+offset, width)
+		if err != nil {
+			return nil, err
+		}
+		size := readUint(sizeBytes)
+		blocks = append(blocks, block{address + offset + uint64(width), size})
+
+		for i := 0; i < len(blocks); i++ {
+			data, err := f.read(blocks[i].address, int(blocks[i].size))
+			if err != nil {
+				return nil, err
+			}
+			headerLen := 4
+			if flags&0x04 != 0 {
+				headerLen += 2 // creation order
+			}
+			for len(data) >= headerLen && len(messages) < maxHeaderMessages {
+				typ, size := uint16(data[0]), int(binary.LittleEndian.Uint16(data[1:]))
+				if headerLen+size > len(data) {
+					return nil, errors.New("hdf5: object header message out of bounds")
+				}
+				m := message{typ: typ, flags: data[3], data: data[headerLen : headerLen+size]}
+				data = data[headerLen+size:]
+				if m.typ == msgContinuation {
+					c, err := continuation(m)
+					if err != nil {
+						return nil, err
+					}
+					// continuation blocks have the OCHK signature and a checksum
+					blocks = append(blocks, block{c.address + 4, c.size - 8})
+				}
+				messages = append(messages, m)
+			}
+		}
+		return messages, nil
+	}
+
+	if prefix[0] != 1 {
+		return nil, unsupported("object header version %d", prefix[0])
+	}
+	count := int(binary.LittleEndian.Uint16(prefix[2:]))
+	blocks = append(blocks, block{address + objectHeaderV1, uint64(binary.LittleEndian.Uint32(prefix[8:]))})
+	for i := 0; i < len(blocks) && len(messages) < count; i++ {
+		data, err := f.read(blocks[i].address, int(blocks[i].size))
+		if err != nil {
+			return nil, err
+		}
+		for len(data) >= 8 && len(messages) < count {
+			typ, size := binary.LittleEndian.Uint16(data), int(binary.LittleEndian.Uint16(data[2:]))
+			if 8+size > len(data) {
+				return nil, errors.New("hdf5: object header message out of bounds")
+			}
+			m := message{typ: typ, flags: data[4], data: data[8 : 8+size]}
+			data = data[8+size:]
+			if m.typ == msgContinuation {
+				c, err := continuation(m)
+				if err != nil {
+					return nil, err
+				}
+				blocks = append(blocks, block{c.address, c.size})
+			}
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+func continuation(m message) (c struct{ address, size uint64 }, err error) {
+	if len(m.data) < 16 {
+		return c, errors.New("hdf5: truncated continuation message")
+	}
+	c.address = binary.LittleEndian.Uint64(m.data)
+	c.size = binary.LittleEndian.Uint64(m.data[8:])
+	if c.size > math.MaxInt32 {
+		return c, fmt.Errorf("hdf5: continuation block of %d bytes", c.size)
+	}
+	return c, nil
+}
+
+// readUint decodes a little-endian unsigned integer of 1 to 8 bytes.
+func readUint(b []byte) uint64 {
+	var v uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	return v
+}
+
+// links returns the object header addresses of the members of the group with the given header messages.
+func (f *file) links(messages []message) (map[string]uint64, error) {
+	links := make(map[string]uint64)
+	for _, m := range messages {
+		switch m.typ {
+		case msgSymbolTable:
+			if len(m.data) < 16 {
+				return nil, errors.New("hdf5: truncated symbol table message")
+			}
+			heap, err := f.localHeap(binary.LittleEndian.Uint64(m.data[8:]))
+			if err != nil {
+				return nil, err
+			}
+			if err := f.btreeLinks(binary.LittleEndian.Uint64(m.data), heap, links, 0); err != nil {
+				return nil, err
+			}
+		case msgLinkInfo:
+			if len(m.data) < 18 {
+				return nil, errors.New("hdf5: truncated link info message")
+			}
+			offset := 2
+			if m.data[1]&1 != 0 {
+				offset += 8 // max creation index
+			}
+			if binary.LittleEndian.Uint64(m.data[offset:]) != undefined {
+				return nil, unsupported("dense link storage")
+			}
+		case msgLink:
+			name, address, err := link(m.data)
+			if err != nil {
+				return nil, err
+			}
+			if address != undefined {
+				links[name] = address
+			}
+		}
+	}
+	return links, nil
+}
+
+// link decodes a link message, soft and external links have an undefined address.
+func link(b []byte) (name string, address uint64, err error) {
+	if len(b) < 2 || b[0] != 1 {
+		return "", 0, errors.New("hdf5: invalid link message")
+	}
+	flags := b[1]
+	b = b[2:]
+	optional := 0
+	if flags&0x08 != 0 {
+		optional++ // link type
+	}
+	if flags&0x04 != 0 {
+		optional += 8 // creation order
+	}
+	if flags&0x10 != 0 {
+		optional++ // charset
+	}
+	width := 1 << (flags & 3)
+	if len(b) < optional+width {
+		return "", 0, errors.New("hdf5: truncated link message")
+	}
+	linkType := byte(0)
+	if flags&0x08 != 0 {
+		linkType = b[0]
+	}
+	b = b[optional:]
+	if len(b) < width {
+		return "", 0, errors.New("hdf5: truncated link message")
+	}
+	n := int(readUint(b[:width]))
+	b = b[width:]
+	if len(b) < n {
+		return "", 0, errors.New("hdf5: truncated link message")
+	}
+	name, b = string(b[:n]), b[n:]
+	if linkType != 0 {
+		return name, undefined, nil
+	}
+	if len(b) < 8 {
+		return "", 0, errors.New("hdf5: truncated link message")
+	}
+	return name, binary.LittleEndian.Uint64(b), nil
+}
+
+func (f *file) localHeap(address uint64) ([]byte, error) {
+	header, err := f.read(address, 32)
+	if err != nil {
+		return nil, err
+	}
+	if string(header[:4]) != "HEAP" {
+		return nil, errors.New("hdf5: invalid local heap signature")
+	}
+	size := binary.LittleEndian.Uint64(header[8:])
+	if size > math.MaxInt32 {
+		return nil, fmt.Errorf("hdf5: local heap of %d bytes", size)
+	}
+	return f.read(binary.LittleEndian.Uint64(header[24:]), int(size))
+}
+
+func heapString(heap []byte, offset uint64) (string, error) {
+	if offset >= uint64(len(heap)) {
+		return "", errors.New("hdf5: name offset out of the local heap")
+	}
+	s := heap[offset:]
+	if i := bytes.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return string(s), nil
+}
+
+// btreeLinks adds the symbols of the group B-tree node at address to links.
+func (f *file) btreeLinks(address uint64, heap []byte, links map[string]uint64, depth int) error {
+	if depth > 64 {
+		return errors.New("hdf5: B-tree too deep")
+	}
+	header, err := f.read(address, 24)
+	if err != nil {
+		return err
+	}
+	if string(header[:4]) != "TREE" || header[4] != 0 {
+		return errors.New("hdf5: invalid group B-tree node")
+	}
+	level, entries := header[5], int(binary.LittleEndian.Uint16(header[6:]))
+	body, err := f.read(address+24, entries*16+8)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		child := binary.LittleEndian.Uint64(body[8+16*i:])
+		if level > 0 {
+			err = f.btreeLinks(child, heap, links, depth+1)
+		} else {
+			err = f.symbolNode(child, heap, links)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *file) symbolNode(address uint64, heap []byte, links map[string]uint64) error {
+	header, err := f.read(address, 8)
+	if err != nil {
+		return err
+	}
+	if string(header[:4]) != "SNOD" {
+		return errors.New("hdf5: invalid symbol table node signature")
+	}
+	count := int(binary.LittleEndian.Uint16(header[6:]))
+	entries, err := f.read(address+8, count*symbolSize)
+	if err != nil {
+		return err
+	}
+	for i := range count {
+		e := entries[i*symbolSize:]
+		name, err := heapString(heap, binary.LittleEndian.Uint64(e))
+		if err != nil {
+			return err
+		}
+		links[name] = binary.LittleEndian.Uint64(e[8:])
+	}
+	return nil
+}
+
+// ReadDataset reads the dataset at path, e.g. "x_train" or "mnist/train/images", converted to float64.
+func ReadDataset(r io.ReaderAt, path string) (Dataset, error) {
+	f, err := open(r)
+	if err != nil {
+		return Dataset{}, err
+	}
+
+	address := f.root
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		messages, err := f.messages(address)
+		if err != nil {
+			return Dataset{}, err
+		}
+		links, err := f.links(messages)
+		if err != nil {
+			return Dataset{}, err
+		}
+		var ok bool
+		if address, ok = links[name]; !ok {
+			return Dataset{}, fmt.Errorf("%w: %q", ErrNotFound, path)
+		}
+	}
+
+	messages, err := f.messages(address)
+	if err != nil {
+		return Dataset{}, err
+	}
+	ds, err := f.dataset(messages)
+	if err != nil {
+		return Dataset{}, fmt.Errorf("hdf5: reading %q: %w", path, err)
+	}
+	ds.Name = path
+	return ds, nil
+}
+
+// datatype describes a little-endian numeric element.
+type datatype struct {
+	float  bool
+	signed bool
+	size   int
+}
+
+func (t datatype) decode(b []byte) float64 {
+	switch {
+	case t.float && t.size == 4:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	case t.float:
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	case t.signed:
+		shift := 64 - 8*t.size
+		return float64(int64(readUint(b[:t.size])<<shift) >> shift)
+	default:
+		return float64(readUint(b[:t.size]))
+	}
+}
+
+func (f *file) dataset(messages []message) (Dataset, error) {
+	var ds Dataset
+	var t datatype
+	var raw []byte
+	var haveSpace, haveType, haveLayout bool
+	n := 1
+	for _, m := range messages {
+		b := m.data
+		switch m.typ {
+		case msgDataspace:
+			if len(b) < 4 {
+				return ds, errors.New("truncated dataspace message")
+			}
+			rank, offset := int(b[1]), 8
+			switch b[0] {
+			case 1:
+			case 2:
+				offset = 4
+				if b[3] == 2 {
+					n = 0 // null dataspace
+				}
+			default:
+				return ds, unsupported("dataspace version %d", b[0])
+			}
+			if len(b) < offset+8*rank {
+				return ds, errors.New("truncated dataspace message")
+			}
+			ds.Shape = make([]int, rank)
+			for i := range ds.Shape {
+				ds.Shape[i] = int(binary.LittleEndian.Uint64(b[offset+8*i:]))
+				n *= ds.Shape[i]
+			}
+			haveSpace = true
+		case msgDatatype:
+			if len(b) < 8 {
+				return ds, errors.New("truncated datatype message")
+			}
+			class, bits := b[0]&0x0f, b[1]
+			t.size = int(binary.LittleEndian.Uint32(b[4:]))
+			switch {
+			case class == 0 && bits&1 == 0 && (t.size == 1 || t.size == 2 || t.size == 4 || t.size == 8):
+				t.signed = bits&0x08 != 0
+			case class == 1 && bits&0x41 == 0 && (t.size == 4 || t.size == 8):
+				t.float = true
+			default:
+				return ds, unsupported("datatype class %d of %d bytes, byte order bits %#x", class, t.size, bits&0x41)
+			}
+			haveType = true
+		case msgLayout:
+			if len(b) < 2 || b[0] < 3 {
+				return ds, unsupported("data layout message version %d", b[0])
+			}
+			switch b[1] {
+			case 0: // compact
+				if len(b) < 4 || len(b) < 4+int(binary.LittleEndian.Uint16(b[2:])) {
+					return ds, errors.New("truncated compact layout")
+				}
+				raw = b[4 : 4+int(binary.LittleEndian.Uint16(b[2:]))]
+			case 1: // contiguous
+				if len(b) < 18 {
+					return ds, errors.New("truncated contiguous layout")
+				}
+				raw = nil
+				if address := binary.LittleEndian.Uint64(b[2:]); address != undefined {
+					size := binary.LittleEndian.Uint64(b[10:])
+					if size > math.MaxInt32 {
+						return ds, fmt.Errorf("dataset of %d bytes", size)
+					}
+					var err error
+					if raw, err = f.read(address, int(size)); err != nil {
+						return ds, err
+					}
+				}
+			case 2:
+				return ds, unsupported("chunked layout")
+			default:
+				return ds, unsupported("layout class %d", b[1])
+			}
+			haveLayout = true
+		}
+	}
+	if !haveSpace || !haveType || !haveLayout {
+		return ds, errors.New("not a dataset")
+	}
+
+	ds.Data = make([]float64, n)
+	if raw == nil {
+		// never written, the default fill value is zero
+		return ds, nil
+	}
+	if len(raw) < n*t.size {
+		return ds, fmt.Errorf("expected %d bytes of data, got %d", n*t.size, len(raw))
+	}
+	for i := range ds.Data {
+		ds.Data[i] = t.decode(raw[i*t.size:])
+	}
+	return ds, nil
+}
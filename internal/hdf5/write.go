@@ -0,0 +1,230 @@
+package hdf5
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	superblockSize = 96
+	// internalK is the group internal node K, the B-tree of the root group has a single leaf node
+	internalK      = 16
+	btreeSize      = 24 + 2*internalK*8 + (2*internalK+1)*8
+	symbolSize     = 40
+	objectHeaderV1 = 16
+	// heapFreeNull marks an empty local heap free list
+	heapFreeNull = 1
+)
+
+// writer appends little-endian values to the file image.
+type writer struct {
+	b []byte
+}
+
+func (w *writer) u8(v uint8)   { w.b = append(w.b, v) }
+func (w *writer) u16(v uint16) { w.b = binary.LittleEndian.AppendUint16(w.b, v) }
+func (w *writer) u32(v uint32) { w.b = binary.LittleEndian.AppendUint32(w.b, v) }
+func (w *writer) u64(v uint64) { w.b = binary.LittleEndian.AppendUint64(w.b, v) }
+func (w *writer) zeros(n int)  { w.b = append(w.b, make([]byte, n)...) }
+
+type message struct {
+	typ   uint16
+	flags uint8
+	data  []byte
+}
+
+// objectHeader appends a version 1 object header with the messages, padded to 8 bytes.
+func (w *writer) objectHeader(messages ...message) {
+	size := objectHeaderSize(messages...) - objectHeaderV1
+	w.u8(1)
+	w.u8(0)
+	w.u16(uint16(len(messages)))
+	w.u32(1) // reference count
+	w.u32(uint32(size))
+	w.zeros(4)
+	for _, m := range messages {
+		w.u16(m.typ)
+		w.u16(uint16(align8(len(m.data))))
+		w.u8(m.flags)
+		w.zeros(3)
+		w.b = append(w.b, m.data...)
+		w.zeros(align8(len(m.data)) - len(m.data))
+	}
+}
+
+func objectHeaderSize(messages ...message) int {
+	size := objectHeaderV1
+	for _, m := range messages {
+		size += 8 + align8(len(m.data))
+	}
+	return size
+}
+
+// symbol appends a symbol table entry.
+func (w *writer) symbol(nameOffset, objectHeader uint64, cacheType uint32, scratch ...uint64) {
+	w.u64(nameOffset)
+	w.u64(objectHeader)
+	w.u32(cacheType)
+	w.zeros(4)
+	for _, v := range scratch {
+		w.u64(v)
+	}
+	w.zeros(16 - 8*len(scratch))
+}
+
+func align8(n int) int {
+	return (n + 7) &^ 7
+}
+
+func datasetMessages(ds Dataset, dataAddress uint64) []message {
+	dataspace := &writer{}
+	dataspace.u8(1)
+	dataspace.u8(uint8(len(ds.Shape)))
+	dataspace.zeros(6)
+	for _, d := range ds.Shape {
+		dataspace.u64(uint64(d))
+	}
+
+	// IEEE 754 little-endian double
+	datatype := &writer{}
+	datatype.b = append(datatype.b, 0x11, 0x20, 63, 0)
+	datatype.u32(8)
+	datatype.u16(0)  // bit offset
+	datatype.u16(64) // precision
+	datatype.b = append(datatype.b, 52, 11, 0, 52)
+	datatype.u32(1023)
+
+	// version 2, allocated early, written if set, undefined fill value
+	fill := []byte{2, 1, 2, 0}
+
+	layout := &writer{}
+	layout.u8(3)
+	layout.u8(1) // contiguous
+	layout.u64(dataAddress)
+	layout.u64(uint64(8 * len(ds.Data)))
+
+	return []message{
+		{typ: msgDataspace, data: dataspace.b},
+		{typ: msgDatatype, flags: 1, data: datatype.b},
+		{typ: msgFillValue, flags: 1, data: fill},
+		{typ: msgLayout, data: layout.b},
+	}
+}
+
+// Write writes the float64 datasets in the root group of a new HDF5 file.
+// Names must be unique and can't contain '/'.
+func Write(out io.Writer, datasets ...Dataset) error {
+	datasets = slices.Clone(datasets)
+	// symbols are sorted by name in the symbol table node
+	slices.SortFunc(datasets, func(a, b Dataset) int { return cmp.Compare(a.Name, b.Name) })
+	for i, ds := range datasets {
+		if err := validate(ds); err != nil {
+			return err
+		}
+		if strings.ContainsRune(ds.Name, '/') {
+			return fmt.Errorf("hdf5: dataset name %q can't contain '/'", ds.Name)
+		}
+		if i > 0 && datasets[i-1].Name == ds.Name {
+			return fmt.Errorf("hdf5: duplicate dataset name %q", ds.Name)
+		}
+	}
+
+	// the local heap holds the names, the empty name of the root group first
+	heapData := make([]byte, 8)
+	nameOffsets := make([]uint64, len(datasets))
+	for i, ds := range datasets {
+		nameOffsets[i] = uint64(len(heapData))
+		heapData = append(heapData, ds.Name...)
+		heapData = append(heapData, make([]byte, align8(len(ds.Name)+1)-len(ds.Name))...)
+	}
+	leafK := max(4, (len(datasets)+1)/2)
+	if leafK > math.MaxUint16 {
+		return fmt.Errorf("hdf5: too many datasets, %d", len(datasets))
+	}
+
+	rootMessages := []message{{typ: msgSymbolTable, data: make([]byte, 16)}}
+	rootAddress := uint64(superblockSize)
+	heapAddress := rootAddress + uint64(objectHeaderSize(rootMessages...))
+	heapDataAddress := heapAddress + 32
+	btreeAddress := heapDataAddress + uint64(len(heapData))
+	snodAddress := btreeAddress + btreeSize
+	datasetsAddress := snodAddress + 8 + uint64(2*leafK*symbolSize)
+	address := datasetsAddress
+
+	headerAddresses := make([]uint64, len(datasets))
+	dataAddresses := make([]uint64, len(datasets))
+	for i, ds := range datasets {
+		headerAddresses[i] = address
+		address += uint64(objectHeaderSize(datasetMessages(ds, 0)...))
+		dataAddresses[i] = undefined
+		if len(ds.Data) > 0 {
+			dataAddresses[i] = address
+			address += uint64(8 * len(ds.Data))
+		}
+	}
+	eof := address
+
+	w := &writer{b: make([]byte, 0, eof)}
+	w.b = append(w.b, signature...)
+	w.b = append(w.b, 0, 0, 0, 0, 0, 8, 8, 0)
+	w.u16(uint16(leafK))
+	w.u16(internalK)
+	w.u32(0) // file consistency flags
+	w.u64(0) // base address
+	w.u64(undefined)
+	w.u64(eof)
+	w.u64(undefined)
+	w.symbol(0, rootAddress, 1, btreeAddress, heapAddress)
+
+	binary.LittleEndian.PutUint64(rootMessages[0].data, btreeAddress)
+	binary.LittleEndian.PutUint64(rootMessages[0].data[8:], heapAddress)
+	w.objectHeader(rootMessages...)
+
+	w.b = append(w.b, "HEAP"...)
+	w.u8(0)
+	w.zeros(3)
+	w.u64(uint64(len(heapData)))
+	w.u64(heapFreeNull)
+	w.u64(heapDataAddress)
+	w.b = append(w.b, heapData...)
+
+	w.b = append(w.b, "TREE"...)
+	w.u8(0) // group node
+	w.u8(0) // leaf
+	// an empty group has no symbol table node
+	w.u16(uint16(min(len(datasets), 1)))
+	w.u64(undefined)
+	w.u64(undefined)
+	w.u64(0) // key 0, the empty name
+	w.u64(snodAddress)
+	if len(datasets) > 0 {
+		w.u64(nameOffsets[len(datasets)-1])
+	} else {
+		w.u64(0)
+	}
+	w.zeros(int(snodAddress) - len(w.b))
+
+	w.b = append(w.b, "SNOD"...)
+	w.u8(1)
+	w.u8(0)
+	w.u16(uint16(len(datasets)))
+	for i := range datasets {
+		w.symbol(nameOffsets[i], headerAddresses[i], 0)
+	}
+	w.zeros(int(datasetsAddress) - len(w.b))
+
+	for i, ds := range datasets {
+		w.objectHeader(datasetMessages(ds, dataAddresses[i])...)
+		for _, v := range ds.Data {
+			w.u64(math.Float64bits(v))
+		}
+	}
+
+	_, err := out.Write(w.b)
+	return err
+}
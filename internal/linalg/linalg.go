@@ -0,0 +1,88 @@
+// Package linalg provides the few dense linear algebra routines needed by the ART models.
+// Matrices are square, stored in row-major order in a flat slice.
+package linalg
+
+import (
+	"errors"
+	"math"
+)
+
+// Cholesky computes the lower triangular matrix L such that a = L * L^T,
+// and stores it in l. It returns false if a is not positive definite.
+func Cholesky(a []float64, n int, l []float64) bool {
+	clear(l)
+	for i := range n {
+		for j := 0; j <= i; j++ {
+			sum := a[i*n+j]
+			for k := range j {
+				sum -= l[i*n+k] * l[j*n+k]
+			}
+			if i == j {
+				if sum <= 0 || math.IsNaN(sum) {
+					return false
+				}
+				l[i*n+i] = math.Sqrt(sum)
+			} else {
+				l[i*n+j] = sum / l[j*n+j]
+			}
+		}
+	}
+	return true
+}
+
+// maxJitterRetries bounds the retries of CholeskyJitter,
+// the jitter grows up to minJitter * 10^maxJitterRetries.
+const maxJitterRetries = 20
+
+// ErrNotPositiveDefinite is returned by CholeskyJitter when even the largest jitter
+// doesn't make the matrix positive definite, e.g.: because it contains NaN or Inf.
+var ErrNotPositiveDefinite = errors.New("linalg: matrix is not positive definite")
+
+// CholeskyJitter works like Cholesky, but if a is not positive definite
+// it retries adding an increasing jitter to the diagonal, starting from minJitter.
+// It returns the jitter eventually added, or ErrNotPositiveDefinite
+// after maxJitterRetries retries.
+func CholeskyJitter(a []float64, n int, l []float64, minJitter float64) (float64, error) {
+	if Cholesky(a, n, l) {
+		return 0, nil
+	}
+
+	tmp := make([]float64, len(a))
+	jitter := minJitter
+	for range maxJitterRetries {
+		copy(tmp, a)
+		for i := range n {
+			tmp[i*n+i] += jitter
+		}
+		if Cholesky(tmp, n, l) {
+			return jitter, nil
+		}
+		jitter *= 10
+	}
+	return 0, ErrNotPositiveDefinite
+}
+
+// LogDet returns the natural logarithm of the determinant
+// of the matrix decomposed by Cholesky in l.
+func LogDet(l []float64, n int) float64 {
+	var sum float64
+	for i := range n {
+		sum += math.Log(l[i*n+i])
+	}
+	return 2 * sum
+}
+
+// Mahalanobis returns the squared Mahalanobis distance (x-mu)^T * (L*L^T)^-1 * (x-mu),
+// solving L*z = x-mu by forward substitution. z must have length n.
+func Mahalanobis(l []float64, n int, x, mu, z []float64) float64 {
+	var sum float64
+	for i := range n {
+		v := x[i] - mu[i]
+		for k := range i {
+			v -= l[i*n+k] * z[k]
+		}
+		z[i] = v / l[i*n+i]
+		sum += z[i] * z[i]
+	}
+	return sum
+}
@@ -0,0 +1,59 @@
+package linalg
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	a := []float64{
+		4, 2,
+		2, 3,
+	}
+	l := make([]float64, 4)
+	if !Cholesky(a, 2, l) {
+		t.Fatal("expected a positive definite matrix")
+	}
+	want := []float64{2, 0, 1, math.Sqrt(2)}
+	for i := range want {
+		if math.Abs(l[i]-want[i]) > 1e-12 {
+			t.Errorf("l[%d]: expected %f, got %f", i, want[i], l[i])
+		}
+	}
+	if got, want := LogDet(l, 2), math.Log(8); math.Abs(got-want) > 1e-12 {
+		t.Errorf("expected log determinant %f, got %f", want, got)
+	}
+
+	// (x-mu)^T * a^-1 * (x-mu), with a^-1 = [3 -2; -2 4] / 8
+	z := make([]float64, 2)
+	if got, want := Mahalanobis(l, 2, []float64{1, 1}, []float64{0, 0}, z), 3.0/8; math.Abs(got-want) > 1e-12 {
+		t.Errorf("expected Mahalanobis distance %f, got %f", want, got)
+	}
+}
+
+func TestCholeskyJitter(t *testing.T) {
+	tests := []struct {
+		name       string
+		a          []float64
+		wantJitter bool
+		wantErr    error
+	}{
+		{"positive definite", []float64{1, 0, 0, 1}, false, nil},
+		{"singular", []float64{1, 1, 1, 1}, true, nil},
+		{"NaN", []float64{math.NaN(), 0, 0, 1}, false, ErrNotPositiveDefinite},
+		{"Inf", []float64{1, math.Inf(1), math.Inf(1), 1}, false, ErrNotPositiveDefinite},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := make([]float64, 4)
+			jitter, err := CholeskyJitter(tt.a, 2, l, 1e-9)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if (jitter > 0) != tt.wantJitter {
+				t.Errorf("unexpected jitter %g", jitter)
+			}
+		})
+	}
+}
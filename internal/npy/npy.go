@@ -0,0 +1,138 @@
+// Package npy reads and writes float64 arrays in the NumPy .npy format.
+package npy
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var magic = []byte("\x93NUMPY\x01\x00")
+
+// header returns the .npy header for a little-endian float64 C-order array of the given shape,
+// padded so that the data starts on a 64 bytes boundary.
+func header(shape []int) []byte {
+	dims := make([]string, len(shape))
+	for i, d := range shape {
+		dims[i] = fmt.Sprint(d)
+	}
+	s := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		s += ","
+	}
+	dict := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': (%s), }", s)
+
+	// magic + 2 bytes header length + dict + padding + '\n'
+	total := len(magic) + 2 + len(dict) + 1
+	padding := (64 - total%64) % 64
+	dict += strings.Repeat(" ", padding) + "\n"
+
+	h := make([]byte, 0, len(magic)+2+len(dict))
+	h = append(h, magic...)
+	h = binary.LittleEndian.AppendUint16(h, uint16(len(dict)))
+	return append(h, dict...)
+}
+
+// WriteFloat64 writes data as a float64 array with the given shape,
+// the product of shape must equal len(data).
+func WriteFloat64(w io.Writer, data []float64, shape ...int) error {
+	n := 1
+	for _, d := range shape {
+		n *= d
+	}
+	if n != len(data) {
+		return fmt.Errorf("npy: shape %v doesn't match %d values", shape, len(data))
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(header(shape)); err != nil {
+		return err
+	}
+	var buf [8]byte
+	for _, v := range data {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		if _, err := bw.Write(buf[:]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+var (
+	descrRE   = regexp.MustCompile(`'descr':\s*'([^']*)'`)
+	fortranRE = regexp.MustCompile(`'fortran_order':\s*(True|False)`)
+	shapeRE   = regexp.MustCompile(`'shape':\s*\(([^)]*)\)`)
+)
+
+// ReadFloat64 reads a little-endian float64 C-order array, .npy versions 1.0 to 3.0.
+func ReadFloat64(r io.Reader) (data []float64, shape []int, err error) {
+	br := bufio.NewReader(r)
+	prefix := make([]byte, 8)
+	if _, err := io.ReadFull(br, prefix); err != nil {
+		return nil, nil, fmt.Errorf("npy: reading magic: %w", err)
+	}
+	if string(prefix[:6]) != "\x93NUMPY" {
+		return nil, nil, fmt.Errorf("npy: not a .npy file")
+	}
+
+	var headerLen int
+	switch prefix[6] {
+	case 1:
+		buf := make([]byte, 2)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, nil, err
+		}
+		headerLen = int(binary.LittleEndian.Uint16(buf))
+	case 2, 3:
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, nil, err
+		}
+		headerLen = int(binary.LittleEndian.Uint32(buf))
+	default:
+		return nil, nil, fmt.Errorf("npy: unsupported version %d.%d", prefix[6], prefix[7])
+	}
+	dictBuf := make([]byte, headerLen)
+	if _, err := io.ReadFull(br, dictBuf); err != nil {
+		return nil, nil, fmt.Errorf("npy: reading header: %w", err)
+	}
+	dict := string(dictBuf)
+
+	if m := descrRE.FindStringSubmatch(dict); m == nil || m[1] != "<f8" {
+		return nil, nil, fmt.Errorf("npy: only little-endian float64 ('<f8') arrays are supported, header %q", dict)
+	}
+	if m := fortranRE.FindStringSubmatch(dict); m == nil || m[1] != "False" {
+		return nil, nil, fmt.Errorf("npy: only C-order arrays are supported")
+	}
+	m := shapeRE.FindStringSubmatch(dict)
+	if m == nil {
+		return nil, nil, fmt.Errorf("npy: missing shape in header %q", dict)
+	}
+	n := 1
+	for _, d := range strings.Split(m[1], ",") {
+		if d = strings.TrimSpace(d); d == "" {
+			continue
+		}
+		v, err := strconv.Atoi(d)
+		if err != nil || v < 0 {
+			return nil, nil, fmt.Errorf("npy: invalid shape %q", m[1])
+		}
+		shape = append(shape, v)
+		n *= v
+	}
+
+	raw := make([]byte, 8*n)
+	if _, err := io.ReadFull(br, raw); err != nil {
+		return nil, nil, fmt.Errorf("npy: reading data: %w", err)
+	}
+	data = make([]float64, n)
+	for i := range data {
+		data[i] = math.Float64frombits(binary.LittleEndian.Uint64(raw[8*i:]))
+	}
+	return data, shape, nil
+}
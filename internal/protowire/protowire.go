@@ -0,0 +1,234 @@
+// Package protowire encodes and decodes the Protocol Buffers wire format,
+// the subset needed by the hand-written model messages, without depending on the protobuf runtime.
+package protowire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Type is a wire type.
+type Type int
+
+const (
+	VarintType  Type = 0
+	Fixed64Type Type = 1
+	BytesType   Type = 2
+	Fixed32Type Type = 5
+)
+
+var errTruncated = errors.New("protowire: truncated message")
+
+// AppendTag appends the key of field number num with wire type t.
+func AppendTag(b []byte, num int, t Type) []byte {
+	return binary.AppendUvarint(b, uint64(num)<<3|uint64(t))
+}
+
+// AppendVarint appends field num as a varint, omitting zero values like proto3.
+func AppendVarint(b []byte, num int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	return binary.AppendUvarint(AppendTag(b, num, VarintType), v)
+}
+
+// AppendInt64 appends field num as a varint even when zero,
+// for proto2 optional fields whose presence is meaningful.
+func AppendInt64(b []byte, num int, v int64) []byte {
+	return binary.AppendUvarint(AppendTag(b, num, VarintType), uint64(v))
+}
+
+// AppendDouble appends field num as a double, omitting zero values like proto3.
+func AppendDouble(b []byte, num int, v float64) []byte {
+	if v == 0 {
+		return b
+	}
+	return binary.LittleEndian.AppendUint64(AppendTag(b, num, Fixed64Type), math.Float64bits(v))
+}
+
+// AppendBytes appends field num as length-delimited bytes, that is a string or an embedded message.
+func AppendBytes(b []byte, num int, v []byte) []byte {
+	b = binary.AppendUvarint(AppendTag(b, num, BytesType), uint64(len(v)))
+	return append(b, v...)
+}
+
+// AppendString appends field num as a string, omitting empty strings like proto3.
+func AppendString(b []byte, num int, v string) []byte {
+	if v == "" {
+		return b
+	}
+	return AppendBytes(b, num, []byte(v))
+}
+
+// AppendPackedDoubles appends field num as a packed repeated double.
+func AppendPackedDoubles(b []byte, num int, v []float64) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(AppendTag(b, num, BytesType), uint64(8*len(v)))
+	for _, x := range v {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(x))
+	}
+	return b
+}
+
+// AppendPackedVarints appends field num as a packed repeated varint.
+func AppendPackedVarints(b []byte, num int, v []uint64) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	var payload []byte
+	for _, x := range v {
+		payload = binary.AppendUvarint(payload, x)
+	}
+	return AppendBytes(b, num, payload)
+}
+
+// Decoder reads the fields of a message in order, the first error is kept in Err.
+type Decoder struct {
+	data []byte
+	Err  error
+}
+
+// NewDecoder returns a decoder of the message data.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Next reads the key of the next field, it returns false at the end of the message or on errors.
+func (d *Decoder) Next() (num int, t Type, ok bool) {
+	if d.Err != nil || len(d.data) == 0 {
+		return 0, 0, false
+	}
+	key := d.uvarint()
+	if d.Err != nil {
+		return 0, 0, false
+	}
+	num, t = int(key>>3), Type(key&7)
+	if num <= 0 {
+		d.Err = fmt.Errorf("protowire: invalid field number %d", num)
+		return 0, 0, false
+	}
+	return num, t, true
+}
+
+func (d *Decoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.Err = errTruncated
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *Decoder) fixed64() uint64 {
+	if len(d.data) < 8 {
+		d.Err = errTruncated
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(d.data)
+	d.data = d.data[8:]
+	return v
+}
+
+// expect records an error unless the field has wire type want.
+func (d *Decoder) expect(got, want Type) bool {
+	if got != want {
+		d.Err = fmt.Errorf("protowire: unexpected wire type %d, expected %d", got, want)
+		return false
+	}
+	return true
+}
+
+// Varint reads a varint field value of wire type t.
+func (d *Decoder) Varint(t Type) uint64 {
+	if !d.expect(t, VarintType) {
+		return 0
+	}
+	return d.uvarint()
+}
+
+// Double reads a double field value of wire type t.
+func (d *Decoder) Double(t Type) float64 {
+	if !d.expect(t, Fixed64Type) {
+		return 0
+	}
+	return math.Float64frombits(d.fixed64())
+}
+
+// Bytes reads a length-delimited field value of wire type t, sharing the message data.
+func (d *Decoder) Bytes(t Type) []byte {
+	if !d.expect(t, BytesType) {
+		return nil
+	}
+	n := d.uvarint()
+	if d.Err != nil {
+		return nil
+	}
+	if n > uint64(len(d.data)) {
+		d.Err = errTruncated
+		return nil
+	}
+	v := d.data[:n]
+	d.data = d.data[n:]
+	return v
+}
+
+// String reads a string field value of wire type t.
+func (d *Decoder) String(t Type) string {
+	return string(d.Bytes(t))
+}
+
+// Doubles appends the values of a repeated double field to v,
+// accepting both the packed and the unpacked encodings.
+func (d *Decoder) Doubles(t Type, v []float64) []float64 {
+	if t == Fixed64Type {
+		return append(v, d.Double(t))
+	}
+	payload := d.Bytes(t)
+	if len(payload)%8 != 0 {
+		d.Err = fmt.Errorf("protowire: packed doubles length %d is not a multiple of 8", len(payload))
+		return v
+	}
+	for i := 0; i < len(payload); i += 8 {
+		v = append(v, math.Float64frombits(binary.LittleEndian.Uint64(payload[i:])))
+	}
+	return v
+}
+
+// Varints appends the values of a repeated varint field to v,
+// accepting both the packed and the unpacked encodings.
+func (d *Decoder) Varints(t Type, v []uint64) []uint64 {
+	if t == VarintType {
+		return append(v, d.Varint(t))
+	}
+	packed := NewDecoder(d.Bytes(t))
+	for d.Err == nil && len(packed.data) > 0 {
+		v = append(v, packed.uvarint())
+		d.Err = packed.Err
+	}
+	return v
+}
+
+// Skip skips a field value of wire type t, unknown fields are skipped for forward compatibility.
+func (d *Decoder) Skip(t Type) {
+	switch t {
+	case VarintType:
+		d.uvarint()
+	case Fixed64Type:
+		d.fixed64()
+	case BytesType:
+		d.Bytes(t)
+	case Fixed32Type:
+		if len(d.data) < 4 {
+			d.Err = errTruncated
+			return
+		}
+		d.data = d.data[4:]
+	default:
+		d.Err = fmt.Errorf("protowire: unsupported wire type %d", t)
+	}
+}
@@ -1,4 +1,4 @@
-//go:build darwin && arm64
+//go:build darwin && arm64 && cgo
 
 package simd
 
@@ -8,6 +8,7 @@ import "unsafe"
 #cgo CFLAGS: -O3
 #cgo LDFLAGS: -framework Accelerate
 #include <Accelerate/Accelerate.h>
+#include <stdlib.h>
 
 double accelerate_fuzzy_intersection_norm(const size_t n, double *A, double *w, double *fuzzy_intersection_out, double *w_norm_out) {
     // Compute min(A[i], w[i])
@@ -23,6 +24,66 @@ double accelerate_fuzzy_intersection_norm(const size_t n, double *A, double *w,
     return intersection_norm;
 }
 
+double accelerate_fuzzy_intersection(const size_t n, double *A, double *w, double *fuzzy_intersection_out) {
+    vDSP_vminD(A, 1, w, 1, fuzzy_intersection_out, 1, n);
+
+    double intersection_norm = 0.0;
+    vDSP_sveD(fuzzy_intersection_out, 1, &intersection_norm, n);
+
+    return intersection_norm;
+}
+
+void accelerate_fuzzy_intersection_norm_batch(const size_t n, double *A, double *w, const size_t rows, double *fi_norms_out) {
+    double *fi = malloc(n * sizeof(double));
+    for (size_t r = 0; r < rows; ++r) {
+        vDSP_vminD(A, 1, w + r * n, 1, fi, 1, n);
+        vDSP_sveD(fi, 1, fi_norms_out + r, n);
+    }
+    free(fi);
+}
+
+void accelerate_complement_intersection_norm_batch(const size_t n, double *a, double *w, const size_t stride, const size_t rows, double *fi_norms_out) {
+    // the complement 1-a is computed once for all the rows
+    double *complement = malloc(2 * n * sizeof(double));
+    double *fi = complement + n;
+    double minus_one = -1.0, one = 1.0;
+    vDSP_vsmsaD(a, 1, &minus_one, &one, complement, 1, n);
+    for (size_t r = 0; r < rows; ++r) {
+        double lower_norm = 0.0, upper_norm = 0.0;
+        vDSP_vminD(a, 1, w + r * stride, 1, fi, 1, n);
+        vDSP_sveD(fi, 1, &lower_norm, n);
+        vDSP_vminD(complement, 1, w + r * stride + n, 1, fi, 1, n);
+        vDSP_sveD(fi, 1, &upper_norm, n);
+        fi_norms_out[r] = lower_norm + upper_norm;
+    }
+    free(complement);
+}
+
+void accelerate_fuzzy_intersection_norm_batch32(const size_t n, float *A, float *w, const size_t rows, float *fi_norms_out) {
+    float *fi = malloc(n * sizeof(float));
+    for (size_t r = 0; r < rows; ++r) {
+        vDSP_vmin(A, 1, w + r * n, 1, fi, 1, n);
+        vDSP_sve(fi, 1, fi_norms_out + r, n);
+    }
+    free(fi);
+}
+
+void accelerate_complement_intersection_norm_batch32(const size_t n, float *a, float *w, const size_t stride, const size_t rows, float *fi_norms_out) {
+    float *complement = malloc(2 * n * sizeof(float));
+    float *fi = complement + n;
+    float minus_one = -1.0f, one = 1.0f;
+    vDSP_vsmsa(a, 1, &minus_one, &one, complement, 1, n);
+    for (size_t r = 0; r < rows; ++r) {
+        float lower_norm = 0.0f, upper_norm = 0.0f;
+        vDSP_vmin(a, 1, w + r * stride, 1, fi, 1, n);
+        vDSP_sve(fi, 1, &lower_norm, n);
+        vDSP_vmin(complement, 1, w + r * stride + n, 1, fi, 1, n);
+        vDSP_sve(fi, 1, &upper_norm, n);
+        fi_norms_out[r] = lower_norm + upper_norm;
+    }
+    free(complement);
+}
+
 double accelerate_sum(const size_t n, double *arr) {
     double sum = 0.0;
     vDSP_sveD(arr, 1, &sum, n);
@@ -51,9 +112,13 @@ import "C"
 // Accelerate implements Provider with Apple's Accelerate framework
 type Accelerate struct{}
 
-func GetProvider() Provider {
+func natives() []backend {
 	// todo: check if available
-	return new(Accelerate)
+	p := new(Accelerate)
+	return []backend{{name: "accelerate", provider: p, provider32: &kernels32{
+		batch:      p.FuzzyIntersectionNormBatch32,
+		complement: p.ComplementIntersectionNormBatch32,
+	}}}
 }
 
 func (p *Accelerate) FuzzyIntersectionNorm(A, w []float64, fuzzyIntersectionOut []float64) (float64, float64) {
@@ -69,6 +134,87 @@ func (p *Accelerate) FuzzyIntersectionNorm(A, w []float64, fuzzyIntersectionOut
 	return float64(fiNormOut), float64(wNormOut)
 }
 
+func (p *Accelerate) FuzzyIntersection(A, w []float64, fuzzyIntersectionOut []float64) float64 {
+	fiNorm := C.accelerate_fuzzy_intersection(
+		(C.size_t)(len(A)),
+		(*C.double)(&A[0]),
+		(*C.double)(&w[0]),
+		(*C.double)(&fuzzyIntersectionOut[0]),
+	)
+
+	return float64(fiNorm)
+}
+
+func (p *Accelerate) FuzzyIntersectionNormBatch(A, Wflat []float64, rows int, fiNormsOut []float64) {
+	if rows == 0 {
+		return
+	}
+	_ = Wflat[rows*len(A)-1]
+	_ = fiNormsOut[rows-1]
+
+	C.accelerate_fuzzy_intersection_norm_batch(
+		(C.size_t)(len(A)),
+		(*C.double)(&A[0]),
+		(*C.double)(&Wflat[0]),
+		(C.size_t)(rows),
+		(*C.double)(&fiNormsOut[0]),
+	)
+}
+
+func (p *Accelerate) ComplementIntersectionNormBatch(a, Wflat []float64, stride, rows int, fiNormsOut []float64) {
+	if rows == 0 || len(a) == 0 {
+		clear(fiNormsOut[:rows])
+		return
+	}
+	_ = Wflat[(rows-1)*stride+2*len(a)-1]
+	_ = fiNormsOut[rows-1]
+
+	C.accelerate_complement_intersection_norm_batch(
+		(C.size_t)(len(a)),
+		(*C.double)(&a[0]),
+		(*C.double)(&Wflat[0]),
+		(C.size_t)(stride),
+		(C.size_t)(rows),
+		(*C.double)(&fiNormsOut[0]),
+	)
+}
+
+// FuzzyIntersectionNormBatch32 works like FuzzyIntersectionNormBatch on float32 vectors.
+func (p *Accelerate) FuzzyIntersectionNormBatch32(A, Wflat []float32, rows int, fiNormsOut []float32) {
+	if rows == 0 {
+		return
+	}
+	_ = Wflat[rows*len(A)-1]
+	_ = fiNormsOut[rows-1]
+
+	C.accelerate_fuzzy_intersection_norm_batch32(
+		(C.size_t)(len(A)),
+		(*C.float)(&A[0]),
+		(*C.float)(&Wflat[0]),
+		(C.size_t)(rows),
+		(*C.float)(&fiNormsOut[0]),
+	)
+}
+
+// ComplementIntersectionNormBatch32 works like ComplementIntersectionNormBatch on float32 vectors.
+func (p *Accelerate) ComplementIntersectionNormBatch32(a, Wflat []float32, stride, rows int, fiNormsOut []float32) {
+	if rows == 0 || len(a) == 0 {
+		clear(fiNormsOut[:rows])
+		return
+	}
+	_ = Wflat[(rows-1)*stride+2*len(a)-1]
+	_ = fiNormsOut[rows-1]
+
+	C.accelerate_complement_intersection_norm_batch32(
+		(C.size_t)(len(a)),
+		(*C.float)(&a[0]),
+		(*C.float)(&Wflat[0]),
+		(C.size_t)(stride),
+		(C.size_t)(rows),
+		(*C.float)(&fiNormsOut[0]),
+	)
+}
+
 func (p *Accelerate) SumFloat64(arr []float64) float64 {
 	sum := C.accelerate_sum(
 		(C.size_t)(len(arr)),
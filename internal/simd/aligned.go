@@ -0,0 +1,40 @@
+package simd
+
+import "unsafe"
+
+const (
+	// Alignment is the alignment in bytes of the buffers of MakeAligned, a cache line and an AVX-512 register.
+	Alignment = 64
+	// Lanes is the number of float64 values in Alignment bytes.
+	Lanes = Alignment / 8
+)
+
+// Padded rounds n up to a multiple of Lanes.
+func Padded(n int) int {
+	return PaddedOf[float64](n)
+}
+
+// PaddedOf works like Padded, rounding n up to the number of values of T in Alignment bytes.
+func PaddedOf[T Float](n int) int {
+	lanes := Alignment / int(unsafe.Sizeof(T(0)))
+	return (n + lanes - 1) / lanes * lanes
+}
+
+// MakeAligned returns a zeroed slice of n values starting on an Alignment boundary,
+// with a capacity of Padded(n): the padding past n is zero, and stays zero as long as only the first n values are written.
+// The kernels read and write exactly len values, the alignment and the padding let the callers
+// lay out vectors on vector boundaries, e.g. the rows of a matrix.
+func MakeAligned(n int) []float64 {
+	return MakeAlignedOf[float64](n)
+}
+
+// MakeAlignedOf works like MakeAligned for any Float type, with a capacity of PaddedOf[T](n).
+func MakeAlignedOf[T Float](n int) []T {
+	size := int(unsafe.Sizeof(T(0)))
+	lanes := Alignment / size
+	padded := PaddedOf[T](n)
+	buf := make([]T, padded+lanes-1)
+	// the garbage collector doesn't move heap objects, the offset stays valid
+	offset := int(-uintptr(unsafe.Pointer(unsafe.SliceData(buf)))%Alignment) / size
+	return buf[offset : offset+n : offset+padded]
+}
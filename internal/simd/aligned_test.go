@@ -0,0 +1,35 @@
+package simd
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestMakeAligned(t *testing.T) {
+	for _, n := range []int{1, 7, 8, 9, 24, 100} {
+		buf := MakeAligned(n)
+		if len(buf) != n || cap(buf) != Padded(n) || cap(buf)%Lanes != 0 {
+			t.Errorf("%d values: expected len %d and cap %d, got %d and %d", n, n, Padded(n), len(buf), cap(buf))
+		}
+		if addr := uintptr(unsafe.Pointer(unsafe.SliceData(buf))); addr%Alignment != 0 {
+			t.Errorf("%d values: expected a %d-byte aligned buffer, got address %#x", n, Alignment, addr)
+		}
+		for i, v := range buf[:cap(buf)] {
+			if v != 0 {
+				t.Errorf("%d values: expected zeroes, got %f at %d", n, v, i)
+			}
+		}
+	}
+}
+
+func TestMakeAlignedOf(t *testing.T) {
+	for _, n := range []int{1, 15, 16, 17, 100} {
+		buf := MakeAlignedOf[float32](n)
+		if len(buf) != n || cap(buf) != PaddedOf[float32](n) || cap(buf)%(2*Lanes) != 0 {
+			t.Errorf("%d values: expected len %d and cap %d, got %d and %d", n, n, PaddedOf[float32](n), len(buf), cap(buf))
+		}
+		if addr := uintptr(unsafe.Pointer(unsafe.SliceData(buf))); addr%Alignment != 0 {
+			t.Errorf("%d values: expected a %d-byte aligned buffer, got address %#x", n, Alignment, addr)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+//go:build amd64
+
+package simd
+
+import "golang.org/x/sys/cpu"
+
+// AVX2 is the Provider of the x86 CPUs with AVX2, written in Go assembly so that it builds without cgo.
+// Its kernels read exactly len(A) values, handling the ones left by the vector width in scalar code.
+type AVX2 struct{}
+
+func hasAVX2() bool {
+	return cpu.X86.HasAVX2
+}
+
+// natives returns the backends supported by the CPU, the fastest first:
+// AVX-512 if the package is built with the avx512 tag and cgo, then AVX2.
+func natives() []backend {
+	var backends []backend
+	if p, p32 := avx512Provider(); p != nil {
+		backends = append(backends, backend{name: "avx512", provider: p, provider32: p32})
+	}
+	if hasAVX2() {
+		backends = append(backends, backend{name: "avx2", provider: new(AVX2), provider32: minSumKernels32(minSumAVX2F32)})
+	}
+	return backends
+}
+
+//go:noescape
+func fuzzyIntersectionNormAVX2(A, w, fi []float64) (fiNorm, wNorm float64)
+
+//go:noescape
+func fuzzyIntersectionAVX2(A, w, fi []float64) (fiNorm float64)
+
+//go:noescape
+func minSumAVX2(A, w []float64) (fiNorm float64)
+
+//go:noescape
+func complementMinSumAVX2(a, w []float64) (fiNorm float64)
+
+//go:noescape
+func minSumAVX2F32(A, w []float32) (fiNorm float32)
+
+//go:noescape
+func sumAVX2(arr []float64) (sum float64)
+
+//go:noescape
+func updateFuzzyWeightsAVX2(W, fi []float64, beta float64)
+
+// FuzzyIntersectionNorm computes elementwise min between A and w and returns the sum and the sum of w
+func (p *AVX2) FuzzyIntersectionNorm(A, w []float64, fuzzyIntersectionOut []float64) (float64, float64) {
+	return fuzzyIntersectionNormAVX2(A, w[:len(A)], fuzzyIntersectionOut[:len(A)])
+}
+
+// FuzzyIntersection computes elementwise min between A and w and returns its sum, without summing w
+func (p *AVX2) FuzzyIntersection(A, w []float64, fuzzyIntersectionOut []float64) float64 {
+	return fuzzyIntersectionAVX2(A, w[:len(A)], fuzzyIntersectionOut[:len(A)])
+}
+
+// FuzzyIntersectionNormBatch computes the fuzzy intersection norm of A with rows rows of Wflat.
+func (p *AVX2) FuzzyIntersectionNormBatch(A, Wflat []float64, rows int, fiNormsOut []float64) {
+	n := len(A)
+	for r := range rows {
+		fiNormsOut[r] = minSumAVX2(A, Wflat[r*n:(r+1)*n])
+	}
+}
+
+// ComplementIntersectionNormBatch computes the fuzzy intersection norm of the complement coding of a
+// with rows rows of Wflat, stride values apart.
+func (p *AVX2) ComplementIntersectionNormBatch(a, Wflat []float64, stride, rows int, fiNormsOut []float64) {
+	n := 2 * len(a)
+	for r := range rows {
+		fiNormsOut[r] = complementMinSumAVX2(a, Wflat[r*stride:r*stride+n])
+	}
+}
+
+// SumFloat64 computes the sum of all elements in the array using AVX2
+func (p *AVX2) SumFloat64(arr []float64) float64 {
+	return sumAVX2(arr)
+}
+
+// UpdateFuzzyWeights updates weights using AVX2 acceleration
+// weights[i] = beta * fi[i] + (1-beta) * weights[i]
+func (p *AVX2) UpdateFuzzyWeights(W []float64, fi []float64, beta float64) {
+	updateFuzzyWeightsAVX2(W, fi[:len(W)], beta)
+}
@@ -0,0 +1,343 @@
+#include "textflag.h"
+
+// The kernels process 8 doubles per iteration in 2 AVX registers,
+// then the values left by the vector width one at a time.
+// VMINPD returns its second source when the values are equal, like the generic A < w ? A : w.
+
+// func fuzzyIntersectionNormAVX2(A, w, fi []float64) (fiNorm, wNorm float64)
+TEXT ·fuzzyIntersectionNormAVX2(SB), NOSPLIT, $0-88
+	MOVQ A_base+0(FP), SI
+	MOVQ A_len+8(FP), CX
+	MOVQ w_base+24(FP), DI
+	MOVQ fi_base+48(FP), DX
+	VXORPD Y0, Y0, Y0 // fi sums
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2 // w sums
+	VXORPD Y3, Y3, Y3
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-8, BX
+
+fin_loop:
+	CMPQ AX, BX
+	JGE  fin_reduce
+	VMOVUPD (SI)(AX*8), Y4
+	VMOVUPD 32(SI)(AX*8), Y5
+	VMOVUPD (DI)(AX*8), Y6
+	VMOVUPD 32(DI)(AX*8), Y7
+	VMINPD  Y6, Y4, Y4
+	VMINPD  Y7, Y5, Y5
+	VMOVUPD Y4, (DX)(AX*8)
+	VMOVUPD Y5, 32(DX)(AX*8)
+	VADDPD  Y4, Y0, Y0
+	VADDPD  Y5, Y1, Y1
+	VADDPD  Y6, Y2, Y2
+	VADDPD  Y7, Y3, Y3
+	ADDQ    $8, AX
+	JMP     fin_loop
+
+fin_reduce:
+	VADDPD       Y1, Y0, Y0
+	VADDPD       Y3, Y2, Y2
+	VEXTRACTF128 $1, Y0, X1
+	VADDPD       X1, X0, X0
+	VUNPCKHPD    X0, X0, X1
+	VADDSD       X1, X0, X0
+	VEXTRACTF128 $1, Y2, X3
+	VADDPD       X3, X2, X2
+	VUNPCKHPD    X2, X2, X3
+	VADDSD       X3, X2, X2
+
+fin_tail:
+	CMPQ   AX, CX
+	JGE    fin_done
+	VMOVSD (SI)(AX*8), X4
+	VMOVSD (DI)(AX*8), X6
+	VMINSD X6, X4, X4
+	VMOVSD X4, (DX)(AX*8)
+	VADDSD X4, X0, X0
+	VADDSD X6, X2, X2
+	INCQ   AX
+	JMP    fin_tail
+
+fin_done:
+	VZEROUPPER
+	MOVSD X0, fiNorm+72(FP)
+	MOVSD X2, wNorm+80(FP)
+	RET
+
+// func fuzzyIntersectionAVX2(A, w, fi []float64) (fiNorm float64)
+TEXT ·fuzzyIntersectionAVX2(SB), NOSPLIT, $0-80
+	MOVQ A_base+0(FP), SI
+	MOVQ A_len+8(FP), CX
+	MOVQ w_base+24(FP), DI
+	MOVQ fi_base+48(FP), DX
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-8, BX
+
+fi_loop:
+	CMPQ AX, BX
+	JGE  fi_reduce
+	VMOVUPD (SI)(AX*8), Y4
+	VMOVUPD 32(SI)(AX*8), Y5
+	VMINPD  (DI)(AX*8), Y4, Y4
+	VMINPD  32(DI)(AX*8), Y5, Y5
+	VMOVUPD Y4, (DX)(AX*8)
+	VMOVUPD Y5, 32(DX)(AX*8)
+	VADDPD  Y4, Y0, Y0
+	VADDPD  Y5, Y1, Y1
+	ADDQ    $8, AX
+	JMP     fi_loop
+
+fi_reduce:
+	VADDPD       Y1, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPD       X1, X0, X0
+	VUNPCKHPD    X0, X0, X1
+	VADDSD       X1, X0, X0
+
+fi_tail:
+	CMPQ   AX, CX
+	JGE    fi_done
+	VMOVSD (SI)(AX*8), X4
+	VMINSD (DI)(AX*8), X4, X4
+	VMOVSD X4, (DX)(AX*8)
+	VADDSD X4, X0, X0
+	INCQ   AX
+	JMP    fi_tail
+
+fi_done:
+	VZEROUPPER
+	MOVSD X0, fiNorm+72(FP)
+	RET
+
+// func minSumAVX2(A, w []float64) (fiNorm float64)
+TEXT ·minSumAVX2(SB), NOSPLIT, $0-56
+	MOVQ A_base+0(FP), SI
+	MOVQ A_len+8(FP), CX
+	MOVQ w_base+24(FP), DI
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-8, BX
+
+ms_loop:
+	CMPQ AX, BX
+	JGE  ms_reduce
+	VMOVUPD (SI)(AX*8), Y4
+	VMOVUPD 32(SI)(AX*8), Y5
+	VMINPD  (DI)(AX*8), Y4, Y4
+	VMINPD  32(DI)(AX*8), Y5, Y5
+	VADDPD  Y4, Y0, Y0
+	VADDPD  Y5, Y1, Y1
+	ADDQ    $8, AX
+	JMP     ms_loop
+
+ms_reduce:
+	VADDPD       Y1, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPD       X1, X0, X0
+	VUNPCKHPD    X0, X0, X1
+	VADDSD       X1, X0, X0
+
+ms_tail:
+	CMPQ   AX, CX
+	JGE    ms_done
+	VMOVSD (SI)(AX*8), X4
+	VMINSD (DI)(AX*8), X4, X4
+	VADDSD X4, X0, X0
+	INCQ   AX
+	JMP    ms_tail
+
+ms_done:
+	VZEROUPPER
+	MOVSD X0, fiNorm+48(FP)
+	RET
+
+// func complementMinSumAVX2(a, w []float64) (fiNorm float64)
+// w holds 2*len(a) values, the lower half is intersected with a and the upper half with 1-a.
+TEXT ·complementMinSumAVX2(SB), NOSPLIT, $0-56
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), CX
+	MOVQ w_base+24(FP), DI
+	LEAQ (DI)(CX*8), R8 // upper half of w
+	MOVQ $0x3ff0000000000000, AX // 1.0
+	MOVQ AX, X15
+	VBROADCASTSD X15, Y15
+	VXORPD Y0, Y0, Y0 // a sums
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2 // 1-a sums
+	VXORPD Y3, Y3, Y3
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-8, BX
+
+cms_loop:
+	CMPQ AX, BX
+	JGE  cms_reduce
+	VMOVUPD (SI)(AX*8), Y4
+	VMOVUPD 32(SI)(AX*8), Y5
+	VSUBPD  Y4, Y15, Y6
+	VSUBPD  Y5, Y15, Y7
+	VMINPD  (DI)(AX*8), Y4, Y4
+	VMINPD  32(DI)(AX*8), Y5, Y5
+	VMINPD  (R8)(AX*8), Y6, Y6
+	VMINPD  32(R8)(AX*8), Y7, Y7
+	VADDPD  Y4, Y0, Y0
+	VADDPD  Y5, Y1, Y1
+	VADDPD  Y6, Y2, Y2
+	VADDPD  Y7, Y3, Y3
+	ADDQ    $8, AX
+	JMP     cms_loop
+
+cms_reduce:
+	VADDPD       Y1, Y0, Y0
+	VADDPD       Y3, Y2, Y2
+	VADDPD       Y2, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPD       X1, X0, X0
+	VUNPCKHPD    X0, X0, X1
+	VADDSD       X1, X0, X0
+
+cms_tail:
+	CMPQ   AX, CX
+	JGE    cms_done
+	VMOVSD (SI)(AX*8), X4
+	VSUBSD X4, X15, X6
+	VMINSD (DI)(AX*8), X4, X4
+	VMINSD (R8)(AX*8), X6, X6
+	VADDSD X4, X0, X0
+	VADDSD X6, X0, X0
+	INCQ   AX
+	JMP    cms_tail
+
+cms_done:
+	VZEROUPPER
+	MOVSD X0, fiNorm+48(FP)
+	RET
+
+// func sumAVX2(arr []float64) (sum float64)
+TEXT ·sumAVX2(SB), NOSPLIT, $0-32
+	MOVQ arr_base+0(FP), SI
+	MOVQ arr_len+8(FP), CX
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-8, BX
+
+sum_loop:
+	CMPQ AX, BX
+	JGE  sum_reduce
+	VADDPD (SI)(AX*8), Y0, Y0
+	VADDPD 32(SI)(AX*8), Y1, Y1
+	ADDQ   $8, AX
+	JMP    sum_loop
+
+sum_reduce:
+	VADDPD       Y1, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPD       X1, X0, X0
+	VUNPCKHPD    X0, X0, X1
+	VADDSD       X1, X0, X0
+
+sum_tail:
+	CMPQ   AX, CX
+	JGE    sum_done
+	VADDSD (SI)(AX*8), X0, X0
+	INCQ   AX
+	JMP    sum_tail
+
+sum_done:
+	VZEROUPPER
+	MOVSD X0, sum+24(FP)
+	RET
+
+// func updateFuzzyWeightsAVX2(W, fi []float64, beta float64)
+TEXT ·updateFuzzyWeightsAVX2(SB), NOSPLIT, $0-56
+	MOVQ W_base+0(FP), DI
+	MOVQ W_len+8(FP), CX
+	MOVQ fi_base+24(FP), SI
+	VBROADCASTSD beta+48(FP), Y0
+	MOVQ $0x3ff0000000000000, AX // 1.0
+	MOVQ AX, X1
+	VSUBSD X0, X1, X1
+	VBROADCASTSD X1, Y1
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-4, BX
+
+ufw_loop:
+	CMPQ AX, BX
+	JGE  ufw_tail
+	VMULPD  (SI)(AX*8), Y0, Y2 // beta * fi
+	VMULPD  (DI)(AX*8), Y1, Y3 // (1-beta) * W
+	VADDPD  Y3, Y2, Y2
+	VMOVUPD Y2, (DI)(AX*8)
+	ADDQ    $4, AX
+	JMP     ufw_loop
+
+ufw_tail:
+	CMPQ   AX, CX
+	JGE    ufw_done
+	VMULSD (SI)(AX*8), X0, X2
+	VMULSD (DI)(AX*8), X1, X3
+	VADDSD X3, X2, X2
+	VMOVSD X2, (DI)(AX*8)
+	INCQ   AX
+	JMP    ufw_tail
+
+ufw_done:
+	VZEROUPPER
+	RET
+
+// func minSumAVX2F32(A, w []float32) (fiNorm float32)
+// The float32 kernel processes 16 floats per iteration in 2 AVX registers.
+TEXT ·minSumAVX2F32(SB), NOSPLIT, $0-52
+	MOVQ A_base+0(FP), SI
+	MOVQ A_len+8(FP), CX
+	MOVQ w_base+24(FP), DI
+	VXORPS Y0, Y0, Y0
+	VXORPS Y1, Y1, Y1
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-16, BX
+
+ms32_loop:
+	CMPQ AX, BX
+	JGE  ms32_reduce
+	VMOVUPS (SI)(AX*4), Y4
+	VMOVUPS 32(SI)(AX*4), Y5
+	VMINPS  (DI)(AX*4), Y4, Y4
+	VMINPS  32(DI)(AX*4), Y5, Y5
+	VADDPS  Y4, Y0, Y0
+	VADDPS  Y5, Y1, Y1
+	ADDQ    $16, AX
+	JMP     ms32_loop
+
+ms32_reduce:
+	VADDPS       Y1, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPS       X1, X0, X0
+	VMOVHLPS     X0, X0, X1
+	VADDPS       X1, X0, X0
+	VMOVSHDUP    X0, X1
+	VADDSS       X1, X0, X0
+
+ms32_tail:
+	CMPQ   AX, CX
+	JGE    ms32_done
+	VMOVSS (SI)(AX*4), X4
+	VMINSS (DI)(AX*4), X4, X4
+	VADDSS X4, X0, X0
+	INCQ   AX
+	JMP    ms32_tail
+
+ms32_done:
+	VZEROUPPER
+	MOVSS X0, fiNorm+48(FP)
+	RET
@@ -0,0 +1,17 @@
+//go:build amd64
+
+package simd
+
+import "testing"
+
+func TestAVX2(t *testing.T) {
+	if !hasAVX2() {
+		t.Skip("the CPU doesn't support AVX2")
+	}
+	p := new(AVX2)
+	if err := selfCheck(p); err != nil {
+		t.Fatal(err)
+	}
+
+	testTails[float64](t, p)
+}
@@ -0,0 +1,440 @@
+//go:build amd64 && cgo
+
+package avx512
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/cpu"
+)
+
+/*
+#cgo CFLAGS: -mavx512f -mavx512dq -mavx512vl -O3 -fPIC
+#cgo CXXFLAGS: -mavx512f -mavx512dq -mavx512vl -O3 -fPIC -std=c++17
+#cgo LDFLAGS: -lm -lstdc++
+#include <stdio.h>
+#include <math.h>
+#include <stdlib.h>
+#include <stdint.h>
+#include <x86intrin.h>
+
+// Computes the fuzzy intersection (elementwise min) between two arrays and returns the sum
+double avx512_fuzzy_intersection_norm(const size_t n, double *A, double *w, double *fuzzy_intersection_out, double *w_norm_out)
+{
+    static const size_t single_size = 8; // 8 doubles per AVX-512 register
+    static const size_t chunk_size = 2 * single_size; // Process 2 chunks (16 doubles) per iteration
+    const size_t end = n / chunk_size;
+
+    __m512d sum_vec1 = _mm512_setzero_pd();
+    __m512d sum_vec2 = _mm512_setzero_pd();
+    __m512d w_sum_vec1 = _mm512_setzero_pd();
+    __m512d w_sum_vec2 = _mm512_setzero_pd();
+
+    // Process 16 doubles (2 chunks) at a time
+    for(size_t i = 0; i < end; ++i) {
+        size_t offset = i * chunk_size;
+
+        // First chunk
+        __m512d a_vec1 = _mm512_loadu_pd(A + offset);
+        __m512d w_vec1 = _mm512_loadu_pd(w + offset);
+        __m512d min_vec1 = _mm512_min_pd(a_vec1, w_vec1);
+        _mm512_storeu_pd(fuzzy_intersection_out + offset, min_vec1);
+        sum_vec1 = _mm512_add_pd(sum_vec1, min_vec1);
+        w_sum_vec1 = _mm512_add_pd(w_sum_vec1, w_vec1);
+
+        // Second chunk
+        __m512d a_vec2 = _mm512_loadu_pd(A + offset + single_size);
+        __m512d w_vec2 = _mm512_loadu_pd(w + offset + single_size);
+        __m512d min_vec2 = _mm512_min_pd(a_vec2, w_vec2);
+        _mm512_storeu_pd(fuzzy_intersection_out + offset + single_size, min_vec2);
+        sum_vec2 = _mm512_add_pd(sum_vec2, min_vec2);
+        w_sum_vec2 = _mm512_add_pd(w_sum_vec2, w_vec2);
+    }
+
+    // Combine sums from both vectors
+    double sum = _mm512_reduce_add_pd(sum_vec1) + _mm512_reduce_add_pd(sum_vec2);
+    double w_sum = _mm512_reduce_add_pd(w_sum_vec1) + _mm512_reduce_add_pd(w_sum_vec2);
+
+    // Handle remaining elements
+    for(size_t i = end * chunk_size; i < n; ++i) {
+        double min_val = A[i] < w[i] ? A[i] : w[i];
+        fuzzy_intersection_out[i] = min_val;
+        sum += min_val;
+        w_sum += w[i];
+    }
+
+    // Store the sum of w elements to the output parameter
+    *w_norm_out = w_sum;
+
+    return sum;
+}
+
+// Computes the fuzzy intersection (elementwise min) between two arrays and returns its sum,
+// without summing w
+double avx512_fuzzy_intersection(const size_t n, double *A, double *w, double *fuzzy_intersection_out)
+{
+    static const size_t single_size = 8; // 8 doubles per AVX-512 register
+    static const size_t chunk_size = 2 * single_size; // Process 2 chunks (16 doubles) per iteration
+    const size_t end = n / chunk_size;
+
+    __m512d sum_vec1 = _mm512_setzero_pd();
+    __m512d sum_vec2 = _mm512_setzero_pd();
+
+    // Process 16 doubles (2 chunks) at a time
+    for(size_t i = 0; i < end; ++i) {
+        size_t offset = i * chunk_size;
+
+        __m512d min_vec1 = _mm512_min_pd(_mm512_loadu_pd(A + offset), _mm512_loadu_pd(w + offset));
+        _mm512_storeu_pd(fuzzy_intersection_out + offset, min_vec1);
+        sum_vec1 = _mm512_add_pd(sum_vec1, min_vec1);
+
+        __m512d min_vec2 = _mm512_min_pd(_mm512_loadu_pd(A + offset + single_size), _mm512_loadu_pd(w + offset + single_size));
+        _mm512_storeu_pd(fuzzy_intersection_out + offset + single_size, min_vec2);
+        sum_vec2 = _mm512_add_pd(sum_vec2, min_vec2);
+    }
+
+    double sum = _mm512_reduce_add_pd(sum_vec1) + _mm512_reduce_add_pd(sum_vec2);
+
+    // Handle remaining elements
+    for(size_t i = end * chunk_size; i < n; ++i) {
+        double min_val = A[i] < w[i] ? A[i] : w[i];
+        fuzzy_intersection_out[i] = min_val;
+        sum += min_val;
+    }
+
+    return sum;
+}
+
+// Computes the fuzzy intersection norm of A with rows consecutive rows of n values of w,
+// storing them in fi_norms_out
+void avx512_fuzzy_intersection_norm_batch(const size_t n, double *A, double *w, const size_t rows, double *fi_norms_out)
+{
+    static const size_t single_size = 8; // 8 doubles per AVX-512 register
+    static const size_t chunk_size = 2 * single_size; // Process 2 chunks (16 doubles) per iteration
+    const size_t end = n / chunk_size;
+
+    for(size_t r = 0; r < rows; ++r) {
+        const double *row = w + r * n;
+        __m512d sum_vec1 = _mm512_setzero_pd();
+        __m512d sum_vec2 = _mm512_setzero_pd();
+
+        for(size_t i = 0; i < end; ++i) {
+            size_t offset = i * chunk_size;
+            sum_vec1 = _mm512_add_pd(sum_vec1, _mm512_min_pd(_mm512_loadu_pd(A + offset), _mm512_loadu_pd(row + offset)));
+            sum_vec2 = _mm512_add_pd(sum_vec2, _mm512_min_pd(_mm512_loadu_pd(A + offset + single_size), _mm512_loadu_pd(row + offset + single_size)));
+        }
+
+        double sum = _mm512_reduce_add_pd(sum_vec1) + _mm512_reduce_add_pd(sum_vec2);
+
+        // Handle remaining elements
+        for(size_t i = end * chunk_size; i < n; ++i) {
+            sum += A[i] < row[i] ? A[i] : row[i];
+        }
+        fi_norms_out[r] = sum;
+    }
+}
+
+// Computes the fuzzy intersection norm of the complement coding of a, [a, 1-a], with rows rows of 2n values of w,
+// stride values apart, storing them in fi_norms_out. 1-a is computed in registers, in the same pass over a.
+void avx512_complement_intersection_norm_batch(const size_t n, double *a, double *w, const size_t stride, const size_t rows, double *fi_norms_out)
+{
+    static const size_t single_size = 8; // 8 doubles per AVX-512 register
+    const size_t end = n / single_size;
+    const __m512d ones = _mm512_set1_pd(1.0);
+
+    for(size_t r = 0; r < rows; ++r) {
+        const double *lower = w + r * stride;
+        const double *upper = lower + n;
+        __m512d sum_vec1 = _mm512_setzero_pd();
+        __m512d sum_vec2 = _mm512_setzero_pd();
+
+        for(size_t i = 0; i < end; ++i) {
+            size_t offset = i * single_size;
+            __m512d a_vec = _mm512_loadu_pd(a + offset);
+            sum_vec1 = _mm512_add_pd(sum_vec1, _mm512_min_pd(a_vec, _mm512_loadu_pd(lower + offset)));
+            sum_vec2 = _mm512_add_pd(sum_vec2, _mm512_min_pd(_mm512_sub_pd(ones, a_vec), _mm512_loadu_pd(upper + offset)));
+        }
+
+        double sum = _mm512_reduce_add_pd(sum_vec1) + _mm512_reduce_add_pd(sum_vec2);
+
+        // Handle remaining elements
+        for(size_t i = end * single_size; i < n; ++i) {
+            double complement = 1.0 - a[i];
+            sum += a[i] < lower[i] ? a[i] : lower[i];
+            sum += complement < upper[i] ? complement : upper[i];
+        }
+        fi_norms_out[r] = sum;
+    }
+}
+
+// Computes the fuzzy intersection norm of A with rows consecutive rows of n float32 values of w,
+// 16 floats per AVX-512 register
+void avx512_fuzzy_intersection_norm_batch32(const size_t n, float *A, float *w, const size_t rows, float *fi_norms_out)
+{
+    static const size_t single_size = 16;
+    const size_t end = n / single_size;
+
+    for(size_t r = 0; r < rows; ++r) {
+        const float *row = w + r * n;
+        __m512 sum_vec = _mm512_setzero_ps();
+
+        for(size_t i = 0; i < end; ++i) {
+            size_t offset = i * single_size;
+            sum_vec = _mm512_add_ps(sum_vec, _mm512_min_ps(_mm512_loadu_ps(A + offset), _mm512_loadu_ps(row + offset)));
+        }
+
+        float sum = _mm512_reduce_add_ps(sum_vec);
+
+        // Handle remaining elements
+        for(size_t i = end * single_size; i < n; ++i) {
+            sum += A[i] < row[i] ? A[i] : row[i];
+        }
+        fi_norms_out[r] = sum;
+    }
+}
+
+// Computes the fuzzy intersection norm of the complement coding of a, [a, 1-a], with rows rows of 2n float32 values of w,
+// stride values apart, storing them in fi_norms_out, 16 floats per AVX-512 register
+void avx512_complement_intersection_norm_batch32(const size_t n, float *a, float *w, const size_t stride, const size_t rows, float *fi_norms_out)
+{
+    static const size_t single_size = 16;
+    const size_t end = n / single_size;
+    const __m512 ones = _mm512_set1_ps(1.0f);
+
+    for(size_t r = 0; r < rows; ++r) {
+        const float *lower = w + r * stride;
+        const float *upper = lower + n;
+        __m512 sum_vec1 = _mm512_setzero_ps();
+        __m512 sum_vec2 = _mm512_setzero_ps();
+
+        for(size_t i = 0; i < end; ++i) {
+            size_t offset = i * single_size;
+            __m512 a_vec = _mm512_loadu_ps(a + offset);
+            sum_vec1 = _mm512_add_ps(sum_vec1, _mm512_min_ps(a_vec, _mm512_loadu_ps(lower + offset)));
+            sum_vec2 = _mm512_add_ps(sum_vec2, _mm512_min_ps(_mm512_sub_ps(ones, a_vec), _mm512_loadu_ps(upper + offset)));
+        }
+
+        float sum = _mm512_reduce_add_ps(sum_vec1) + _mm512_reduce_add_ps(sum_vec2);
+
+        // Handle remaining elements
+        for(size_t i = end * single_size; i < n; ++i) {
+            float complement = 1.0f - a[i];
+            sum += a[i] < lower[i] ? a[i] : lower[i];
+            sum += complement < upper[i] ? complement : upper[i];
+        }
+        fi_norms_out[r] = sum;
+    }
+}
+
+// Computes the sum of an array using AVX-512 with 2 chunks per iteration
+double avx512_sum(const size_t n, double *arr)
+{
+    static const size_t single_size = 8; // 8 doubles per AVX-512 register
+    static const size_t chunk_count = 2; // Process 2 chunks per iteration
+    const size_t chunk_size = single_size * chunk_count; // 16 doubles per iteration
+    const size_t end = n / chunk_size;
+
+    __m512d sum_vec1 = _mm512_setzero_pd();
+    __m512d sum_vec2 = _mm512_setzero_pd();
+
+    // Process 16 doubles at a time (2 chunks of 8 doubles each)
+    for(size_t i = 0; i < end; ++i) {
+        __m512d arr_vec1 = _mm512_loadu_pd(arr + i * chunk_size);
+        __m512d arr_vec2 = _mm512_loadu_pd(arr + i * chunk_size + single_size);
+
+        sum_vec1 = _mm512_add_pd(sum_vec1, arr_vec1);
+        sum_vec2 = _mm512_add_pd(sum_vec2, arr_vec2);
+    }
+
+    // Combine the two sum vectors
+    __m512d total_sum_vec = _mm512_add_pd(sum_vec1, sum_vec2);
+
+    // Reduce sum vector to a single value using AVX-512 intrinsic
+    double sum = _mm512_reduce_add_pd(total_sum_vec);
+
+    // Handle remaining elements
+    for(size_t i = end * chunk_size; i < n; ++i) {
+        sum += arr[i];
+    }
+
+    return sum;
+}
+
+// update_fuzzy_weights updates weights using the formula:
+// weights[i] = beta * fi[i] + (1-beta) * weights[i]
+void update_fuzzy_weights(double* weights, const double* fi, double beta, int length) {
+    int i = 0;
+
+    // Get the value of (1-beta) once
+    double oneminusbeta = 1.0 - beta;
+
+    // Process 8 elements at a time using AVX512
+    if (length >= 8) {
+        __m512d beta_vec = _mm512_set1_pd(beta);
+        __m512d oneminusbeta_vec = _mm512_set1_pd(oneminusbeta);
+
+        for (; i <= length - 8; i += 8) {
+            __m512d weights_vec = _mm512_loadu_pd(&weights[i]);
+            __m512d fi_vec = _mm512_loadu_pd(&fi[i]);
+
+            // beta * fi
+            __m512d beta_fi = _mm512_mul_pd(beta_vec, fi_vec);
+
+            // (1-beta) * weights
+            __m512d oneminusbeta_weights = _mm512_mul_pd(oneminusbeta_vec, weights_vec);
+
+            // beta * fi + (1-beta) * weights
+            __m512d result = _mm512_add_pd(beta_fi, oneminusbeta_weights);
+
+            // Store the result back to weights
+            _mm512_storeu_pd(&weights[i], result);
+        }
+    }
+
+    // Handle remaining elements
+    for (; i < length; i++) {
+        weights[i] = beta * fi[i] + oneminusbeta * weights[i];
+    }
+}
+
+*/
+import "C"
+
+// AVX512 implements the simd Provider with AVX-512 kernels in C.
+type AVX512 struct{}
+
+// Supported reports whether the CPU supports the AVX-512 instructions of the kernels.
+func Supported() bool {
+	return cpu.X86.HasAVX512 &&
+		cpu.X86.HasAVX512F &&
+		cpu.X86.HasAVX512DQ
+}
+
+// FuzzyIntersectionNorm computes elementwise min between A and w and returns the sum and the sum of w.
+// The kernels read and write exactly len(A) values, handling the ones left by the vector width in scalar code.
+func (p *AVX512) FuzzyIntersectionNorm(A, w []float64, fuzzyIntersectionOut []float64) (float64, float64) {
+	_ = w[len(A)-1]
+	_ = fuzzyIntersectionOut[len(A)-1]
+
+	var wNormOut C.double
+	fiNormOut := C.avx512_fuzzy_intersection_norm(
+		(C.size_t)(len(A)),
+		(*C.double)(&A[0]),
+		(*C.double)(&w[0]),
+		(*C.double)(&fuzzyIntersectionOut[0]),
+		&wNormOut,
+	)
+
+	return float64(fiNormOut), float64(wNormOut)
+}
+
+// FuzzyIntersection computes elementwise min between A and w and returns its sum, without summing w
+func (p *AVX512) FuzzyIntersection(A, w []float64, fuzzyIntersectionOut []float64) float64 {
+	_ = w[len(A)-1]
+	_ = fuzzyIntersectionOut[len(A)-1]
+
+	fiNorm := C.avx512_fuzzy_intersection(
+		(C.size_t)(len(A)),
+		(*C.double)(&A[0]),
+		(*C.double)(&w[0]),
+		(*C.double)(&fuzzyIntersectionOut[0]),
+	)
+
+	return float64(fiNorm)
+}
+
+// FuzzyIntersectionNormBatch computes the fuzzy intersection norm of A with rows rows of Wflat in a single cgo call.
+// The rows are len(A) values apart, so the kernel reads exactly len(A) values per row.
+func (p *AVX512) FuzzyIntersectionNormBatch(A, Wflat []float64, rows int, fiNormsOut []float64) {
+	if rows == 0 {
+		return
+	}
+	_ = Wflat[rows*len(A)-1]
+	_ = fiNormsOut[rows-1]
+
+	C.avx512_fuzzy_intersection_norm_batch(
+		(C.size_t)(len(A)),
+		(*C.double)(&A[0]),
+		(*C.double)(&Wflat[0]),
+		(C.size_t)(rows),
+		(*C.double)(&fiNormsOut[0]),
+	)
+}
+
+// ComplementIntersectionNormBatch computes the fuzzy intersection norm of the complement coding of a
+// with rows rows of Wflat, stride values apart, in a single cgo call.
+func (p *AVX512) ComplementIntersectionNormBatch(a, Wflat []float64, stride, rows int, fiNormsOut []float64) {
+	if rows == 0 || len(a) == 0 {
+		clear(fiNormsOut[:rows])
+		return
+	}
+	_ = Wflat[(rows-1)*stride+2*len(a)-1]
+	_ = fiNormsOut[rows-1]
+
+	C.avx512_complement_intersection_norm_batch(
+		(C.size_t)(len(a)),
+		(*C.double)(&a[0]),
+		(*C.double)(&Wflat[0]),
+		(C.size_t)(stride),
+		(C.size_t)(rows),
+		(*C.double)(&fiNormsOut[0]),
+	)
+}
+
+// FuzzyIntersectionNormBatch32 works like FuzzyIntersectionNormBatch on float32 vectors.
+func (p *AVX512) FuzzyIntersectionNormBatch32(A, Wflat []float32, rows int, fiNormsOut []float32) {
+	if rows == 0 || len(A) == 0 {
+		clear(fiNormsOut[:rows])
+		return
+	}
+	_ = Wflat[rows*len(A)-1]
+	_ = fiNormsOut[rows-1]
+
+	C.avx512_fuzzy_intersection_norm_batch32(
+		(C.size_t)(len(A)),
+		(*C.float)(&A[0]),
+		(*C.float)(&Wflat[0]),
+		(C.size_t)(rows),
+		(*C.float)(&fiNormsOut[0]),
+	)
+}
+
+// ComplementIntersectionNormBatch32 works like ComplementIntersectionNormBatch on float32 vectors.
+func (p *AVX512) ComplementIntersectionNormBatch32(a, Wflat []float32, stride, rows int, fiNormsOut []float32) {
+	if rows == 0 || len(a) == 0 {
+		clear(fiNormsOut[:rows])
+		return
+	}
+	_ = Wflat[(rows-1)*stride+2*len(a)-1]
+	_ = fiNormsOut[rows-1]
+
+	C.avx512_complement_intersection_norm_batch32(
+		(C.size_t)(len(a)),
+		(*C.float)(&a[0]),
+		(*C.float)(&Wflat[0]),
+		(C.size_t)(stride),
+		(C.size_t)(rows),
+		(*C.float)(&fiNormsOut[0]),
+	)
+}
+
+// SumFloat64 computes the sum of all elements in the array using AVX-512
+func (p *AVX512) SumFloat64(arr []float64) float64 {
+	if len(arr) == 0 {
+		return 0
+	}
+	return float64(C.avx512_sum((C.size_t)(len(arr)), (*C.double)(&arr[0])))
+}
+
+// UpdateFuzzyWeights updates weights using AVX512 acceleration
+// weights[i] = beta * fi[i] + (1-beta) * weights[i]
+func (p *AVX512) UpdateFuzzyWeights(W []float64, fi []float64, beta float64) {
+	if len(W) == 0 {
+		return
+	}
+	_ = fi[len(W)-1]
+
+	weightsPtr := (*C.double)(unsafe.Pointer(&W[0]))
+	fiPtr := (*C.double)(unsafe.Pointer(&fi[0]))
+	C.update_fuzzy_weights(weightsPtr, fiPtr, C.double(beta), C.int(len(W)))
+}
@@ -0,0 +1,3 @@
+// Package avx512 holds the cgo AVX-512 kernels, used by the simd package when built with the avx512 tag.
+// They live in their own package because a package using cgo can't have Go assembly files.
+package avx512
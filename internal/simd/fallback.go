@@ -0,0 +1,199 @@
+package simd
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"unsafe"
+)
+
+// selfCheck compares the results of p with the generic provider on a few inputs,
+// to detect providers misbehaving on the current CPU before they're used.
+// The sizes include multiples of the native kernels vector widths and a remainder.
+func selfCheck(p Provider) error {
+	return selfCheckOf(p)
+}
+
+// selfCheckOf works like selfCheck for the kernels of any Float type.
+func selfCheckOf[T Float](p ProviderOf[T]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("self-check panicked: %v", r)
+		}
+	}()
+
+	ref := new(genericOf[T])
+	for _, size := range []int{8, 13, 64, 136} {
+		A, w := make([]T, size), make([]T, size)
+		for i := range A {
+			A[i] = T((i*7)%13) / 13
+			w[i] = T((i*5)%11) / 11
+		}
+
+		fi, refFi := make([]T, size), make([]T, size)
+		fiNorm, wNorm := p.FuzzyIntersectionNorm(A, w, fi)
+		refFiNorm, refWNorm := ref.FuzzyIntersectionNorm(A, w, refFi)
+		if !approxEqual(fiNorm, refFiNorm) || !approxEqual(wNorm, refWNorm) || !approxEqualAll(fi, refFi) {
+			return fmt.Errorf("FuzzyIntersectionNorm mismatch on %d elements", size)
+		}
+
+		clear(fi)
+		if fiNorm := p.FuzzyIntersection(A, w, fi); !approxEqual(fiNorm, refFiNorm) || !approxEqualAll(fi, refFi) {
+			return fmt.Errorf("FuzzyIntersection mismatch on %d elements", size)
+		}
+
+		Wflat := append(append([]T(nil), w...), A...)
+		norms := make([]T, 2)
+		p.FuzzyIntersectionNormBatch(A, Wflat, 2, norms)
+		if !approxEqual(norms[0], refFiNorm) || !approxEqual(norms[1], ref.SumFloat64(A)) {
+			return fmt.Errorf("FuzzyIntersectionNormBatch mismatch on %d elements", size)
+		}
+
+		// the complement coding of half of A, with the rows w and A stored 3 values apart
+		a := A[:size/2]
+		coded := make([]T, 2*len(a))
+		for i, v := range a {
+			coded[i], coded[len(a)+i] = v, 1-v
+		}
+		Wflat = append(append(append([]T(nil), w[:len(coded)]...), 0, 0, 0), A[:len(coded)]...)
+		p.ComplementIntersectionNormBatch(a, Wflat, len(coded)+3, 2, norms)
+		refNorms := make([]T, 2)
+		ref.FuzzyIntersectionNormBatch(coded, append(append([]T(nil), w[:len(coded)]...), A[:len(coded)]...), 2, refNorms)
+		if !approxEqualAll(norms, refNorms) {
+			return fmt.Errorf("ComplementIntersectionNormBatch mismatch on %d elements", len(a))
+		}
+
+		if !approxEqual(p.SumFloat64(A), ref.SumFloat64(A)) {
+			return fmt.Errorf("SumFloat64 mismatch on %d elements", size)
+		}
+
+		W, refW := append([]T(nil), w...), append([]T(nil), w...)
+		p.UpdateFuzzyWeights(W, refFi, 0.5)
+		ref.UpdateFuzzyWeights(refW, refFi, 0.5)
+		if !approxEqualAll(W, refW) {
+			return fmt.Errorf("UpdateFuzzyWeights mismatch on %d elements", size)
+		}
+	}
+	return nil
+}
+
+// approxEqual compares a and b with a relative tolerance of the precision of T,
+// the kernels sum the values in a different order.
+func approxEqual[T Float](a, b T) bool {
+	tolerance := 1e-9
+	if unsafe.Sizeof(a) == 4 {
+		tolerance = 1e-5
+	}
+	return math.Abs(float64(a-b)) <= tolerance*math.Max(1, math.Abs(float64(b)))
+}
+
+func approxEqualAll[T Float](a, b []T) bool {
+	for i := range a {
+		if !approxEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// fallback wraps a native provider and permanently switches to the generic one
+// the first time a native call panics, logging the event instead of crashing the process.
+// Faults inside C code (e.g.: SIGILL on an unexpected CPU) can't be recovered by Go,
+// those are caught by the startup self-check instead.
+type fallbackOf[T Float] struct {
+	native  ProviderOf[T]
+	generic genericOf[T]
+	failed  atomic.Bool
+}
+
+// fallback is the fallbackOf of the float64 kernels.
+type fallback = fallbackOf[float64]
+
+func (p *fallbackOf[T]) fail(op string, r any) {
+	if p.failed.CompareAndSwap(false, true) {
+		logf("simd: %T.%s panicked (%v), switching to the generic provider", p.native, op, r)
+	}
+}
+
+func (p *fallbackOf[T]) FuzzyIntersectionNorm(A, w []T, fuzzyIntersectionOut []T) (fiNorm T, wNorm T) {
+	if p.failed.Load() {
+		return p.generic.FuzzyIntersectionNorm(A, w, fuzzyIntersectionOut)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			p.fail("FuzzyIntersectionNorm", r)
+			fiNorm, wNorm = p.generic.FuzzyIntersectionNorm(A, w, fuzzyIntersectionOut)
+		}
+	}()
+	return p.native.FuzzyIntersectionNorm(A, w, fuzzyIntersectionOut)
+}
+
+func (p *fallbackOf[T]) FuzzyIntersection(A, w []T, fuzzyIntersectionOut []T) (fiNorm T) {
+	if p.failed.Load() {
+		return p.generic.FuzzyIntersection(A, w, fuzzyIntersectionOut)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			p.fail("FuzzyIntersection", r)
+			fiNorm = p.generic.FuzzyIntersection(A, w, fuzzyIntersectionOut)
+		}
+	}()
+	return p.native.FuzzyIntersection(A, w, fuzzyIntersectionOut)
+}
+
+func (p *fallbackOf[T]) FuzzyIntersectionNormBatch(A, Wflat []T, rows int, fiNormsOut []T) {
+	if p.failed.Load() {
+		p.generic.FuzzyIntersectionNormBatch(A, Wflat, rows, fiNormsOut)
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			p.fail("FuzzyIntersectionNormBatch", r)
+			p.generic.FuzzyIntersectionNormBatch(A, Wflat, rows, fiNormsOut)
+		}
+	}()
+	p.native.FuzzyIntersectionNormBatch(A, Wflat, rows, fiNormsOut)
+}
+
+func (p *fallbackOf[T]) ComplementIntersectionNormBatch(a, Wflat []T, stride, rows int, fiNormsOut []T) {
+	if p.failed.Load() {
+		p.generic.ComplementIntersectionNormBatch(a, Wflat, stride, rows, fiNormsOut)
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			p.fail("ComplementIntersectionNormBatch", r)
+			p.generic.ComplementIntersectionNormBatch(a, Wflat, stride, rows, fiNormsOut)
+		}
+	}()
+	p.native.ComplementIntersectionNormBatch(a, Wflat, stride, rows, fiNormsOut)
+}
+
+func (p *fallbackOf[T]) SumFloat64(arr []T) (sum T) {
+	if p.failed.Load() {
+		return p.generic.SumFloat64(arr)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			p.fail("SumFloat64", r)
+			sum = p.generic.SumFloat64(arr)
+		}
+	}()
+	return p.native.SumFloat64(arr)
+}
+
+func (p *fallbackOf[T]) UpdateFuzzyWeights(W, fi []T, beta T) {
+	if p.failed.Load() {
+		p.generic.UpdateFuzzyWeights(W, fi, beta)
+		return
+	}
+	// panics can only happen in the Go code around the kernel call, not in the middle of it,
+	// so W is never left half updated
+	defer func() {
+		if r := recover(); r != nil {
+			p.fail("UpdateFuzzyWeights", r)
+			p.generic.UpdateFuzzyWeights(W, fi, beta)
+		}
+	}()
+	p.native.UpdateFuzzyWeights(W, fi, beta)
+}
@@ -0,0 +1,57 @@
+package simd
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+// panicking is a broken provider
+type panicking struct{ generic }
+
+func (p *panicking) SumFloat64(arr []float64) float64 {
+	panic("broken kernel")
+}
+
+func TestFallback(t *testing.T) {
+	p := &fallback{native: new(panicking)}
+	if err := selfCheck(p.native); err == nil {
+		t.Error("self-check should fail on a broken provider")
+	}
+
+	if sum := p.SumFloat64([]float64{1, 2, 3}); sum != 6 {
+		t.Errorf("expected the generic sum 6, got %f", sum)
+	}
+	if !p.failed.Load() {
+		t.Error("fallback should have switched to the generic provider")
+	}
+}
+
+func TestSelfCheck(t *testing.T) {
+	if native := GetProvider(); native != nil {
+		if err := selfCheck(native); err != nil {
+			t.Errorf("%T: %v", native, err)
+		}
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	defer SetLogger(logger.Load())
+
+	var buf bytes.Buffer
+	SetLogger(log.New(&buf, "", 0))
+	p := &fallback{native: new(panicking)}
+	p.SumFloat64([]float64{1})
+	if !strings.Contains(buf.String(), "switching to the generic provider") {
+		t.Errorf("expected the switch to be logged, got %q", buf.String())
+	}
+
+	buf.Reset()
+	SetLogger(nil)
+	p = &fallback{native: new(panicking)}
+	p.SumFloat64([]float64{1})
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing logged without a logger, got %q", buf.String())
+	}
+}
@@ -0,0 +1,122 @@
+package simd
+
+import "sync"
+
+// Float is the constraint of the generic kernels, float32 halves the memory of the weights.
+type Float interface {
+	~float32 | ~float64
+}
+
+// kernels32 implements ProviderOf[float32] with the native float32 batch kernels of a backend,
+// which hold twice the values per vector register of the float64 ones. The activation of the categories
+// only runs the batch kernels, the other operations are the generic ones.
+type kernels32 struct {
+	genericOf[float32]
+	batch      func(A, Wflat []float32, rows int, fiNormsOut []float32)
+	complement func(a, Wflat []float32, stride, rows int, fiNormsOut []float32)
+}
+
+func (p *kernels32) FuzzyIntersectionNormBatch(A, Wflat []float32, rows int, fiNormsOut []float32) {
+	p.batch(A, Wflat, rows, fiNormsOut)
+}
+
+func (p *kernels32) ComplementIntersectionNormBatch(a, Wflat []float32, stride, rows int, fiNormsOut []float32) {
+	p.complement(a, Wflat, stride, rows, fiNormsOut)
+}
+
+// complements pools the complement-coded inputs of minSumKernels32.
+var complements = sync.Pool{New: func() any { return new([]float32) }}
+
+// minSumKernels32 returns the float32 kernels of a backend built on its minSum kernel, the sum of min(A, w):
+// the complement-coded batches build the complement coding of a once per call, in a pooled buffer,
+// and intersect it with every row, so that the values are summed in the same order for every row.
+func minSumKernels32(minSum func(A, w []float32) float32) *kernels32 {
+	return &kernels32{
+		batch: func(A, Wflat []float32, rows int, fiNormsOut []float32) {
+			n := len(A)
+			for r := range rows {
+				fiNormsOut[r] = minSum(A, Wflat[r*n:(r+1)*n])
+			}
+		},
+		complement: func(a, Wflat []float32, stride, rows int, fiNormsOut []float32) {
+			buf := complements.Get().(*[]float32)
+			defer complements.Put(buf)
+			n := 2 * len(a)
+			if cap(*buf) < n {
+				*buf = make([]float32, n)
+			}
+			A := (*buf)[:n]
+			for i, v := range a {
+				A[i], A[len(a)+i] = v, 1-v
+			}
+			for r := range rows {
+				fiNormsOut[r] = minSum(A, Wflat[r*stride:r*stride+n])
+			}
+		},
+	}
+}
+
+// FuzzyIntersectionNormOf works like Provider.FuzzyIntersectionNorm, in portable Go code, for any Float type.
+func FuzzyIntersectionNormOf[T Float](A, w []T, fuzzyIntersectionOut []T) (fiNorm T, wNorm T) {
+	for i := range A {
+		fuzzyIntersectionOut[i] = min(A[i], w[i])
+		fiNorm += fuzzyIntersectionOut[i]
+		wNorm += w[i]
+	}
+	return fiNorm, wNorm
+}
+
+// FuzzyIntersectionOf works like Provider.FuzzyIntersection, in portable Go code, for any Float type.
+func FuzzyIntersectionOf[T Float](A, w []T, fuzzyIntersectionOut []T) (fiNorm T) {
+	for i := range A {
+		fuzzyIntersectionOut[i] = min(A[i], w[i])
+		fiNorm += fuzzyIntersectionOut[i]
+	}
+	return fiNorm
+}
+
+// FuzzyIntersectionNormBatchOf works like Provider.FuzzyIntersectionNormBatch, in portable Go code, for any Float type.
+func FuzzyIntersectionNormBatchOf[T Float](A, Wflat []T, rows int, fiNormsOut []T) {
+	for r := range rows {
+		w := Wflat[r*len(A) : (r+1)*len(A)]
+		var fiNorm T
+		for i := range A {
+			fiNorm += min(A[i], w[i])
+		}
+		fiNormsOut[r] = fiNorm
+	}
+}
+
+// ComplementIntersectionNormBatchOf works like Provider.ComplementIntersectionNormBatch, in portable Go code, for any Float type.
+// The values are summed in the order of FuzzyIntersectionNormBatchOf on the complement-coded input, with the same result.
+func ComplementIntersectionNormBatchOf[T Float](a, Wflat []T, stride, rows int, fiNormsOut []T) {
+	for r := range rows {
+		w := Wflat[r*stride : r*stride+2*len(a)]
+		var fiNorm T
+		for i := range a {
+			fiNorm += min(a[i], w[i])
+		}
+		for i := range a {
+			fiNorm += min(1-a[i], w[len(a)+i])
+		}
+		fiNormsOut[r] = fiNorm
+	}
+}
+
+// SumOf works like Provider.SumFloat64, in portable Go code, for any Float type.
+func SumOf[T Float](arr []T) T {
+	var sum T
+	for _, v := range arr {
+		sum += v
+	}
+	return sum
+}
+
+// UpdateFuzzyWeightsOf works like Provider.UpdateFuzzyWeights, in portable Go code, for any Float type.
+func UpdateFuzzyWeightsOf[T Float](W, fi []T, beta T) {
+	for i := range W {
+		// the explicit conversions round both products, so that the compiler can't fuse them
+		// in a multiply-add on some architectures and not on others
+		W[i] = T(beta*fi[i]) + T((1-beta)*W[i])
+	}
+}
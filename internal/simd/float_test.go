@@ -0,0 +1,50 @@
+package simd
+
+import "testing"
+
+func TestFloat32Kernels(t *testing.T) {
+	A := []float32{0.1, 0.5, 0.9, 0.3}
+	w := []float32{0.2, 0.4, 0.8, 0.3}
+	fi := make([]float32, len(A))
+	fiNorm, wNorm := FuzzyIntersectionNormOf(A, w, fi)
+	if fi[0] != 0.1 || fi[1] != 0.4 || fi[2] != 0.8 || fi[3] != 0.3 {
+		t.Errorf("unexpected fuzzy intersection %v", fi)
+	}
+	if fiNorm != SumOf(fi) || wNorm != SumOf(w) {
+		t.Errorf("expected the norms %f and %f, got %f and %f", SumOf(fi), SumOf(w), fiNorm, wNorm)
+	}
+
+	UpdateFuzzyWeightsOf(w, fi, 0.5)
+	for i, want := range []float32{0.15, 0.4, 0.8, 0.3} {
+		if d := w[i] - want; d > 1e-6 || d < -1e-6 {
+			t.Errorf("weight %d: expected %f, got %f", i, want, w[i])
+		}
+	}
+}
+
+func TestSharedOf(t *testing.T) {
+	if SharedOf[float64]() != Shared {
+		t.Error("expected Shared as the float64 kernels")
+	}
+	if SharedOf[float32]() != Shared32 {
+		t.Error("expected Shared32 as the float32 kernels")
+	}
+	type weight float32
+	if _, ok := SharedOf[weight]().(*genericOf[weight]); !ok {
+		t.Errorf("expected the generic kernels for a named float type, got %T", SharedOf[weight]())
+	}
+}
+
+func TestKernels32(t *testing.T) {
+	for _, b := range natives() {
+		if b.provider32 == nil {
+			continue
+		}
+		t.Run(b.name, func(t *testing.T) {
+			if err := selfCheckOf(b.provider32); err != nil {
+				t.Fatal(err)
+			}
+			testTails(t, b.provider32)
+		})
+	}
+}
@@ -1,38 +1,40 @@
 package simd
 
-import (
-	"math"
-)
+// genericOf implements ProviderOf using standard Go code without SIMD
+type genericOf[T Float] struct{}
 
-// generic implements Provider using standard Go code without SIMD
-type generic struct{}
+// generic is the float64 Provider of genericOf.
+type generic = genericOf[float64]
 
 // FuzzyIntersectionNorm computes elementwise min between activations and weights,
 // and returns the sum of the result and sum of weights
-func (p *generic) FuzzyIntersectionNorm(A, w []float64, fuzzyIntersectionOut []float64) (float64, float64) {
-	var fiNorm, wNorm float64
+func (p *genericOf[T]) FuzzyIntersectionNorm(A, w []T, fuzzyIntersectionOut []T) (T, T) {
+	return FuzzyIntersectionNormOf(A, w, fuzzyIntersectionOut)
+}
+
+// FuzzyIntersection computes elementwise min between activations and weights,
+// and returns the sum of the result
+func (p *genericOf[T]) FuzzyIntersection(A, w []T, fuzzyIntersectionOut []T) T {
+	return FuzzyIntersectionOf(A, w, fuzzyIntersectionOut)
+}
 
-	for i := range A {
-		fuzzyIntersectionOut[i] = math.Min(A[i], w[i])
-		fiNorm += fuzzyIntersectionOut[i]
-		wNorm += w[i]
-	}
+// FuzzyIntersectionNormBatch computes the sum of the elementwise min between activations and every row of weights
+func (p *genericOf[T]) FuzzyIntersectionNormBatch(A, Wflat []T, rows int, fiNormsOut []T) {
+	FuzzyIntersectionNormBatchOf(A, Wflat, rows, fiNormsOut)
+}
 
-	return fiNorm, wNorm
+// ComplementIntersectionNormBatch computes the sum of the elementwise min between the complement coding of a
+// and every row of weights
+func (p *genericOf[T]) ComplementIntersectionNormBatch(a, Wflat []T, stride, rows int, fiNormsOut []T) {
+	ComplementIntersectionNormBatchOf(a, Wflat, stride, rows, fiNormsOut)
 }
 
 // SumFloat64 computes the sum of all elements in the array
-func (p *generic) SumFloat64(arr []float64) float64 {
-	var sum float64
-	for _, v := range arr {
-		sum += v
-	}
-	return sum
+func (p *genericOf[T]) SumFloat64(arr []T) T {
+	return SumOf(arr)
 }
 
 // UpdateFuzzyWeights updates the mean weights in the Euclidean ART
-func (p *generic) UpdateFuzzyWeights(W, fi []float64, beta float64) {
-	for i := range W {
-		W[i] = beta*fi[i] + (1-beta)*W[i]
-	}
+func (p *genericOf[T]) UpdateFuzzyWeights(W, fi []T, beta T) {
+	UpdateFuzzyWeightsOf(W, fi, beta)
 }
@@ -0,0 +1,77 @@
+//go:build !(darwin && cgo)
+
+package simd
+
+import "golang.org/x/sys/cpu"
+
+// NEON is the Provider of the arm64 CPUs without Accelerate, e.g.: Graviton, Ampere or Raspberry Pi 5,
+// written in Go assembly so that it builds without cgo.
+// Its kernels read exactly len(A) values, handling the ones left by the vector width in scalar code.
+type NEON struct{}
+
+// natives returns NEON, Advanced SIMD is mandatory on arm64 but the CPU features are checked anyway.
+func natives() []backend {
+	if cpu.ARM64.HasASIMD {
+		return []backend{{name: "neon", provider: new(NEON), provider32: minSumKernels32(minSumNEONF32)}}
+	}
+	return nil
+}
+
+//go:noescape
+func fuzzyIntersectionNormNEON(A, w, fi []float64) (fiNorm, wNorm float64)
+
+//go:noescape
+func fuzzyIntersectionNEON(A, w, fi []float64) (fiNorm float64)
+
+//go:noescape
+func minSumNEON(A, w []float64) (fiNorm float64)
+
+//go:noescape
+func complementMinSumNEON(a, w []float64) (fiNorm float64)
+
+//go:noescape
+func minSumNEONF32(A, w []float32) (fiNorm float32)
+
+//go:noescape
+func sumNEON(arr []float64) (sum float64)
+
+//go:noescape
+func updateFuzzyWeightsNEON(W, fi []float64, beta float64)
+
+// FuzzyIntersectionNorm computes elementwise min between A and w and returns the sum and the sum of w
+func (p *NEON) FuzzyIntersectionNorm(A, w []float64, fuzzyIntersectionOut []float64) (float64, float64) {
+	return fuzzyIntersectionNormNEON(A, w[:len(A)], fuzzyIntersectionOut[:len(A)])
+}
+
+// FuzzyIntersection computes elementwise min between A and w and returns its sum, without summing w
+func (p *NEON) FuzzyIntersection(A, w []float64, fuzzyIntersectionOut []float64) float64 {
+	return fuzzyIntersectionNEON(A, w[:len(A)], fuzzyIntersectionOut[:len(A)])
+}
+
+// FuzzyIntersectionNormBatch computes the fuzzy intersection norm of A with rows rows of Wflat.
+func (p *NEON) FuzzyIntersectionNormBatch(A, Wflat []float64, rows int, fiNormsOut []float64) {
+	n := len(A)
+	for r := range rows {
+		fiNormsOut[r] = minSumNEON(A, Wflat[r*n:(r+1)*n])
+	}
+}
+
+// ComplementIntersectionNormBatch computes the fuzzy intersection norm of the complement coding of a
+// with rows rows of Wflat, stride values apart.
+func (p *NEON) ComplementIntersectionNormBatch(a, Wflat []float64, stride, rows int, fiNormsOut []float64) {
+	n := 2 * len(a)
+	for r := range rows {
+		fiNormsOut[r] = complementMinSumNEON(a, Wflat[r*stride:r*stride+n])
+	}
+}
+
+// SumFloat64 computes the sum of all elements in the array using NEON
+func (p *NEON) SumFloat64(arr []float64) float64 {
+	return sumNEON(arr)
+}
+
+// UpdateFuzzyWeights updates weights using NEON acceleration
+// weights[i] = beta * fi[i] + (1-beta) * weights[i]
+func (p *NEON) UpdateFuzzyWeights(W []float64, fi []float64, beta float64) {
+	updateFuzzyWeightsNEON(W, fi[:len(W)], beta)
+}
@@ -0,0 +1,321 @@
+//go:build !(darwin && cgo)
+
+#include "textflag.h"
+
+// The kernels process 4 doubles per iteration in 2 NEON registers,
+// then the values left by the vector width one at a time.
+// The vector floating point instructions are encoded as WORDs, the Go assembler
+// only knows them since recent releases; the instruction is in the comment.
+
+// func fuzzyIntersectionNormNEON(A, w, fi []float64) (fiNorm, wNorm float64)
+TEXT ·fuzzyIntersectionNormNEON(SB), NOSPLIT, $0-88
+	MOVD A_base+0(FP), R0
+	MOVD A_len+8(FP), R3
+	MOVD w_base+24(FP), R1
+	MOVD fi_base+48(FP), R2
+	VEOR V16.B16, V16.B16, V16.B16 // fi sums
+	VEOR V17.B16, V17.B16, V17.B16
+	VEOR V18.B16, V18.B16, V18.B16 // w sums
+	VEOR V19.B16, V19.B16, V19.B16
+	LSR  $2, R3, R4
+	AND  $3, R3, R3
+	CBZ  R4, fin_reduce
+
+fin_loop:
+	VLD1.P 32(R0), [V0.D2, V1.D2]
+	VLD1.P 32(R1), [V2.D2, V3.D2]
+	WORD   $0x4ee2f404 // fmin v4.2d, v0.2d, v2.2d
+	WORD   $0x4ee3f425 // fmin v5.2d, v1.2d, v3.2d
+	VST1.P [V4.D2, V5.D2], 32(R2)
+	WORD   $0x4e64d610 // fadd v16.2d, v16.2d, v4.2d
+	WORD   $0x4e65d631 // fadd v17.2d, v17.2d, v5.2d
+	WORD   $0x4e62d652 // fadd v18.2d, v18.2d, v2.2d
+	WORD   $0x4e63d673 // fadd v19.2d, v19.2d, v3.2d
+	SUB    $1, R4
+	CBNZ   R4, fin_loop
+
+fin_reduce:
+	WORD   $0x4e71d610 // fadd v16.2d, v16.2d, v17.2d
+	WORD   $0x4e73d652 // fadd v18.2d, v18.2d, v19.2d
+	VMOV  V16.D[1], R5
+	FMOVD R5, F1
+	FADDD F1, F16, F0
+	VMOV  V18.D[1], R5
+	FMOVD R5, F1
+	FADDD F1, F18, F2
+	CBZ   R3, fin_done
+
+fin_tail:
+	FMOVD.P 8(R0), F4
+	FMOVD.P 8(R1), F5
+	FMIND   F5, F4, F6
+	FMOVD.P F6, 8(R2)
+	FADDD   F6, F0
+	FADDD   F5, F2
+	SUB     $1, R3
+	CBNZ    R3, fin_tail
+
+fin_done:
+	FMOVD F0, fiNorm+72(FP)
+	FMOVD F2, wNorm+80(FP)
+	RET
+
+// func fuzzyIntersectionNEON(A, w, fi []float64) (fiNorm float64)
+TEXT ·fuzzyIntersectionNEON(SB), NOSPLIT, $0-80
+	MOVD A_base+0(FP), R0
+	MOVD A_len+8(FP), R3
+	MOVD w_base+24(FP), R1
+	MOVD fi_base+48(FP), R2
+	VEOR V16.B16, V16.B16, V16.B16
+	VEOR V17.B16, V17.B16, V17.B16
+	LSR  $2, R3, R4
+	AND  $3, R3, R3
+	CBZ  R4, fi_reduce
+
+fi_loop:
+	VLD1.P 32(R0), [V0.D2, V1.D2]
+	VLD1.P 32(R1), [V2.D2, V3.D2]
+	WORD   $0x4ee2f404 // fmin v4.2d, v0.2d, v2.2d
+	WORD   $0x4ee3f425 // fmin v5.2d, v1.2d, v3.2d
+	VST1.P [V4.D2, V5.D2], 32(R2)
+	WORD   $0x4e64d610 // fadd v16.2d, v16.2d, v4.2d
+	WORD   $0x4e65d631 // fadd v17.2d, v17.2d, v5.2d
+	SUB    $1, R4
+	CBNZ   R4, fi_loop
+
+fi_reduce:
+	WORD   $0x4e71d610 // fadd v16.2d, v16.2d, v17.2d
+	VMOV  V16.D[1], R5
+	FMOVD R5, F1
+	FADDD F1, F16, F0
+	CBZ   R3, fi_done
+
+fi_tail:
+	FMOVD.P 8(R0), F4
+	FMOVD.P 8(R1), F5
+	FMIND   F5, F4, F6
+	FMOVD.P F6, 8(R2)
+	FADDD   F6, F0
+	SUB     $1, R3
+	CBNZ    R3, fi_tail
+
+fi_done:
+	FMOVD F0, fiNorm+72(FP)
+	RET
+
+// func minSumNEON(A, w []float64) (fiNorm float64)
+TEXT ·minSumNEON(SB), NOSPLIT, $0-56
+	MOVD A_base+0(FP), R0
+	MOVD A_len+8(FP), R3
+	MOVD w_base+24(FP), R1
+	VEOR V16.B16, V16.B16, V16.B16
+	VEOR V17.B16, V17.B16, V17.B16
+	LSR  $2, R3, R4
+	AND  $3, R3, R3
+	CBZ  R4, ms_reduce
+
+ms_loop:
+	VLD1.P 32(R0), [V0.D2, V1.D2]
+	VLD1.P 32(R1), [V2.D2, V3.D2]
+	WORD   $0x4ee2f404 // fmin v4.2d, v0.2d, v2.2d
+	WORD   $0x4ee3f425 // fmin v5.2d, v1.2d, v3.2d
+	WORD   $0x4e64d610 // fadd v16.2d, v16.2d, v4.2d
+	WORD   $0x4e65d631 // fadd v17.2d, v17.2d, v5.2d
+	SUB    $1, R4
+	CBNZ   R4, ms_loop
+
+ms_reduce:
+	WORD   $0x4e71d610 // fadd v16.2d, v16.2d, v17.2d
+	VMOV  V16.D[1], R5
+	FMOVD R5, F1
+	FADDD F1, F16, F0
+	CBZ   R3, ms_done
+
+ms_tail:
+	FMOVD.P 8(R0), F4
+	FMOVD.P 8(R1), F5
+	FMIND   F5, F4, F6
+	FADDD   F6, F0
+	SUB     $1, R3
+	CBNZ    R3, ms_tail
+
+ms_done:
+	FMOVD F0, fiNorm+48(FP)
+	RET
+
+// func complementMinSumNEON(a, w []float64) (fiNorm float64)
+// w holds 2*len(a) values, the lower half is intersected with a and the upper half with 1-a.
+TEXT ·complementMinSumNEON(SB), NOSPLIT, $0-56
+	MOVD  a_base+0(FP), R0
+	MOVD  a_len+8(FP), R3
+	MOVD  w_base+24(FP), R1
+	ADD   R3<<3, R1, R2 // upper half of w
+	FMOVD $1.0, F21
+	VDUP  V21.D[0], V20.D2
+	VEOR  V16.B16, V16.B16, V16.B16 // a sums
+	VEOR  V17.B16, V17.B16, V17.B16
+	VEOR  V18.B16, V18.B16, V18.B16 // 1-a sums
+	VEOR  V19.B16, V19.B16, V19.B16
+	LSR   $2, R3, R4
+	AND   $3, R3, R3
+	CBZ   R4, cms_reduce
+
+cms_loop:
+	VLD1.P 32(R0), [V0.D2, V1.D2]
+	VLD1.P 32(R1), [V2.D2, V3.D2]
+	VLD1.P 32(R2), [V6.D2, V7.D2]
+	WORD   $0x4ee0d684 // fsub v4.2d, v20.2d, v0.2d
+	WORD   $0x4ee1d685 // fsub v5.2d, v20.2d, v1.2d
+	WORD   $0x4ee2f400 // fmin v0.2d, v0.2d, v2.2d
+	WORD   $0x4ee3f421 // fmin v1.2d, v1.2d, v3.2d
+	WORD   $0x4ee6f484 // fmin v4.2d, v4.2d, v6.2d
+	WORD   $0x4ee7f4a5 // fmin v5.2d, v5.2d, v7.2d
+	WORD   $0x4e60d610 // fadd v16.2d, v16.2d, v0.2d
+	WORD   $0x4e61d631 // fadd v17.2d, v17.2d, v1.2d
+	WORD   $0x4e64d652 // fadd v18.2d, v18.2d, v4.2d
+	WORD   $0x4e65d673 // fadd v19.2d, v19.2d, v5.2d
+	SUB    $1, R4
+	CBNZ   R4, cms_loop
+
+cms_reduce:
+	WORD   $0x4e71d610 // fadd v16.2d, v16.2d, v17.2d
+	WORD   $0x4e73d652 // fadd v18.2d, v18.2d, v19.2d
+	WORD   $0x4e72d610 // fadd v16.2d, v16.2d, v18.2d
+	VMOV  V16.D[1], R5
+	FMOVD R5, F1
+	FADDD F1, F16, F0
+	CBZ   R3, cms_done
+
+cms_tail:
+	FMOVD.P 8(R0), F4
+	FMOVD.P 8(R1), F5
+	FMOVD.P 8(R2), F6
+	FSUBD   F4, F21, F7
+	FMIND   F5, F4, F4
+	FMIND   F6, F7, F7
+	FADDD   F4, F0
+	FADDD   F7, F0
+	SUB     $1, R3
+	CBNZ    R3, cms_tail
+
+cms_done:
+	FMOVD F0, fiNorm+48(FP)
+	RET
+
+// func sumNEON(arr []float64) (sum float64)
+TEXT ·sumNEON(SB), NOSPLIT, $0-32
+	MOVD arr_base+0(FP), R0
+	MOVD arr_len+8(FP), R3
+	VEOR V16.B16, V16.B16, V16.B16
+	VEOR V17.B16, V17.B16, V17.B16
+	LSR  $2, R3, R4
+	AND  $3, R3, R3
+	CBZ  R4, sum_reduce
+
+sum_loop:
+	VLD1.P 32(R0), [V0.D2, V1.D2]
+	WORD   $0x4e60d610 // fadd v16.2d, v16.2d, v0.2d
+	WORD   $0x4e61d631 // fadd v17.2d, v17.2d, v1.2d
+	SUB    $1, R4
+	CBNZ   R4, sum_loop
+
+sum_reduce:
+	WORD   $0x4e71d610 // fadd v16.2d, v16.2d, v17.2d
+	VMOV  V16.D[1], R5
+	FMOVD R5, F1
+	FADDD F1, F16, F0
+	CBZ   R3, sum_done
+
+sum_tail:
+	FMOVD.P 8(R0), F4
+	FADDD   F4, F0
+	SUB     $1, R3
+	CBNZ    R3, sum_tail
+
+sum_done:
+	FMOVD F0, sum+24(FP)
+	RET
+
+// func updateFuzzyWeightsNEON(W, fi []float64, beta float64)
+TEXT ·updateFuzzyWeightsNEON(SB), NOSPLIT, $0-56
+	MOVD  W_base+0(FP), R0
+	MOVD  W_len+8(FP), R3
+	MOVD  fi_base+24(FP), R1
+	FMOVD beta+48(FP), F0
+	FMOVD $1.0, F1
+	FSUBD F0, F1, F1
+	VDUP  V0.D[0], V2.D2 // beta
+	VDUP  V1.D[0], V3.D2 // 1-beta
+	LSR   $2, R3, R4
+	AND   $3, R3, R3
+	CBZ   R4, ufw_tail_check
+
+ufw_loop:
+	VLD1.P 32(R1), [V4.D2, V5.D2]
+	VLD1   (R0), [V6.D2, V7.D2]
+	// beta * fi and (1-beta) * W
+	WORD   $0x6e64dc44 // fmul v4.2d, v2.2d, v4.2d
+	WORD   $0x6e65dc45 // fmul v5.2d, v2.2d, v5.2d
+	WORD   $0x6e66dc66 // fmul v6.2d, v3.2d, v6.2d
+	WORD   $0x6e67dc67 // fmul v7.2d, v3.2d, v7.2d
+	WORD   $0x4e66d484 // fadd v4.2d, v4.2d, v6.2d
+	WORD   $0x4e67d4a5 // fadd v5.2d, v5.2d, v7.2d
+	VST1.P [V4.D2, V5.D2], 32(R0)
+	SUB    $1, R4
+	CBNZ   R4, ufw_loop
+
+ufw_tail_check:
+	CBZ R3, ufw_done
+
+ufw_tail:
+	FMOVD.P 8(R1), F4
+	FMOVD   (R0), F6
+	FMULD   F0, F4, F4
+	FMULD   F1, F6, F6
+	FADDD   F6, F4, F4
+	FMOVD.P F4, 8(R0)
+	SUB     $1, R3
+	CBNZ    R3, ufw_tail
+
+ufw_done:
+	RET
+
+// func minSumNEONF32(A, w []float32) (fiNorm float32)
+// The float32 kernel processes 8 floats per iteration in 2 NEON registers.
+TEXT ·minSumNEONF32(SB), NOSPLIT, $0-52
+	MOVD A_base+0(FP), R0
+	MOVD A_len+8(FP), R3
+	MOVD w_base+24(FP), R1
+	VEOR V16.B16, V16.B16, V16.B16
+	VEOR V17.B16, V17.B16, V17.B16
+	LSR  $3, R3, R4
+	AND  $7, R3, R3
+	CBZ  R4, ms32_reduce
+
+ms32_loop:
+	VLD1.P 32(R0), [V0.S4, V1.S4]
+	VLD1.P 32(R1), [V2.S4, V3.S4]
+	WORD   $0x4ea2f400 // fmin v0.4s, v0.4s, v2.4s
+	WORD   $0x4ea3f421 // fmin v1.4s, v1.4s, v3.4s
+	WORD   $0x4e20d610 // fadd v16.4s, v16.4s, v0.4s
+	WORD   $0x4e21d631 // fadd v17.4s, v17.4s, v1.4s
+	SUB    $1, R4
+	CBNZ   R4, ms32_loop
+
+ms32_reduce:
+	WORD $0x4e31d610 // fadd v16.4s, v16.4s, v17.4s
+	WORD $0x6e30d610 // faddp v16.4s, v16.4s, v16.4s
+	WORD $0x7e30da00 // faddp s0, v16.2s
+	CBZ  R3, ms32_done
+
+ms32_tail:
+	FMOVS.P 4(R0), F4
+	FMOVS.P 4(R1), F5
+	FMINS   F5, F4, F6
+	FADDS   F6, F0
+	SUB     $1, R3
+	CBNZ    R3, ms32_tail
+
+ms32_done:
+	FMOVS F0, fiNorm+48(FP)
+	RET
@@ -0,0 +1,14 @@
+//go:build !(darwin && cgo)
+
+package simd
+
+import "testing"
+
+func TestNEON(t *testing.T) {
+	p := new(NEON)
+	if err := selfCheck(p); err != nil {
+		t.Fatal(err)
+	}
+
+	testTails[float64](t, p)
+}
@@ -0,0 +1,8 @@
+//go:build amd64 && !(cgo && avx512)
+
+package simd
+
+// avx512Provider returns nil, the AVX-512 provider needs cgo and the avx512 build tag.
+func avx512Provider() (Provider, ProviderOf[float32]) {
+	return nil, nil
+}
@@ -0,0 +1,8 @@
+//go:build !amd64 && !arm64
+
+package simd
+
+// natives returns nil, there are no native kernels for this architecture.
+func natives() []backend {
+	return nil
+}
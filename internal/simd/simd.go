@@ -1,29 +1,223 @@
 package simd
 
 import (
+	"errors"
 	"fmt"
-	"runtime"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
-// Provider defines the interface for platform-specific SIMD operations
-type Provider interface {
+// ProviderOf defines the interface for platform-specific SIMD operations on vectors of T,
+// implemented by the native backends and by the ones added by Register
+type ProviderOf[T Float] interface {
 	// FuzzyIntersectionNorm computes element-wise min between vectors and returns norms
-	FuzzyIntersectionNorm(A, w []float64, fuzzyIntersectionOut []float64) (fiNorm float64, wNorm float64)
+	FuzzyIntersectionNorm(A, w []T, fuzzyIntersectionOut []T) (fiNorm T, wNorm T)
 
-	// SumFloat64 computes the sum of all elements in an array
-	SumFloat64(arr []float64) float64
+	// FuzzyIntersection computes element-wise min between vectors and returns the norm of the result,
+	// for callers caching the norm of w
+	FuzzyIntersection(A, w []T, fuzzyIntersectionOut []T) (fiNorm T)
+
+	// FuzzyIntersectionNormBatch computes the fuzzy intersection norm of A with rows consecutive vectors of len(A) values
+	// stored in Wflat, in fiNormsOut, in a single call
+	FuzzyIntersectionNormBatch(A, Wflat []T, rows int, fiNormsOut []T)
+
+	// ComplementIntersectionNormBatch computes the fuzzy intersection norm of the complement coding of a, [a, 1-a],
+	// with rows vectors of 2*len(a) values stored stride values apart in Wflat, in fiNormsOut, in a single call.
+	// The complement coding is computed on the fly, in the same pass over a as the intersections.
+	ComplementIntersectionNormBatch(a, Wflat []T, stride, rows int, fiNormsOut []T)
+
+	// SumFloat64 computes the sum of all elements in an array, the name predates the float32 kernels
+	SumFloat64(arr []T) T
 
 	// UpdateFuzzyWeights updates weights according to the ART learning rule
-	UpdateFuzzyWeights(W, fi []float64, beta float64)
+	UpdateFuzzyWeights(W, fi []T, beta T)
+}
+
+// Provider is the ProviderOf of the float64 kernels.
+type Provider = ProviderOf[float64]
+
+// Shared and Shared32 are the float64 and float32 kernels of the selected backend, see SharedOf.
+var (
+	Shared   Provider
+	Shared32 ProviderOf[float32]
+)
+
+// backend is a Provider with the name used to select it, e.g.: "avx2",
+// and its float32 kernels, nil if it has none.
+type backend struct {
+	name       string
+	provider   Provider
+	provider32 ProviderOf[float32]
 }
 
-var Shared Provider
+// selected is the name of the backend of Shared.
+var selected string
+
+// logger receives the self-check failures and the switches to the generic provider, see SetLogger.
+var logger atomic.Pointer[log.Logger]
 
 func init() {
-	Shared = GetProvider()
-	if Shared == nil {
-		Shared = new(generic)
+	logger.Store(log.Default())
+
+	if name := os.Getenv("ART_SIMD"); name != "" {
+		err := Use(name)
+		if err == nil {
+			return
+		}
+		logf("simd: ART_SIMD: %v, selecting the backend automatically", err)
+	}
+
+	for _, b := range natives() {
+		if err := b.check(); err != nil {
+			logf("simd: %T failed the self-check (%v), skipping it", b.provider, err)
+			continue
+		}
+		b.use()
+		return
+	}
+
+	useGeneric()
+}
+
+// check compares the kernels of b with the generic ones, see selfCheck.
+func (b backend) check() error {
+	if err := selfCheck(b.provider); err != nil {
+		return err
+	}
+	if b.provider32 != nil {
+		if err := selfCheckOf(b.provider32); err != nil {
+			return fmt.Errorf("float32 kernels: %w", err)
+		}
+	}
+	return nil
+}
+
+// use selects b, its float32 kernels are the generic ones if it has none.
+func (b backend) use() {
+	Shared, Shared32, selected = &fallback{native: b.provider}, new(genericOf[float32]), b.name
+	if b.provider32 != nil {
+		Shared32 = &fallbackOf[float32]{native: b.provider32}
+	}
+}
+
+// useGeneric selects the generic backend.
+func useGeneric() {
+	Shared, Shared32, selected = new(generic), new(genericOf[float32]), "generic"
+}
+
+// SetLogger sets the logger of the self-check failures and of the switches to the generic provider
+// after a native one panicked, log.Default() until then; nil discards them.
+// The failures of the startup self-check are logged before SetLogger can be called.
+func SetLogger(l *log.Logger) {
+	logger.Store(l)
+}
+
+// logf logs with the logger set by SetLogger, if any.
+func logf(format string, args ...any) {
+	if l := logger.Load(); l != nil {
+		l.Printf(format, args...)
 	}
+}
 
-	fmt.Printf("Using %T on %s/%s\n", Shared, runtime.GOOS, runtime.GOARCH)
+// GetProvider returns the fastest native Provider supported by the CPU, nil if there is none.
+func GetProvider() Provider {
+	if backends := natives(); len(backends) > 0 {
+		return backends[0].provider
+	}
+	return nil
+}
+
+// Available returns the names of the backends supported by the CPU and the build, the fastest first,
+// then the ones added by Register, ending with "generic".
+func Available() []string {
+	var names []string
+	for _, b := range backends() {
+		names = append(names, b.name)
+	}
+	return append(names, "generic")
+}
+
+// registered holds the backends added by Register.
+var (
+	registeredMu sync.Mutex
+	registered   []backend
+)
+
+// Register adds p to the backends selectable by Use with the given name, e.g.: a wrapper of an external library.
+// Like the native ones, it is checked against the generic provider when selected,
+// and replaced by it if it panics. Its float32 kernels are the generic ones.
+// Registering doesn't select the backend, and registered backends can't be selected by ART_SIMD,
+// which is read before they are registered.
+func Register(name string, p Provider) error {
+	if name == "" || p == nil {
+		return errors.New("simd backend name and provider must be set")
+	}
+
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	if name == "generic" || slices.ContainsFunc(natives(), func(b backend) bool { return b.name == name }) ||
+		slices.ContainsFunc(registered, func(b backend) bool { return b.name == name }) {
+		return fmt.Errorf("simd backend %q is already registered", name)
+	}
+	registered = append(registered, backend{name: name, provider: p})
+	return nil
+}
+
+// backends returns the native backends, then the registered ones.
+func backends() []backend {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	return append(natives(), registered...)
+}
+
+// Use sets Shared and Shared32 to the backend named name, one of Available, after checking it against the generic one.
+// It must not be called while they are in use.
+// The ART_SIMD environment variable selects the backend the same way at startup.
+func Use(name string) error {
+	if name == "generic" {
+		useGeneric()
+		return nil
+	}
+	for _, b := range backends() {
+		if b.name != name {
+			continue
+		}
+		if err := b.check(); err != nil {
+			return fmt.Errorf("simd backend %q failed the self-check: %w", name, err)
+		}
+		b.use()
+		return nil
+	}
+	return fmt.Errorf("simd backend %q is not available, expected one of %s", name, strings.Join(Available(), ", "))
+}
+
+// ProviderName returns the name of the backend of Shared, one of Available, set by Use or selected at startup.
+func ProviderName() string {
+	return selected
+}
+
+// Generic returns the portable Go Provider, the fallback when no native one is available.
+func Generic() Provider {
+	return new(generic)
+}
+
+// GenericOf returns the portable Go kernels of T, see Generic.
+func GenericOf[T Float]() ProviderOf[T] {
+	return new(genericOf[T])
+}
+
+// SharedOf returns the kernels of T of the selected backend: Shared for float64, Shared32 for float32,
+// and the generic ones for the other types whose underlying type is a Float.
+func SharedOf[T Float]() ProviderOf[T] {
+	if p, ok := any(Shared).(ProviderOf[T]); ok {
+		return p
+	}
+	if p, ok := any(Shared32).(ProviderOf[T]); ok {
+		return p
+	}
+	return new(genericOf[T])
 }
@@ -42,6 +42,88 @@ func TestFuzzyIntersectionNorm(t *testing.T) {
 						i, expected, intersection[i])
 				}
 			}
+
+			// the variant without the norm of w computes the same intersection
+			clear(intersection)
+			if sum := Shared.FuzzyIntersection(a, w, intersection); math.Abs(expectedSum-sum) > 1e-10 {
+				t.Errorf("FuzzyIntersection should return sum %.10f, but got %.10f", expectedSum, sum)
+			}
+			for i := 0; i < size; i++ {
+				if expected := math.Min(a[i], w[i]); math.Abs(expected-intersection[i]) > 1e-10 {
+					t.Errorf("FuzzyIntersection at index %d should be %.10f, but got %.10f", i, expected, intersection[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFuzzyIntersectionNormBatch(t *testing.T) {
+	for _, size := range []int{7, 8, 15, 16, 31, 32, 63, 64, 127, 128, 256} {
+		t.Run("size="+strconv.Itoa(size), func(t *testing.T) {
+			const rows = 5
+			a := make([]float64, size)
+			w := make([]float64, rows*size)
+			for i := range a {
+				a[i] = rand.Float64()
+			}
+			for i := range w {
+				w[i] = rand.Float64()
+			}
+
+			norms := make([]float64, rows)
+			Shared.FuzzyIntersectionNormBatch(a, w, rows, norms)
+			fi := make([]float64, size)
+			for r := range rows {
+				expected, _ := Generic().FuzzyIntersectionNorm(a, w[r*size:(r+1)*size], fi)
+				if math.Abs(expected-norms[r]) > 1e-10 {
+					t.Errorf("row %d should have norm %.10f, but got %.10f", r, expected, norms[r])
+				}
+			}
+		})
+	}
+}
+
+// complementCode returns [a, 1-a]
+func complementCode[T Float](a []T) []T {
+	A := make([]T, 2*len(a))
+	for i, v := range a {
+		A[i], A[len(a)+i] = v, 1-v
+	}
+	return A
+}
+
+func TestComplementIntersectionNormBatch(t *testing.T) {
+	for _, size := range []int{7, 8, 15, 16, 31, 32, 63, 64, 127, 128, 256} {
+		t.Run("size="+strconv.Itoa(size), func(t *testing.T) {
+			// the rows are padded, as in the slabs of the models
+			const rows = 5
+			stride := Padded(2 * size)
+			a := make([]float64, size)
+			w := make([]float64, rows*stride)
+			for i := range a {
+				a[i] = rand.Float64()
+			}
+			for i := range w {
+				w[i] = rand.Float64()
+			}
+
+			norms := make([]float64, rows)
+			Shared.ComplementIntersectionNormBatch(a, w, stride, rows, norms)
+			A, fi := complementCode(a), make([]float64, 2*size)
+			for r := range rows {
+				expected, _ := Generic().FuzzyIntersectionNorm(A, w[r*stride:r*stride+2*size], fi)
+				if math.Abs(expected-norms[r]) > 1e-10 {
+					t.Errorf("row %d should have norm %.10f, but got %.10f", r, expected, norms[r])
+				}
+			}
+
+			// the generic kernel sums in the same order as on the complement-coded input
+			Generic().ComplementIntersectionNormBatch(a, w, stride, rows, norms)
+			for r := range rows {
+				if expected, _ := Generic().FuzzyIntersectionNorm(A, w[r*stride:r*stride+2*size], fi); norms[r] != expected {
+					t.Errorf("row %d should have the generic norm %.17f, but got %.17f", r, expected, norms[r])
+				}
+			}
 		})
 	}
 }
@@ -90,6 +172,31 @@ func BenchmarkFuzzyIntersectionSum(b *testing.B) {
 	}
 }
 
+func BenchmarkComplementIntersectionNormBatch(b *testing.B) {
+	benchSizes := []int{8, 64, 256, 1024, 4096}
+
+	for _, size := range benchSizes {
+		b.Run("size="+strconv.Itoa(size), func(b *testing.B) {
+			const rows = 64
+			a := make([]float64, size)
+			w := make([]float64, rows*2*size)
+			norms := make([]float64, rows)
+
+			for i := range a {
+				a[i] = rand.Float64()
+			}
+			for i := range w {
+				w[i] = rand.Float64()
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				Shared.ComplementIntersectionNormBatch(a, w, 2*size, rows, norms)
+			}
+		})
+	}
+}
+
 func BenchmarkSumFloat64(b *testing.B) {
 	benchSizes := []int{8, 64, 256, 1024, 4096}
 
@@ -108,3 +215,121 @@ func BenchmarkSumFloat64(b *testing.B) {
 		})
 	}
 }
+
+// testTails compares p with the generic provider on lengths that aren't a multiple of the vector width,
+// which exercise the scalar tails of the native kernels.
+func testTails[T Float](t *testing.T, p ProviderOf[T]) {
+	ref := new(genericOf[T])
+	for _, size := range []int{1, 3, 5, 9, 13, 30, 37} {
+		t.Run("size="+strconv.Itoa(size), func(t *testing.T) {
+			A, w := make([]T, size), make([]T, 2*size)
+			for i := range A {
+				A[i] = T(rand.Float64())
+			}
+			for i := range w {
+				w[i] = T(rand.Float64())
+			}
+
+			fi, refFi := make([]T, size), make([]T, size)
+			fiNorm, wNorm := p.FuzzyIntersectionNorm(A, w[:size], fi)
+			refFiNorm, refWNorm := ref.FuzzyIntersectionNorm(A, w[:size], refFi)
+			if !approxEqual(fiNorm, refFiNorm) || !approxEqual(wNorm, refWNorm) || !approxEqualAll(fi, refFi) {
+				t.Errorf("FuzzyIntersectionNorm returned %f, %f, expected %f, %f", fiNorm, wNorm, refFiNorm, refWNorm)
+			}
+			if fiNorm := p.FuzzyIntersection(A, w[:size], fi); !approxEqual(fiNorm, refFiNorm) {
+				t.Errorf("FuzzyIntersection returned %f, expected %f", fiNorm, refFiNorm)
+			}
+
+			norms, refNorms := make([]T, 2), make([]T, 2)
+			p.FuzzyIntersectionNormBatch(A, w, 2, norms)
+			ref.FuzzyIntersectionNormBatch(A, w, 2, refNorms)
+			if !approxEqualAll(norms, refNorms) {
+				t.Errorf("FuzzyIntersectionNormBatch returned %v, expected %v", norms, refNorms)
+			}
+
+			p.ComplementIntersectionNormBatch(A, w, 2*size, 1, norms)
+			ref.FuzzyIntersectionNormBatch(complementCode(A), w, 1, refNorms)
+			if !approxEqual(norms[0], refNorms[0]) {
+				t.Errorf("ComplementIntersectionNormBatch returned %f, expected %f", norms[0], refNorms[0])
+			}
+
+			if sum, refSum := p.SumFloat64(A), ref.SumFloat64(A); !approxEqual(sum, refSum) {
+				t.Errorf("SumFloat64 returned %f, expected %f", sum, refSum)
+			}
+
+			// the weights past the first size values must not be touched
+			W, refW := append([]T(nil), w...), append([]T(nil), w...)
+			p.UpdateFuzzyWeights(W[:size], refFi, 0.3)
+			ref.UpdateFuzzyWeights(refW[:size], refFi, 0.3)
+			if !approxEqualAll(W, refW) {
+				t.Errorf("UpdateFuzzyWeights returned %v, expected %v", W, refW)
+			}
+		})
+	}
+}
+
+func TestUse(t *testing.T) {
+	shared, shared32, name := Shared, Shared32, ProviderName()
+	defer func() { Shared, Shared32, selected = shared, shared32, name }()
+
+	available := Available()
+	if available[len(available)-1] != "generic" {
+		t.Errorf("expected generic as the last available backend, got %v", available)
+	}
+	for _, backend := range available {
+		if err := Use(backend); err != nil {
+			t.Fatal(err)
+		}
+		if ProviderName() != backend {
+			t.Errorf("expected %s selected, got %s", backend, ProviderName())
+		}
+		if sum := Shared.SumFloat64([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}); sum != 45 {
+			t.Errorf("%s: expected the sum 45, got %f", backend, sum)
+		}
+		norms := make([]float32, 1)
+		SharedOf[float32]().ComplementIntersectionNormBatch([]float32{0.25, 0.5}, []float32{1, 1, 1, 1}, 4, 1, norms)
+		if norms[0] != 2 {
+			t.Errorf("%s: expected the float32 norm 2, got %f", backend, norms[0])
+		}
+	}
+
+	if err := Use("sse9"); err == nil {
+		t.Error("expected an error selecting an unknown backend")
+	}
+	if ProviderName() != available[len(available)-1] {
+		t.Errorf("a failed Use shouldn't change the backend, got %s", ProviderName())
+	}
+}
+
+func TestRegister(t *testing.T) {
+	shared, shared32, name := Shared, Shared32, ProviderName()
+	defer func() {
+		Shared, Shared32, selected = shared, shared32, name
+		registeredMu.Lock()
+		registered = nil
+		registeredMu.Unlock()
+	}()
+
+	if err := Register("custom", new(generic)); err != nil {
+		t.Fatal(err)
+	}
+	if err := Register("custom", new(generic)); err == nil {
+		t.Error("expected an error registering a name twice")
+	}
+	if err := Register("generic", new(generic)); err == nil {
+		t.Error("expected an error registering the generic name")
+	}
+	if available := Available(); available[len(available)-2] != "custom" {
+		t.Errorf("expected the registered backend before generic, got %v", available)
+	}
+
+	if err := Use("custom"); err != nil || ProviderName() != "custom" {
+		t.Fatalf("expected the custom backend selected, got %s (%v)", ProviderName(), err)
+	}
+	if err := Register("broken", new(panicking)); err != nil {
+		t.Fatal(err)
+	}
+	if err := Use("broken"); err == nil || ProviderName() != "custom" {
+		t.Errorf("expected a broken backend to fail the self-check, got %s (%v)", ProviderName(), err)
+	}
+}
@@ -0,0 +1,113 @@
+package art
+
+import (
+	"fmt"
+	"maps"
+	"time"
+)
+
+// WithMatchTracking enables match tracking in FitLabeled, as in ARTMAP:
+// when the best resonating category has a different label, the vigilance is raised
+// just above its resonance (by epsilon) for the current input and the search continues,
+// eventually creating a new category. Categories without a label accept any label.
+// Recommended value: 0.001, small negative values (MT-) suit inconsistent (noisy) labels better, see SFAM.
+// The category cap (WithMaxCategories) doesn't apply to the categories created by match tracking.
+func WithMatchTracking(epsilon float64) Option {
+	return func(f *fuzzyState) error {
+		if epsilon <= -1 || epsilon >= 1 {
+			return fmt.Errorf("match tracking parameter (epsilon) must be between -1 and 1, got %f", epsilon)
+		}
+		f.matchTracking, f.epsilon = true, epsilon
+		return nil
+	}
+}
+
+// FitLabeled works like Fit, and votes label for the winning category:
+// the label of every category (see Label) is the majority label of the samples it learned,
+// the current one in case of ties.
+// Categories labeled before the first vote (e.g. by ImportClusters or Load) count the votes of all their samples.
+// A frozen model doesn't vote, see SetFrozen. Match tracking can be enabled with WithMatchTracking.
+func (f *FuzzyARTOf[T]) FitLabeled(a []float64, label string) (categoryActivation float64, categoryIndex int) {
+	if f.matchTracking && !f.frozen {
+		categoryActivation, categoryIndex = f.fitTrackingMatch(a, label)
+	} else {
+		categoryActivation, categoryIndex = f.Fit(a)
+	}
+	if f.frozen {
+		return categoryActivation, categoryIndex
+	}
+	c := &f.categories[categoryIndex]
+	// Fit already counted this sample
+	votes := f.labelVotes(categoryIndex, c.count-1)
+	votes[label]++
+	if votes[label] > votes[c.label] {
+		c.label = label
+	}
+	return categoryActivation, categoryIndex
+}
+
+// fitTrackingMatch works like Fit, with match tracking on the category labels.
+func (f *FuzzyARTOf[T]) fitTrackingMatch(a []float64, label string) (categoryActivation float64, categoryIndex int) {
+	if f.latency != nil {
+		defer f.latency.fit.observe(time.Now())
+	}
+	A := f.learningInput(a)
+	f.activateCategories(A)
+	lastID := f.lastID
+	categoryActivation, categoryIndex, _ = f.trackMatch(A, f.epsilon, func(j int) bool {
+		l := f.categories[j].label
+		return l == "" || l == label
+	})
+	f.notifyHooks(categoryIndex, categoryActivation, lastID)
+	if f.checkpoint != nil {
+		f.checkpoint.learned(f)
+	}
+	return categoryActivation, categoryIndex
+}
+
+// PredictLabel works like Predict without learning, and returns the label of the winning category.
+// If the model has no categories yet, the category index is -1 and the label is empty.
+func (f *FuzzyARTOf[T]) PredictLabel(a []float64) (label string, categoryActivation float64, categoryIndex int) {
+	if len(f.w) == 0 {
+		return "", 0, -1
+	}
+	categoryActivation, categoryIndex = f.Predict(a, false)
+	return f.categories[categoryIndex].label, categoryActivation, categoryIndex
+}
+
+// labelVotes returns the label votes of category j, created if needed
+// with n votes for its current label, if any.
+func (f *FuzzyARTOf[T]) labelVotes(j, n int) map[string]int {
+	c := &f.categories[j]
+	if c.votes == nil {
+		c.votes = make(map[string]int)
+		if c.label != "" {
+			c.votes[c.label] = max(n, 1)
+		}
+	}
+	return c.votes
+}
+
+// mergeLabels merges the label votes of category b into category a, before their counts are merged.
+func (f *FuzzyARTOf[T]) mergeLabels(a, b int) {
+	ca, cb := &f.categories[a], &f.categories[b]
+	if ca.votes == nil && cb.votes == nil && cb.label == "" {
+		return
+	}
+	votes := f.labelVotes(a, ca.count)
+	for label, n := range f.labelVotes(b, cb.count) {
+		votes[label] += n
+		if votes[label] > votes[ca.label] {
+			ca.label = label
+		}
+	}
+}
+
+// cloneVotes copies the label votes of the categories, so that they aren't shared with a copy of the model.
+func cloneVotes(categories []category) {
+	for j := range categories {
+		if categories[j].votes != nil {
+			categories[j].votes = maps.Clone(categories[j].votes)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package art
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFitLabeledMajority(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.5, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	if label, _, j := model.PredictLabel([]float64{0.1, 0.1, 0.1, 0.1}); label != "" || j != -1 {
+		t.Errorf("expected no label from an empty model, got %q (category %d)", label, j)
+	}
+
+	for _, label := range []string{"a", "b", "b", "a", "b"} {
+		if _, j := model.FitLabeled([]float64{0.1, 0.1, 0.1, 0.1}, label); j != 0 {
+			t.Fatalf("expected a single category, got %d", j)
+		}
+	}
+	if label, _, _ := model.PredictLabel([]float64{0.1, 0.1, 0.1, 0.1}); label != "b" {
+		t.Errorf("expected the majority label b, got %q", label)
+	}
+
+	model.FitLabeled([]float64{0.95, 0.95, 0.95, 0.95}, "c")
+	if len(model.w) != 2 || model.Label(1) != "c" {
+		t.Fatalf("expected a new category labeled c, got %d categories", len(model.w))
+	}
+	// a tie keeps the current label
+	for _, label := range []string{"d", "c", "d"} {
+		model.FitLabeled([]float64{0.95, 0.95, 0.95, 0.95}, label)
+	}
+	if model.Label(1) != "c" {
+		t.Errorf("expected the tie to keep label c, got %q", model.Label(1))
+	}
+}
+
+func TestFitLabeledImportedLabel(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.5, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	if err := model.ImportClusters([][]float64{{0.5, 0.5, 0.5, 0.5}}, []int{3}, []string{"x"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, label := range []string{"y", "y", "y"} {
+		model.FitLabeled([]float64{0.5, 0.5, 0.5, 0.5}, label)
+	}
+	if model.Label(0) != "x" {
+		t.Errorf("expected the 3 imported samples to keep label x, got %q", model.Label(0))
+	}
+	model.FitLabeled([]float64{0.5, 0.5, 0.5, 0.5}, "y")
+	if model.Label(0) != "y" {
+		t.Errorf("expected label y after 4 votes, got %q", model.Label(0))
+	}
+}
+
+func TestFitLabeledMergedCategories(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithMaxCategories(2, CapMerge))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.FitLabeled([]float64{0.1, 0.1, 0.1, 0.1}, "low")
+	model.FitLabeled([]float64{0.1, 0.1, 0.1, 0.1}, "low")
+	model.FitLabeled([]float64{0.2, 0.2, 0.2, 0.2}, "mid")
+	// merges the two closest categories, low and mid
+	model.FitLabeled([]float64{0.9, 0.9, 0.9, 0.9}, "high")
+
+	if len(model.w) != 2 || model.Label(0) != "low" || model.Label(1) != "high" {
+		t.Errorf("expected categories low and high, got %d: %q, %q", len(model.w), model.Label(0), model.Label(1))
+	}
+}
+
+func TestFitLabeledSnapshot(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.5, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.FitLabeled([]float64{0.1, 0.1, 0.1, 0.1}, "a")
+	s := model.Snapshot()
+	model.FitLabeled([]float64{0.1, 0.1, 0.1, 0.1}, "b")
+	model.FitLabeled([]float64{0.1, 0.1, 0.1, 0.1}, "b")
+	if model.Label(0) != "b" {
+		t.Fatalf("expected label b, got %q", model.Label(0))
+	}
+
+	if err := model.Restore(s); err != nil {
+		t.Fatal(err)
+	}
+	model.FitLabeled([]float64{0.1, 0.1, 0.1, 0.1}, "b")
+	if model.Label(0) != "a" {
+		t.Errorf("expected the restored votes to keep label a, got %q", model.Label(0))
+	}
+}
+
+func TestFitLabeledMatchTracking(t *testing.T) {
+	if _, err := NewFuzzyART(4, 0.5, 0.01, 1, WithMatchTracking(1)); err == nil {
+		t.Error("expected an error with epsilon 1")
+	}
+	model, err := NewFuzzyART(4, 0.5, 0.01, 1, WithMatchTracking(0.001))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	samples := []struct {
+		a     []float64
+		label string
+	}{
+		{[]float64{0.1, 0.1, 0.1, 0.1}, "a"},
+		{[]float64{0.3, 0.3, 0.3, 0.3}, "b"},
+		{[]float64{0.12, 0.1, 0.1, 0.1}, "a"},
+		{[]float64{0.3, 0.32, 0.3, 0.3}, "b"},
+	}
+	for _, s := range samples {
+		model.FitLabeled(s.a, s.label)
+	}
+	if len(model.w) != 2 {
+		t.Fatalf("expected match tracking to separate the labels in 2 categories, got %d", len(model.w))
+	}
+	for _, s := range samples {
+		if label, _, _ := model.PredictLabel(s.a); label != s.label {
+			t.Errorf("input %v: expected label %s, got %s", s.a, s.label, label)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := model.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFuzzyART(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+	decoded, err := FromProto(model.ToProto())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Close()
+	for _, m := range []*FuzzyART{loaded, decoded} {
+		if !m.matchTracking || m.epsilon != 0.001 {
+			t.Errorf("expected match tracking to be restored, got %t (%f)", m.matchTracking, m.epsilon)
+		}
+	}
+}
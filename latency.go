@@ -0,0 +1,167 @@
+package art
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// latencyBucketsPerOctave sets the histogram resolution: quantiles are within ~9% of the true value.
+const (
+	latencyBucketsPerOctave = 8
+	latencyBuckets          = 64 * latencyBucketsPerOctave
+)
+
+// latencyHistogram is a lock-free log-linear histogram of durations,
+// recording costs a few atomic operations and no allocations.
+type latencyHistogram struct {
+	// sum is the total of the observed durations, in nanoseconds
+	sum     atomic.Uint64
+	buckets [latencyBuckets]atomic.Uint64
+}
+
+// bucket returns the histogram bucket of d: the octave (log2) of d
+// split in latencyBucketsPerOctave linear sub-buckets.
+func latencyBucket(d time.Duration) int {
+	ns := uint64(max(d, 1))
+	octave := bits.Len64(ns) - 1
+	// the bits right below the leading one select the linear sub-bucket
+	var sub uint64
+	if octave >= 3 {
+		sub = (ns >> (octave - 3)) & (latencyBucketsPerOctave - 1)
+	} else {
+		sub = (ns << (3 - octave)) & (latencyBucketsPerOctave - 1)
+	}
+	return octave*latencyBucketsPerOctave + int(sub)
+}
+
+// latencyBucketValue returns the middle value of bucket b.
+func latencyBucketValue(b int) time.Duration {
+	octave, sub := b/latencyBucketsPerOctave, b%latencyBucketsPerOctave
+	lower := math.Ldexp(1+float64(sub)/latencyBucketsPerOctave, octave)
+	width := math.Ldexp(1.0/latencyBucketsPerOctave, octave)
+	return time.Duration(lower + width/2)
+}
+
+func (h *latencyHistogram) observe(start time.Time) {
+	d := time.Since(start)
+	h.buckets[latencyBucket(d)].Add(1)
+	h.sum.Add(uint64(max(d, 0)))
+}
+
+// quantiles returns the requested quantiles, computed on a snapshot of the buckets.
+func (h *latencyHistogram) quantiles(qs ...float64) (count uint64, values []time.Duration) {
+	var snapshot [latencyBuckets]uint64
+	for b := range snapshot {
+		snapshot[b] = h.buckets[b].Load()
+		count += snapshot[b]
+	}
+
+	values = make([]time.Duration, len(qs))
+	if count == 0 {
+		return 0, values
+	}
+	for i, q := range qs {
+		rank := uint64(math.Ceil(q * float64(count)))
+		var cumulative uint64
+		for b, n := range snapshot {
+			cumulative += n
+			if cumulative >= max(rank, 1) {
+				values[i] = latencyBucketValue(b)
+				break
+			}
+		}
+	}
+	return count, values
+}
+
+// LatencyStats summarizes the latency of an operation.
+type LatencyStats struct {
+	Count uint64
+	// Sum is the total time spent in the operation.
+	Sum           time.Duration
+	P50, P95, P99 time.Duration
+}
+
+func (h *latencyHistogram) stats() LatencyStats {
+	count, q := h.quantiles(0.5, 0.95, 0.99)
+	return LatencyStats{Count: count, Sum: time.Duration(h.sum.Load()), P50: q[0], P95: q[1], P99: q[2]}
+}
+
+// Stats is a snapshot of the model operational statistics.
+type Stats struct {
+	Categories int
+	// Fit and Predict latencies, only tracked after WithLatencyStats
+	Fit, Predict LatencyStats
+}
+
+type latencyStats struct {
+	fit, predict latencyHistogram
+}
+
+// WithLatencyStats tracks the latency percentiles of Fit and Predict, exposed by Stats.
+func WithLatencyStats() Option {
+	return func(f *fuzzyState) error {
+		f.latency = new(latencyStats)
+		return nil
+	}
+}
+
+// Stats returns the number of categories and, if enabled, the Fit and Predict latency percentiles.
+// Latency percentiles can be safely read while the model is learning.
+func (f *FuzzyARTOf[T]) Stats() Stats {
+	s := Stats{Categories: len(f.w)}
+	if f.latency != nil {
+		s.Fit = f.latency.fit.stats()
+		s.Predict = f.latency.predict.stats()
+	}
+	return s
+}
+
+// PublishStats publishes the model Stats as an expvar variable with the given name.
+// Like expvar.Publish, it panics if the name is already in use.
+// The number of categories is read without synchronization,
+// it may be slightly stale while the model is learning.
+func (f *FuzzyARTOf[T]) PublishStats(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return f.Stats() }))
+}
+
+// WritePrometheus writes the model Stats in the Prometheus text exposition format,
+// as metrics prefixed by name, e.g.: name_categories, name_fit_seconds{quantile="0.99"}.
+func (f *FuzzyARTOf[T]) WritePrometheus(w io.Writer, name string) error {
+	s := f.Stats()
+	var b strings.Builder
+	fmt.Fprintf(&b, "# TYPE %s_categories gauge\n%s_categories %d\n", name, name, s.Categories)
+	if f.latency != nil {
+		for _, op := range []struct {
+			name  string
+			stats LatencyStats
+		}{{"fit", s.Fit}, {"predict", s.Predict}} {
+			metric := name + "_" + op.name + "_seconds"
+			fmt.Fprintf(&b, "# TYPE %s summary\n", metric)
+			for _, q := range []struct {
+				quantile string
+				value    time.Duration
+			}{{"0.5", op.stats.P50}, {"0.95", op.stats.P95}, {"0.99", op.stats.P99}} {
+				fmt.Fprintf(&b, "%s{quantile=%q} %g\n", metric, q.quantile, q.value.Seconds())
+			}
+			fmt.Fprintf(&b, "%s_sum %g\n%s_count %d\n", metric, op.stats.Sum.Seconds(), metric, op.stats.Count)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// PrometheusHandler returns an http.Handler serving WritePrometheus.
+func (f *FuzzyARTOf[T]) PrometheusHandler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		f.WritePrometheus(w, name)
+	})
+}
@@ -0,0 +1,76 @@
+package art
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatencyHistogramQuantiles(t *testing.T) {
+	var h latencyHistogram
+	for i := 1; i <= 1000; i++ {
+		h.buckets[latencyBucket(time.Duration(i)*time.Microsecond)].Add(1)
+	}
+
+	_, q := h.quantiles(0.5, 0.99)
+	for i, want := range []time.Duration{500 * time.Microsecond, 990 * time.Microsecond} {
+		if rel := float64(q[i]-want) / float64(want); rel < -0.1 || rel > 0.1 {
+			t.Errorf("quantile %d: expected ~%s, got %s", i, want, q[i])
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 1, WithLatencyStats())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	model.Predict([]float64{0.1, 0.1, 0.1, 0.1}, false)
+
+	s := model.Stats()
+	if s.Categories != 1 || s.Fit.Count != 1 || s.Predict.Count != 1 || s.Fit.P99 == 0 || s.Fit.Sum == 0 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+
+	var b strings.Builder
+	if err := model.WritePrometheus(&b, "art"); err != nil {
+		t.Fatal(err)
+	}
+	for _, metric := range []string{`art_predict_seconds{quantile="0.99"}`, "art_fit_seconds_sum ", "art_fit_seconds_count 1\n"} {
+		if !strings.Contains(b.String(), metric) {
+			t.Errorf("missing %s in:\n%s", metric, b.String())
+		}
+	}
+
+	if err := model.WritePrometheus(failingWriter{}, "art"); err == nil {
+		t.Error("expected the write error")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestStatsFrozen(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 1, WithLatencyStats())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	model.SetFrozen(true)
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	if _, _, err := model.FitCtx(context.Background(), []float64{0.1, 0.1, 0.1, 0.1}); err != nil {
+		t.Fatal(err)
+	}
+	model.FitWeighted([]float64{0.1, 0.1, 0.1, 0.1}, 2)
+	model.Predict([]float64{0.1, 0.1, 0.1, 0.1}, true)
+
+	if s := model.Stats(); s.Fit.Count != 4 || s.Predict.Count != 1 {
+		t.Errorf("expected 4 fits and 1 prediction, got %d and %d", s.Fit.Count, s.Predict.Count)
+	}
+}
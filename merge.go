@@ -0,0 +1,187 @@
+package art
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// MergeCategories merges categories i and j into the smallest hyper-rectangle containing both,
+// keeping the lower index and summing their counts.
+// It returns the index remapping: old index -> new index, the merged categories both map to the new one.
+func (f *FuzzyARTOf[T]) MergeCategories(i, j int) (remap []int, err error) {
+	for _, k := range []int{i, j} {
+		if k < 0 || k >= len(f.w) {
+			return nil, fmt.Errorf("category index must be between 0 and %d, got %d", len(f.w)-1, k)
+		}
+	}
+	if i == j {
+		return nil, fmt.Errorf("cannot merge category %d with itself", i)
+	}
+
+	a, b := min(i, j), max(i, j)
+	f.mergeInto(a, b)
+	into := identityRemap(len(f.w))
+	into[b] = a
+	return f.compactMerged(into), nil
+}
+
+// MergeOverlapping merges the categories whose hyper-rectangles overlap by at least threshold,
+// the fraction of the smaller one covered by their intersection (measured by the sum of its sides),
+// as long as their union still passes the vigilance test for every input it contains.
+// Fast learning tends to proliferate almost coincident categories, which are merged with a threshold of e.g. 0.8.
+// Categories are compared in index order, each one absorbing the later ones overlapping it, in a single O(C²) pass.
+// It returns the index remapping: old index -> new index, merged categories map to the category they were merged into.
+func (f *FuzzyARTOf[T]) MergeOverlapping(threshold float64) (remap []int, err error) {
+	if threshold <= 0 || threshold > 1 {
+		return nil, fmt.Errorf("overlap threshold must be between 0 (excluded) and 1, got %f", threshold)
+	}
+
+	into := identityRemap(len(f.w))
+	merged := false
+	fi := make([]T, 2*f.M)
+	for a := range f.w {
+		if into[a] != a {
+			continue
+		}
+		for b := a + 1; b < len(f.w); b++ {
+			if into[b] != b {
+				continue
+			}
+			// |w_a ∧ w_b| is the size of the union: every input inside it resonates with |union| / M
+			if unionNorm := float64(f.kernels().FuzzyIntersection(f.w[a], f.w[b], fi)); unionNorm < max(f.vigilance(a), f.vigilance(b))*float64(f.M) {
+				continue
+			}
+			if f.overlap(a, b) >= threshold {
+				f.mergeInto(a, b)
+				into[b] = a
+				merged = true
+			}
+		}
+	}
+	if !merged {
+		return into, nil
+	}
+	return f.compactMerged(into), nil
+}
+
+// overlap returns the fraction of the smaller hyper-rectangle of categories a and b covered by their intersection,
+// measured by the sum of the sides. A point category overlaps fully a category containing it.
+func (f *FuzzyARTOf[T]) overlap(a, b int) float64 {
+	return overlap(f.w[a], f.w[b])
+}
+
+// overlap returns the overlap of the hyper-rectangles of the complement-coded weights wa and wb, see FuzzyART.overlap.
+func overlap[T Float](wa, wb []T) float64 {
+	m := len(wa) / 2
+	var intersection, sizeA, sizeB float64
+	for i := range m {
+		lower, upper := float64(max(wa[i], wb[i])), min(1-float64(wa[i+m]), 1-float64(wb[i+m]))
+		// the upper bounds are rounded by the complement, coincident sides must still touch
+		if upper < lower-1e-12 {
+			return 0
+		}
+		intersection += max(upper-lower, 0)
+		sizeA += 1 - float64(wa[i+m]) - float64(wa[i])
+		sizeB += 1 - float64(wb[i+m]) - float64(wb[i])
+	}
+	smaller := min(sizeA, sizeB)
+	if smaller <= 1e-12 {
+		return 1
+	}
+	return intersection / smaller
+}
+
+// compactMerged removes the categories merged into others, into[j] != j,
+// and returns the index remapping with the merged categories mapped to the category they were merged into.
+// The metadata store drops the metadata of the merged categories.
+func (f *FuzzyARTOf[T]) compactMerged(into []int) (remap []int) {
+	// errors of the metadata store are returned by the next metadata operation
+	remap, _ = f.compactMetadata(func(j int) bool { return into[j] == j })
+	remap = slices.Clone(remap)
+	for j, k := range into {
+		if k != j {
+			remap[j] = remap[k]
+		}
+	}
+	return remap
+}
+
+// MergeStrategy tells how Merge deduplicates the categories of the merged model.
+type MergeStrategy int
+
+const (
+	// MergeAppend appends all the categories of the other model.
+	MergeAppend MergeStrategy = iota
+	// MergeDuplicates merges the categories with the same weights, summing their counts.
+	MergeDuplicates
+	// MergeOverlaps merges the categories overlapping by at least 0.8 whose union passes the vigilance test,
+	// as MergeOverlapping does, so that the prototypes learned by both models from the same region are unified.
+	MergeOverlaps
+)
+
+// mergeOverlapThreshold is the overlap threshold of MergeOverlaps.
+const mergeOverlapThreshold = 0.8
+
+// Merge adds the categories of other, e.g. a model trained on another data shard, to f.
+// The categories keep their counts and labels, and get new IDs in f. Depending on the strategy,
+// the categories of other are then merged into the categories of f (or previously added ones) they duplicate.
+// The hyperparameters and options of f are kept, other must have the same input length and is not modified.
+// It returns the index remapping of the categories of other: index in other -> index in f.
+func (f *FuzzyARTOf[T]) Merge(other *FuzzyARTOf[T], strategy MergeStrategy) (remap []int, err error) {
+	if other.M != f.M {
+		return nil, fmt.Errorf("merged model must have %d features, got %d", f.M, other.M)
+	}
+	if strategy < MergeAppend || strategy > MergeOverlaps {
+		return nil, fmt.Errorf("unknown merge strategy %d", strategy)
+	}
+
+	n := len(f.w)
+	for j := range other.w {
+		f.appendCategoryOf(other, j)
+	}
+
+	into := identityRemap(len(f.w))
+	merged := false
+	fi := make([]T, 2*f.M)
+	for b := n; b < len(f.w); b++ {
+		for a := range b {
+			if into[a] != a || !f.duplicates(a, b, strategy, fi) {
+				continue
+			}
+			f.mergeInto(a, b)
+			into[b] = a
+			merged = true
+			break
+		}
+	}
+	if merged {
+		into = f.compactMerged(into)
+	}
+	return into[n:], nil
+}
+
+// appendCategoryOf appends a copy of category j of other, keeping its counts and labels, with a new ID.
+func (f *FuzzyARTOf[T]) appendCategoryOf(other *FuzzyARTOf[T], j int) int {
+	k := f.appendNewCategory(slices.Clone(other.w[j]))
+	c := other.categories[j]
+	c.id, c.born, c.shared = f.categories[k].id, f.categories[k].born, false
+	c.usage, c.usageAt = other.CategoryStats(j).Usage, f.age
+	if c.votes != nil {
+		c.votes = maps.Clone(c.votes)
+	}
+	f.categories[k] = c
+	return k
+}
+
+// duplicates reports whether category b duplicates category a for the merge strategy.
+func (f *FuzzyARTOf[T]) duplicates(a, b int, strategy MergeStrategy, fi []T) bool {
+	switch strategy {
+	case MergeDuplicates:
+		return slices.Equal(f.w[a], f.w[b])
+	case MergeOverlaps:
+		unionNorm := float64(f.kernels().FuzzyIntersection(f.w[a], f.w[b], fi))
+		return unionNorm >= max(f.vigilance(a), f.vigilance(b))*float64(f.M) && f.overlap(a, b) >= mergeOverlapThreshold
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package art
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestMergeCategories(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.95, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}, {0.2, 0.2, 0.2, 0.2}} {
+		model.Fit(a)
+	}
+
+	if _, err := model.MergeCategories(0, 3); err == nil {
+		t.Error("expected an error merging a category out of range")
+	}
+	if _, err := model.MergeCategories(1, 1); err == nil {
+		t.Error("expected an error merging a category with itself")
+	}
+
+	remap, err := model.MergeCategories(2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(remap, []int{0, 1, 0}) {
+		t.Errorf("expected remap [0 1 0], got %v", remap)
+	}
+	want := []float64{0.1, 0.1, 0.1, 0.1, 0.8, 0.8, 0.8, 0.8}
+	if len(model.w) != 2 || !slices.Equal(model.w[0], want) || model.Count(0) != 2 {
+		t.Errorf("expected the union %v learned twice, got %v (%d)", want, model.w[0], model.Count(0))
+	}
+}
+
+func TestMergeOverlapping(t *testing.T) {
+	model, err := NewFuzzyART(2, 0.5, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	// hyper-rectangles as [x0, x1] x [y0, y1]: weights are x0, y0, 1-x1, 1-y1
+	for _, r := range [][4]float64{
+		{0.1, 0.3, 0.1, 0.3},   // 0
+		{0.12, 0.3, 0.1, 0.32}, // 1 mostly inside 0
+		{0.6, 0.9, 0.6, 0.9},   // 2 far from 0 and 1
+		{0.2, 0.25, 0.2, 0.25}, // 3 inside 0
+		{0.25, 0.7, 0.25, 0.7}, // 4 overlapping 0 a little
+	} {
+		model.appendNewCategory([]float64{r[0], r[2], 1 - r[1], 1 - r[3]})
+	}
+
+	if _, err := model.MergeOverlapping(0); err == nil {
+		t.Error("expected an error with a zero threshold")
+	}
+	remap, err := model.MergeOverlapping(0.8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(remap, []int{0, 0, 1, 0, 2}) {
+		t.Errorf("expected remap [0 0 1 0 2], got %v", remap)
+	}
+	if len(model.w) != 3 || model.Count(0) != 3 {
+		t.Fatalf("expected 3 categories, the first with 3 samples, got %d", len(model.w))
+	}
+	want := []float64{0.1, 0.1, 0.7, 0.68}
+	for i, v := range model.w[0] {
+		if v-want[i] > 1e-12 || want[i]-v > 1e-12 {
+			t.Fatalf("expected the union %v, got %v", want, model.w[0])
+		}
+	}
+
+	if remap, _ := model.MergeOverlapping(0.8); !slices.Equal(remap, []int{0, 1, 2}) {
+		t.Errorf("expected nothing left to merge, got remap %v", remap)
+	}
+}
+
+func TestMergeOverlappingVigilance(t *testing.T) {
+	model, err := NewFuzzyART(2, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	// a point inside a large category: their union doesn't pass the vigilance test
+	model.appendNewCategory([]float64{0.1, 0.1, 0.6, 0.6})
+	model.appendNewCategory([]float64{0.2, 0.2, 0.8, 0.8})
+	if remap, _ := model.MergeOverlapping(0.5); len(model.w) != 2 {
+		t.Errorf("expected no merge, got remap %v", remap)
+	}
+}
+
+func TestMergeModels(t *testing.T) {
+	shard := func(points ...float64) *FuzzyART {
+		model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range points {
+			model.FitLabeled([]float64{v, v, v, v}, fmt.Sprint(v > 0.5))
+		}
+		return model
+	}
+
+	for _, tc := range []struct {
+		strategy   MergeStrategy
+		categories int
+	}{{MergeAppend, 5}, {MergeDuplicates, 4}, {MergeOverlaps, 3}} {
+		model := shard(0.1, 0.9)
+		// 0.1 duplicates a category of model, 0.89 to 0.91 contains 0.9, 0.5 is new
+		other := shard(0.1, 0.89, 0.5)
+		other.Fit([]float64{0.91, 0.91, 0.91, 0.91})
+
+		remap, err := model.Merge(other, tc.strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(model.w) != tc.categories || len(remap) != 3 {
+			t.Fatalf("strategy %d: expected %d categories and 3 remapped, got %d and %v", tc.strategy, tc.categories, len(model.w), remap)
+		}
+		total := 0
+		for j := range model.w {
+			total += model.Count(j)
+		}
+		if total != 6 {
+			t.Errorf("strategy %d: expected the counts of both models, got %d", tc.strategy, total)
+		}
+		for j, k := range remap {
+			if model.Label(k) != other.Label(j) {
+				t.Errorf("strategy %d: category %d of other mapped to %d, labels %q and %q", tc.strategy, j, k, other.Label(j), model.Label(k))
+			}
+		}
+		if tc.strategy != MergeAppend && remap[0] != 0 {
+			t.Errorf("strategy %d: expected the duplicated category to map to 0, got %d", tc.strategy, remap[0])
+		}
+		if _, j := model.Predict([]float64{0.5, 0.5, 0.5, 0.5}, false); j != remap[2] {
+			t.Errorf("strategy %d: expected the new category %d to match, got %d", tc.strategy, remap[2], j)
+		}
+		model.Close()
+		other.Close()
+	}
+
+	model := shard(0.1)
+	defer model.Close()
+	other, err := NewFuzzyART(2, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if _, err := model.Merge(other, MergeAppend); err == nil {
+		t.Error("expected an error with a different input length")
+	}
+}
@@ -0,0 +1,134 @@
+package art
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// MetadataStore stores per-category metadata (exemplars, stats, annotations...)
+// outside of the model, so that it doesn't need to live in RAM.
+// Values are opaque to the model, keys are category IDs (see CategoryID),
+// so that they don't change when the categories are compacted.
+// The boltstore package provides a persistent implementation.
+type MetadataStore interface {
+	// Get returns the metadata of the category, nil if there is none.
+	Get(id uint64) ([]byte, error)
+	// Put stores the metadata of the category.
+	Put(id uint64, value []byte) error
+	// Delete removes the metadata of the categories removed from the model.
+	Delete(ids ...uint64) error
+	// Close releases the store resources.
+	Close() error
+}
+
+// WithMetadataStore attaches a metadata store to the model,
+// operations removing or merging categories keep it consistent.
+func WithMetadataStore(s MetadataStore) Option {
+	return func(f *fuzzyState) error {
+		if s == nil {
+			return fmt.Errorf("metadata store must not be nil")
+		}
+		f.metadata = s
+		return nil
+	}
+}
+
+// Metadata returns the metadata of category j, nil if there is none or no store is attached.
+func (f *FuzzyARTOf[T]) Metadata(j int) ([]byte, error) {
+	if f.metadata == nil {
+		return nil, nil
+	}
+	if err := f.metadataErr; err != nil {
+		return nil, fmt.Errorf("metadata store is inconsistent: %w", err)
+	}
+	if j < 0 || j >= len(f.w) {
+		return nil, fmt.Errorf("category index must be between 0 and %d, got %d", len(f.w)-1, j)
+	}
+	return f.metadata.Get(f.categories[j].id)
+}
+
+// SetMetadata stores the metadata of category j in the attached store.
+func (f *FuzzyARTOf[T]) SetMetadata(j int, value []byte) error {
+	if f.metadata == nil {
+		return fmt.Errorf("no metadata store attached")
+	}
+	if err := f.metadataErr; err != nil {
+		return fmt.Errorf("metadata store is inconsistent: %w", err)
+	}
+	if j < 0 || j >= len(f.w) {
+		return fmt.Errorf("category index must be between 0 and %d, got %d", len(f.w)-1, j)
+	}
+	return f.metadata.Put(f.categories[j].id, value)
+}
+
+// compactMetadata works like compact, and deletes the metadata of the removed categories from the attached store.
+// Errors are also kept, so that operations which can't fail (e.g.: Fit) don't lose them.
+func (f *FuzzyARTOf[T]) compactMetadata(keep func(j int) bool) (remap []int, err error) {
+	var removed []uint64
+	if f.metadata != nil {
+		for j, c := range f.categories {
+			if !keep(j) {
+				removed = append(removed, c.id)
+			}
+		}
+	}
+	remap = f.compact(keep)
+	if len(removed) == 0 {
+		return remap, nil
+	}
+	err = f.metadata.Delete(removed...)
+	if err != nil && f.metadataErr == nil {
+		f.metadataErr = err
+	}
+	return remap, err
+}
+
+// MemoryStore is a MetadataStore keeping the metadata in memory.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	values map[uint64][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: make(map[uint64][]byte)}
+}
+
+func (s *MemoryStore) Get(id uint64) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.values[id]), nil
+}
+
+func (s *MemoryStore) Put(id uint64, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[id] = slices.Clone(value)
+	return nil
+}
+
+func (s *MemoryStore) Delete(ids ...uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		delete(s.values, id)
+	}
+	return nil
+}
+
+// Len returns the number of categories with metadata.
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.values)
+}
+
+// Categories returns the sorted IDs of the categories with metadata.
+func (s *MemoryStore) Categories() []uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Sorted(maps.Keys(s.values))
+}
+
+func (s *MemoryStore) Close() error { return nil }
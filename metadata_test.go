@@ -0,0 +1,50 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+// deleteRecorder is a MemoryStore recording the deleted IDs.
+type deleteRecorder struct {
+	*MemoryStore
+	deleted []uint64
+}
+
+func (s *deleteRecorder) Delete(ids ...uint64) error {
+	s.deleted = append(s.deleted, ids...)
+	return s.MemoryStore.Delete(ids...)
+}
+
+func TestMetadataKeyedByID(t *testing.T) {
+	store := &deleteRecorder{MemoryStore: NewMemoryStore()}
+	model, err := NewFuzzyART(2, 0.9, 0.01, 1, WithMetadataStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1}, {0.5, 0.5}, {0.9, 0.9}} {
+		model.Fit(a)
+	}
+	for j, v := range []string{"a", "b", "c"} {
+		if err := model.SetMetadata(j, []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := model.DeleteCategory(1); err != nil {
+		t.Fatal(err)
+	}
+	// the compaction only deletes the removed category, the others keep their keys
+	if !slices.Equal(store.deleted, []uint64{2}) {
+		t.Errorf("expected only the ID 2 deleted, got %v", store.deleted)
+	}
+	if ids := store.Categories(); !slices.Equal(ids, []uint64{1, 3}) {
+		t.Errorf("expected the metadata of IDs 1 and 3, got %v", ids)
+	}
+	for j, want := range []string{"a", "c"} {
+		if v, err := model.Metadata(j); err != nil || string(v) != want {
+			t.Errorf("category %d: expected %q, got %q (%v)", j, want, v, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package art
+
+import (
+	"maps"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+)
+
+// FitMiniBatch learns the samples as a mini-batch, deferring the weight updates:
+// every sample is matched in parallel against the weights at the start of the batch,
+// running the search of Fit (the most active category passing the vigilance test),
+// then the updates of every category are applied together, so that the result doesn't depend
+// on the order of the samples matching the same category. With fast learning (beta = 1)
+// a category learns the intersection of all its samples, as the sequential updates would,
+// with slow learning it moves toward the mean of their fuzzy intersections.
+// The samples that don't match any category are then fitted sequentially, in order, as by Fit,
+// possibly creating new categories (or evicting some, with a category cap).
+// It returns the resonance and the category of every sample, indexed as the samples.
+// A frozen model only predicts the samples, see SetFrozen.
+func (f *FuzzyARTOf[T]) FitMiniBatch(samples [][]float64) []Prediction {
+	if f.frozen {
+		return f.PredictBatch(samples)
+	}
+	if f.halfLife > 0 && f.age >= f.nextSweep {
+		f.evictDecayed()
+	}
+
+	predictions := make([]Prediction, len(samples))
+	inputs := make([][]T, len(samples))
+	if len(f.w) > 0 {
+		var wg sync.WaitGroup
+		workers := make(chan struct{}, runtime.NumCPU())
+		for start := 0; start < len(samples); start += predictBatchSize {
+			end := min(start+predictBatchSize, len(samples))
+			var rng *rand.Rand
+			if f.tieBreak == TieBreakRandom {
+				rng = rand.New(rand.NewPCG(f.random().Uint64(), f.random().Uint64()))
+			}
+
+			wg.Add(1)
+			workers <- struct{}{}
+			go func() {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+				fi, fiNorms := f.newVector(), make([]T, len(f.w))
+				for i := start; i < end; i++ {
+					started := time.Now()
+					// without an Encoder the inputs are nil, and the complement coding is fused in the kernels
+					inputs[i] = f.encodedInput(samples[i])
+					predictions[i] = f.resonating(samples[i], inputs[i], fi, fiNorms, rng)
+					// the unmatched samples are recorded by the sequential Fit below
+					if f.latency != nil && predictions[i].Category != -1 {
+						f.latency.fit.observe(started)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	} else {
+		for i := range predictions {
+			predictions[i].Category = -1
+		}
+	}
+
+	// updates accumulates the fuzzy intersections learned by every matched category
+	updates := make(map[int][]T)
+	matched := make(map[int]int)
+	for i, p := range predictions {
+		j := p.Category
+		if j == -1 {
+			continue
+		}
+		A := inputs[i]
+		if A == nil {
+			A = f.learningInput(samples[i])
+		}
+		fi, ok := updates[j]
+		if !ok {
+			fi = f.newVector()
+			if f.beta == 1 {
+				copy(fi, f.w[j])
+			}
+			updates[j] = fi
+		}
+		for k, v := range A {
+			if f.beta == 1 {
+				fi[k] = min(fi[k], v)
+			} else {
+				fi[k] += min(f.w[j][k], v)
+			}
+		}
+		matched[j]++
+	}
+	// categories are updated in index order: with decay the usage depends on the age,
+	// which every update advances, and WithDeterministic needs the same result on every run
+	for _, j := range slices.Sorted(maps.Keys(updates)) {
+		fi := updates[j]
+		if f.beta < 1 {
+			for k := range fi {
+				fi[k] /= T(matched[j])
+			}
+		}
+		f.learnBatch(j, fi, matched[j])
+	}
+	for _, p := range predictions {
+		if p.Category != -1 {
+			f.notifyHooks(p.Category, p.Activation, f.lastID)
+			if f.checkpoint != nil {
+				f.checkpoint.learned(f)
+			}
+		}
+	}
+
+	for i, p := range predictions {
+		if p.Category == -1 {
+			predictions[i].Activation, predictions[i].Category = f.Fit(samples[i])
+		}
+	}
+	return predictions
+}
+
+// resonating returns the resonance and the index of the most active category passing the vigilance test
+// for the input a, given its encodedInput A, -1 if none, without touching the shared activation list.
+// fi and fiNorms are the buffers of allInputNorms.
+func (f *FuzzyARTOf[T]) resonating(a []float64, A, fi, fiNorms []T, rng *rand.Rand) Prediction {
+	x := valuesOf[T](a)
+	f.allInputNorms(x, A, fiNorms, fi)
+	aNorm := f.sampleNorm(x, A)
+	best := Prediction{Category: -1}
+	var bestT, t fuzzyActivation[T]
+	for j, fiNorm := range fiNorms[:len(f.w)] {
+		t.j = j
+		t.fiNorm, t.wNorm = float64(fiNorm), f.categories[j].wNorm
+		resonance := f.normalizedActivation(t.fiNorm, aNorm)
+		if resonance < f.vigilance(j) {
+			continue
+		}
+		t.activation = t.fiNorm / (f.alpha + t.wNorm) * f.usagePrior(j)
+		if rng != nil {
+			t.tieKey = rng.Uint64()
+		}
+		if best.Category == -1 || f.beats(&t, &bestT) {
+			bestT, best = t, Prediction{Activation: resonance, Category: j}
+		}
+	}
+	return best
+}
+
+// learnBatch updates the weights of category j toward fi with learning rate beta, for n samples.
+func (f *FuzzyARTOf[T]) learnBatch(j int, fi []T, n int) {
+	f.unshare(j)
+	f.kernels().UpdateFuzzyWeights(f.w[j], fi, T(f.beta))
+	c := &f.categories[j]
+	c.wNorm = float64(f.kernels().SumFloat64(f.w[j]))
+	c.count += n
+	c.lastUsed = time.Now()
+	if f.halfLife > 0 {
+		c.usage, c.usageAt = f.decayedUsage(j)+float64(n), f.age
+	}
+	f.age += n
+	f.coarseDirty = true
+}
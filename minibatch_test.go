@@ -0,0 +1,134 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFitMiniBatchOrderIndependent(t *testing.T) {
+	samples := [][]float64{
+		{0.1, 0.1, 0.1, 0.1}, {0.12, 0.1, 0.11, 0.1}, {0.9, 0.9, 0.9, 0.9},
+		{0.88, 0.9, 0.91, 0.9}, {0.11, 0.12, 0.1, 0.1}, {0.9, 0.88, 0.9, 0.89},
+	}
+	categories := []int{0, 0, 1, 1, 0, 1}
+	fit := func(order []int) *FuzzyART {
+		model, err := NewFuzzyART(4, 0.8, 0.01, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+		model.Fit([]float64{0.9, 0.9, 0.9, 0.9})
+		batch := make([][]float64, len(order))
+		for i, k := range order {
+			batch[i] = samples[k]
+		}
+		predictions := model.FitMiniBatch(batch)
+		for i, p := range predictions {
+			if p.Category != categories[order[i]] {
+				t.Errorf("sample %d: expected category %d, got %+v", order[i], categories[order[i]], p)
+			}
+		}
+		return model
+	}
+
+	first, second := fit([]int{0, 1, 2, 3, 4, 5}), fit([]int{5, 3, 1, 4, 2, 0})
+	defer first.Close()
+	defer second.Close()
+	if len(first.w) != 2 || !slices.EqualFunc(first.w, second.w, slices.Equal) {
+		t.Errorf("expected the same 2 categories in any order, got %v and %v", first.w, second.w)
+	}
+	if first.categories[0].count+first.categories[1].count != 8 {
+		t.Errorf("expected 8 learned samples, got %d and %d", first.categories[0].count, first.categories[1].count)
+	}
+}
+
+func TestFitMiniBatchNewCategories(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	predictions := model.FitMiniBatch([][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}, {0.1, 0.1, 0.1, 0.1}})
+	want := []int{0, 1, 0}
+	for i, p := range predictions {
+		if p.Category != want[i] {
+			t.Errorf("sample %d: expected category %d, got %+v", i, want[i], p)
+		}
+	}
+	if len(model.w) != 2 {
+		t.Errorf("expected 2 categories, got %d", len(model.w))
+	}
+}
+
+func TestFitMiniBatchMatchesFit(t *testing.T) {
+	for _, beta := range []float64{1, 0.5} {
+		batch, err := NewFuzzyART(4, 0.7, 0.01, beta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sequential, err := NewFuzzyART(4, 0.7, 0.01, beta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, a := range [][]float64{{0.2, 0.3, 0.4, 0.5}, {0.8, 0.7, 0.6, 0.5}} {
+			batch.Fit(a)
+			sequential.Fit(a)
+		}
+
+		a := []float64{0.25, 0.3, 0.35, 0.5}
+		activation, j := sequential.Fit(a)
+		got := batch.FitMiniBatch([][]float64{a})
+		if got[0] != (Prediction{Activation: activation, Category: j}) {
+			t.Errorf("beta %f: expected %d (%f) as Fit, got %+v", beta, j, activation, got[0])
+		}
+		if !slices.EqualFunc(batch.w, sequential.w, slices.Equal) {
+			t.Errorf("beta %f: expected the weights of Fit, got %v and %v", beta, batch.w, sequential.w)
+		}
+		batch.Close()
+		sequential.Close()
+	}
+}
+
+func TestFitMiniBatchDeterministicDecay(t *testing.T) {
+	samples := [][]float64{{0.1, 0.1}, {0.3, 0.3}, {0.5, 0.5}, {0.7, 0.7}, {0.9, 0.9}}
+	fit := func() []float64 {
+		model, err := NewFuzzyART(2, 0.9, 0.01, 1, WithDecay(10, 1e-9), WithDeterministic(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer model.Close()
+		for _, a := range samples {
+			model.Fit(a)
+		}
+		// one sample per category, updated in the order of the map without sorting
+		model.FitMiniBatch(samples)
+		usage := make([]float64, len(model.categories))
+		for j, c := range model.categories {
+			usage[j] = c.usage
+		}
+		return usage
+	}
+
+	want := fit()
+	for range 20 {
+		if got := fit(); !slices.Equal(got, want) {
+			t.Fatalf("expected the same category usage on every run, got %v and %v", got, want)
+		}
+	}
+}
+
+func TestFitMiniBatchLatency(t *testing.T) {
+	model, err := NewFuzzyART(2, 0.9, 0.01, 1, WithLatencyStats())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.1})
+
+	// one sample matching the category and one fitted sequentially
+	model.FitMiniBatch([][]float64{{0.1, 0.1}, {0.9, 0.9}})
+	if n := model.Stats().Fit.Count; n != 3 {
+		t.Errorf("expected the latency of 3 fits, got %d", n)
+	}
+}
@@ -0,0 +1,178 @@
+package art
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"unsafe"
+)
+
+// The weights file layout is little endian:
+//
+//	magic    [4]byte "FARW"
+//	version  uint32
+//	rowLen   uint64, 2M
+//	count    uint64, the number of categories
+//	padding  up to mmapHeaderSize bytes
+//	rows     [capacity][rowLen]float64
+//
+// The file grows by doubling its capacity, rows past count are unused.
+var mmapMagic = [4]byte{'F', 'A', 'R', 'W'}
+
+const (
+	mmapVersion    = 1
+	mmapHeaderSize = 64
+	mmapMinRows    = 64
+)
+
+// mmapWeights backs the weights of a FuzzyART with a memory-mapped file.
+// The rows of the weights alias the mapping, so learning writes through to the file.
+type mmapWeights struct {
+	file     *os.File
+	data     []byte
+	rowLen   int
+	capacity int
+}
+
+// WithMmapWeights backs the weights with the memory-mapped file at path, created if missing,
+// so that models with more categories than fit in RAM are paged in and out by the OS,
+// and can be reopened instantly passing the same path.
+// Only the weights are stored: category counts, labels and the other options are not,
+// use Save to persist the whole model.
+// The mapping is released by Close. It is supported on unix systems only, for float64 weights.
+func WithMmapWeights(path string) Option {
+	return func(f *fuzzyState) error {
+		f.mmapPath = path
+		return nil
+	}
+}
+
+// mapWeights maps the weights file of WithMmapWeights, once the options are applied,
+// and appends its rows as the categories of f. The file stores float64 values.
+func (f *FuzzyARTOf[T]) mapWeights() error {
+	if !isFloat64[T]() {
+		return fmt.Errorf("memory-mapped weights must be float64, got %T", T(0))
+	}
+	m, count, err := openMmapWeights(f.mmapPath, 2*f.M)
+	if err != nil {
+		return fmt.Errorf("mapping weights file: %w", err)
+	}
+	// the rows are appended once the mapping is set, so that they alias the file instead of a slab
+	f.mmap = m
+	for j := range count {
+		f.appendNewCategory(valuesOf[T](m.row(j)))
+	}
+	return nil
+}
+
+func openMmapWeights(path string, rowLen int) (_ *mmapWeights, count int, err error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, 0, err
+	}
+	m := &mmapWeights{file: file, rowLen: rowLen}
+	defer func() {
+		if err != nil {
+			m.close()
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	if info.Size() == 0 {
+		if err := m.mapRows(mmapMinRows); err != nil {
+			return nil, 0, err
+		}
+		copy(m.data, mmapMagic[:])
+		binary.LittleEndian.PutUint32(m.data[4:], mmapVersion)
+		binary.LittleEndian.PutUint64(m.data[8:], uint64(rowLen))
+		return m, 0, nil
+	}
+
+	rowBytes := int64(8 * rowLen)
+	if info.Size() < mmapHeaderSize || (info.Size()-mmapHeaderSize)%rowBytes != 0 {
+		return nil, 0, fmt.Errorf("invalid weights file size %d", info.Size())
+	}
+	if err := m.mapRows(int((info.Size() - mmapHeaderSize) / rowBytes)); err != nil {
+		return nil, 0, err
+	}
+	if [4]byte(m.data[:4]) != mmapMagic {
+		return nil, 0, fmt.Errorf("not a weights file, magic %q", m.data[:4])
+	}
+	if v := binary.LittleEndian.Uint32(m.data[4:]); v != mmapVersion {
+		return nil, 0, fmt.Errorf("unsupported weights file version %d", v)
+	}
+	if n := binary.LittleEndian.Uint64(m.data[8:]); n != uint64(rowLen) {
+		return nil, 0, fmt.Errorf("weights file rows have length %d, expected %d", n, rowLen)
+	}
+	count = int(binary.LittleEndian.Uint64(m.data[16:]))
+	if count > m.capacity {
+		return nil, 0, fmt.Errorf("weights file has %d categories but room for %d", count, m.capacity)
+	}
+	return m, count, nil
+}
+
+// mapRows grows the file to hold capacity rows and maps it, replacing the previous mapping.
+// The previous mapping is released only once the new one is in place, so it stays valid on errors.
+func (m *mmapWeights) mapRows(capacity int) error {
+	size := mmapHeaderSize + 8*m.rowLen*capacity
+	if err := m.file.Truncate(int64(size)); err != nil {
+		return err
+	}
+	data, err := mapFile(m.file, size)
+	if err != nil {
+		return err
+	}
+	if m.data != nil {
+		if err := unmapFile(m.data); err != nil {
+			unmapFile(data)
+			return err
+		}
+	}
+	m.data = data
+	m.capacity = capacity
+	return nil
+}
+
+// row returns row j, aliasing the mapping.
+func (m *mmapWeights) row(j int) []float64 {
+	offset := mmapHeaderSize + 8*m.rowLen*j
+	// the capacity extends to the end of the mapping, so that consecutive rows are contiguous, see contiguousRows
+	return unsafe.Slice((*float64)(unsafe.Pointer(&m.data[offset])), (m.capacity-j)*m.rowLen)[:m.rowLen]
+}
+
+func (m *mmapWeights) setCount(n int) {
+	binary.LittleEndian.PutUint64(m.data[16:], uint64(n))
+}
+
+// appendRow copies w in the row following the rows of f.w, growing the mapping if needed,
+// and returns it. Growing moves the mapping, the rows of f.w are then pointed to the new one.
+func (f *FuzzyARTOf[T]) appendRow(w []T) []T {
+	m := f.mmap
+	n := len(f.w)
+	if n == m.capacity {
+		if err := m.mapRows(max(2*m.capacity, mmapMinRows)); err != nil {
+			panic(fmt.Sprintf("art: growing the weights file: %v", err))
+		}
+		for j := range f.w {
+			f.w[j] = valuesOf[T](m.row(j))
+		}
+	}
+	row := valuesOf[T](m.row(n))
+	copy(row, w)
+	m.setCount(n + 1)
+	return row
+}
+
+// close releases the mapping and the file, flushing the changes.
+func (m *mmapWeights) close() error {
+	var err error
+	if m.data != nil {
+		err = errors.Join(syncFile(m.data), unmapFile(m.data))
+		m.data = nil
+	}
+	return errors.Join(err, m.file.Close())
+}
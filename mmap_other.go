@@ -0,0 +1,22 @@
+//go:build !unix
+
+package art
+
+import (
+	"errors"
+	"os"
+)
+
+var errMmapUnsupported = errors.New("memory-mapped weights are not supported on this platform")
+
+func mapFile(*os.File, int) ([]byte, error) {
+	return nil, errMmapUnsupported
+}
+
+func unmapFile([]byte) error {
+	return errMmapUnsupported
+}
+
+func syncFile([]byte) error {
+	return errMmapUnsupported
+}
@@ -0,0 +1,138 @@
+//go:build unix
+
+package art
+
+import (
+	"math/rand/v2"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMmapWeights(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weights.farw")
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	model, err := NewFuzzyART(4, 0.95, 0.01, 1, WithMmapWeights(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference, err := NewFuzzyART(4, 0.95, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reference.Close()
+
+	// enough categories to grow the mapping a few times
+	for range 2000 {
+		a := []float64{rng.Float64(), rng.Float64(), rng.Float64(), rng.Float64()}
+		model.Fit(a)
+		reference.Fit(a)
+	}
+	if len(model.w) <= 2*mmapMinRows {
+		t.Fatalf("expected more than %d categories, got %d", 2*mmapMinRows, len(model.w))
+	}
+	model.compact(func(j int) bool { return j%3 != 0 })
+	reference.compact(func(j int) bool { return j%3 != 0 })
+	model.Close()
+
+	reopened, err := NewFuzzyART(4, 0.95, 0.01, 1, WithMmapWeights(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if !slices.EqualFunc(reopened.w, reference.w, slices.Equal) {
+		t.Fatalf("reopened weights differ: %d categories, expected %d", len(reopened.w), len(reference.w))
+	}
+
+	a := []float64{0.3, 0.6, 0.2, 0.8}
+	_, want := reference.Predict(a, false)
+	if _, got := reopened.Predict(a, false); got != want {
+		t.Errorf("reopened model predicted %d, expected %d", got, want)
+	}
+
+	if _, err := NewFuzzyART(8, 0.95, 0.01, 1, WithMmapWeights(path)); err == nil {
+		t.Error("expected an error reopening the file with a different input length")
+	}
+}
+
+func TestMmapWeightsReopenLearn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weights.farw")
+	rng := rand.New(rand.NewPCG(3, 4))
+	sample := func() []float64 {
+		return []float64{rng.Float64(), rng.Float64(), rng.Float64(), rng.Float64()}
+	}
+	open := func() *FuzzyART {
+		model, err := NewFuzzyART(4, 0.9, 0.01, 0.5, WithMmapWeights(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return model
+	}
+
+	model := open()
+	for range 20 {
+		model.Fit(sample())
+	}
+	model.Close()
+
+	// learning after a reopen writes through to the file
+	reopened := open()
+	for range 20 {
+		reopened.Fit(sample())
+	}
+	want := make([][]float64, len(reopened.w))
+	for j, w := range reopened.w {
+		want[j] = slices.Clone(w)
+	}
+	reopened.Close()
+
+	again := open()
+	defer again.Close()
+	if !slices.EqualFunc(again.w, want, slices.Equal) {
+		t.Fatalf("the weights learned after reopening were lost: %d categories, expected %d", len(again.w), len(want))
+	}
+
+	// growing the mapping keeps the learned weights
+	for len(again.w) <= mmapMinRows {
+		again.Fit(sample())
+		for j, w := range again.w {
+			if norm := again.kernels().SumFloat64(w); norm != again.categories[j].wNorm {
+				t.Fatalf("category %d: cached norm %f, weights norm %f", j, again.categories[j].wNorm, norm)
+			}
+		}
+	}
+}
+
+func TestMmapRestoreMatching(t *testing.T) {
+	dir := t.TempDir()
+	ar, err := OpenArchive(filepath.Join(dir, "archive.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := NewFuzzyART(4, 0.95, 0.01, 1, WithMmapWeights(filepath.Join(dir, "weights.mmap")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	inputs := [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.5, 0.5, 0.5, 0.5}, {0.9, 0.9, 0.9, 0.9}}
+	for _, a := range inputs {
+		model.Fit(a)
+	}
+	model.categories[0].lastUsed = time.Now().Add(-time.Hour)
+	if _, err := model.ArchiveUnused(ar, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	// the restored rows shift in the mapping, the weights must follow their category
+	if j, err := model.RestoreMatching(ar, inputs[0]); err != nil || j != 0 {
+		t.Fatalf("expected the category restored at 0, got %d (%v)", j, err)
+	}
+	for j, a := range inputs {
+		if _, k := model.Predict(a, false); k != j || model.CategoryID(k) != uint64(j+1) {
+			t.Errorf("input %d: expected category %d, got %d with ID %d", j, j, k, model.CategoryID(k))
+		}
+	}
+}
@@ -0,0 +1,21 @@
+//go:build unix
+
+package art
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func mapFile(file *os.File, size int) ([]byte, error) {
+	return unix.Mmap(int(file.Fd()), 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+}
+
+func unmapFile(data []byte) error {
+	return unix.Munmap(data)
+}
+
+func syncFile(data []byte) error {
+	return unix.Msync(data, unix.MS_SYNC)
+}
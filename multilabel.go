@@ -0,0 +1,159 @@
+package art
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// LabelSet is a set of non-negative class labels, stored as a bit vector.
+type LabelSet []uint64
+
+// NewLabelSet returns the set of the given labels.
+func NewLabelSet(labels ...int) LabelSet {
+	var s LabelSet
+	for _, l := range labels {
+		s.Add(l)
+	}
+	return s
+}
+
+// Add adds label to the set.
+func (s *LabelSet) Add(label int) {
+	if label < 0 {
+		panic(fmt.Sprintf("label must not be negative, got %d", label))
+	}
+	for len(*s) <= label/64 {
+		*s = append(*s, 0)
+	}
+	(*s)[label/64] |= 1 << (label % 64)
+}
+
+// Has reports whether label is in the set.
+func (s LabelSet) Has(label int) bool {
+	return label >= 0 && label/64 < len(s) && s[label/64]&(1<<(label%64)) != 0
+}
+
+// Labels returns the labels of the set in increasing order.
+func (s LabelSet) Labels() []int {
+	var labels []int
+	for i, word := range s {
+		for word != 0 {
+			labels = append(labels, i*64+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+	return labels
+}
+
+// Equal reports whether the sets have the same labels.
+func (s LabelSet) Equal(other LabelSet) bool {
+	trim := func(s LabelSet) LabelSet {
+		for len(s) > 0 && s[len(s)-1] == 0 {
+			s = s[:len(s)-1]
+		}
+		return s
+	}
+	return slices.Equal(trim(s), trim(other))
+}
+
+// MultiLabelSFAM extends SFAM to multi-label classification, for tagging-style problems:
+// every category stores the label set of the samples it learned, and match tracking
+// separates the inputs with different label sets, as SFAM does with different labels.
+// Predictions score every label by the activations of the most active categories having it,
+// as in ML-ARAM (Benites & Sapozhnikova, 2015), and return the labels scoring above a threshold.
+type MultiLabelSFAM struct {
+	fuzzy *FuzzyART
+
+	// Match tracking parameter - the vigilance increase after a wrong prediction, see SFAM.
+	epsilon float64
+
+	// Number of categories voting the labels in Predict
+	// Recommended value: 1 to 5, 1 predicts the label set of the best matching category.
+	neighbors int
+
+	// sets stores the label set of every category
+	sets []LabelSet
+}
+
+func NewMultiLabelSFAM(inputLen int, rho, alpha, beta, epsilon float64, neighbors int) (*MultiLabelSFAM, error) {
+	if epsilon <= -1 || epsilon >= 1 {
+		return nil, fmt.Errorf("match tracking parameter (epsilon) must be between -1 and 1, got %f", epsilon)
+	}
+	if neighbors < 1 {
+		return nil, fmt.Errorf("voting neighbors must be at least 1, got %d", neighbors)
+	}
+	fuzzy, err := NewFuzzyART(inputLen, rho, alpha, beta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiLabelSFAM{
+		fuzzy:     fuzzy,
+		epsilon:   epsilon,
+		neighbors: neighbors,
+		sets:      make([]LabelSet, 0),
+	}, nil
+}
+
+// Fit learns the input with its label set and returns the index of the learning category.
+func (m *MultiLabelSFAM) Fit(a []float64, labels LabelSet) (categoryIndex int) {
+	A := m.fuzzy.learningInput(a)
+	m.fuzzy.activateCategories(A)
+
+	_, categoryIndex, created := m.fuzzy.trackMatch(A, m.epsilon, func(j int) bool {
+		return m.sets[j].Equal(labels)
+	})
+	if created {
+		m.sets = append(m.sets, slices.Clone(labels))
+	}
+	return categoryIndex
+}
+
+// Predict returns the labels scoring at least threshold and the index of the best matching category,
+// or nil, -1 if the model has no categories yet.
+// The score of a label, between 0 and 1, is the activation of the voting categories having it
+// over the activation of all the voting categories: with a threshold of 0.5 the labels of
+// the majority of the neighbors (by activation) are returned.
+func (m *MultiLabelSFAM) Predict(a []float64, threshold float64) (labels LabelSet, categoryIndex int) {
+	if len(m.sets) == 0 {
+		return nil, -1
+	}
+
+	f := m.fuzzy
+	f.activateCategories(f.complementCode(a))
+	voters := f.t[:min(m.neighbors, len(f.t))]
+	scores := make(map[int]float64)
+	var sum float64
+	for _, t := range voters {
+		for _, l := range m.sets[t.j].Labels() {
+			scores[l] += t.activation
+		}
+		sum += t.activation
+	}
+
+	categoryIndex = f.t[0].j
+	if sum == 0 {
+		return slices.Clone(m.sets[categoryIndex]), categoryIndex
+	}
+	for l, score := range scores {
+		if score/sum >= threshold {
+			labels.Add(l)
+		}
+	}
+	return labels, categoryIndex
+}
+
+// Labels returns the label set of category j.
+func (m *MultiLabelSFAM) Labels(j int) LabelSet {
+	return m.sets[j]
+}
+
+// CategoryCount returns the number of categories.
+func (m *MultiLabelSFAM) CategoryCount() int {
+	return len(m.sets)
+}
+
+func (m *MultiLabelSFAM) Close() {
+	m.fuzzy.Close()
+}
@@ -0,0 +1,52 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLabelSet(t *testing.T) {
+	s := NewLabelSet(3, 70, 0)
+	if !slices.Equal(s.Labels(), []int{0, 3, 70}) || !s.Has(70) || s.Has(4) || s.Has(200) || s.Has(-1) {
+		t.Errorf("unexpected set %v", s.Labels())
+	}
+	if !NewLabelSet(1).Equal(LabelSet{2, 0}) || NewLabelSet(1).Equal(NewLabelSet(1, 2)) || !LabelSet(nil).Equal(LabelSet{0}) {
+		t.Error("expected sets to be compared by their labels")
+	}
+}
+
+func TestMultiLabelSFAM(t *testing.T) {
+	model, err := NewMultiLabelSFAM(4, 0.1, 0.01, 1, 0.001, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	if labels, j := model.Predict([]float64{0.5, 0.5, 0.5, 0.5}, 0.5); labels != nil || j != -1 {
+		t.Errorf("expected no prediction from an empty model, got %v, %d", labels, j)
+	}
+
+	a := []float64{0.2, 0.2, 0.2, 0.2}
+	b := []float64{0.3, 0.3, 0.3, 0.3}
+	model.Fit(a, NewLabelSet(1, 2))
+	if j := model.Fit(b, NewLabelSet(2, 5)); j != 1 {
+		t.Fatalf("a different label set should be reset by match tracking, got category %d", j)
+	}
+	if j := model.Fit([]float64{0.21, 0.2, 0.2, 0.2}, NewLabelSet(2, 1)); j != 0 || model.CategoryCount() != 2 {
+		t.Fatalf("the same label set should be learned by category 0, got %d of %d", j, model.CategoryCount())
+	}
+
+	// both categories vote, the shared label scores 1
+	labels, j := model.Predict(a, 0.99)
+	if j != 0 || !slices.Equal(labels.Labels(), []int{2}) {
+		t.Errorf("expected label 2 from category 0, got %v from %d", labels.Labels(), j)
+	}
+	if labels, _ := model.Predict(a, 0.5); !slices.Equal(labels.Labels(), []int{1, 2}) {
+		t.Errorf("expected the labels of the most active category, got %v", labels.Labels())
+	}
+	if labels, _ := model.Predict(a, 0.01); !slices.Equal(labels.Labels(), []int{1, 2, 5}) {
+		t.Errorf("expected the labels of both categories, got %v", labels.Labels())
+	}
+	if !model.Labels(1).Equal(NewLabelSet(2, 5)) {
+		t.Errorf("unexpected labels of category 1: %v", model.Labels(1).Labels())
+	}
+}
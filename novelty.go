@@ -0,0 +1,14 @@
+package art
+
+// NoveltyScore returns 1 minus the best resonance of the input among the categories, between 0 and 1,
+// without learning or creating categories: it grows with the distance from the closest category,
+// inputs inside it score the size of the category over M, and the score is 1 if the model has no categories yet.
+// Inputs scoring above 1 - rho would create a new category if learned,
+// so the model can be used directly as a streaming anomaly detector.
+func (f *FuzzyARTOf[T]) NoveltyScore(a []float64) float64 {
+	if len(f.w) == 0 {
+		return 1
+	}
+	resonance, _ := f.bestResonance(a)
+	return 1 - resonance
+}
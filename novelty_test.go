@@ -0,0 +1,32 @@
+package art
+
+import "testing"
+
+func TestNoveltyScore(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	if score := model.NoveltyScore([]float64{0.5, 0.5, 0.5, 0.5}); score != 1 {
+		t.Errorf("expected novelty 1 from an empty model, got %f", score)
+	}
+
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	model.Fit([]float64{0.14, 0.14, 0.14, 0.14})
+	model.Fit([]float64{0.9, 0.9, 0.9, 0.9})
+	n := len(model.w)
+
+	inside := model.NoveltyScore([]float64{0.12, 0.12, 0.12, 0.12})
+	near := model.NoveltyScore([]float64{0.25, 0.14, 0.14, 0.14})
+	far := model.NoveltyScore([]float64{0.5, 0.5, 0.5, 0.5})
+	if inside > 0.05 || near <= inside || far <= near || far > 1 {
+		t.Errorf("expected novelty growing with the distance, got %f, %f, %f", inside, near, far)
+	}
+	if far <= 1-model.rho {
+		t.Errorf("expected a novel input to score above 1 - rho, got %f", far)
+	}
+	if len(model.w) != n || model.Count(0) != 2 {
+		t.Errorf("expected nothing learned, got %d categories and count %d", len(model.w), model.Count(0))
+	}
+}
@@ -0,0 +1,125 @@
+package art
+
+import (
+	"context"
+	"fmt"
+)
+
+// TrainerEvent describes a sample learned by an OnlineTrainer.
+type TrainerEvent struct {
+	Sample []float64
+	Prediction
+	// CategoryID is the stable ID of the category, see CategoryID: the index can be shifted
+	// by the next samples (e.g. with a category cap) before the event is handled.
+	CategoryID uint64
+	// Created is true when the sample created the category.
+	Created bool
+}
+
+// OnlineTrainer learns the samples read from a channel, emitting an event for every learned sample,
+// the building block to embed a model in an event-driven service.
+// It applies backpressure: the events must be consumed, otherwise the trainer stops reading the samples
+// once the event buffer is full, and the producers block on the sample channel.
+// The samples available on the channel are learned in batches of up to batchSize, without waiting for a full batch.
+type OnlineTrainer struct {
+	model     *FuzzyART
+	in        <-chan []float64
+	events    chan TrainerEvent
+	batchSize int
+	// miniBatch fits every batch with FitMiniBatch instead of Fit
+	miniBatch bool
+}
+
+// NewOnlineTrainer returns a trainer of model reading the samples from in, in batches of up to batchSize samples.
+// With miniBatch, every batch is learned with FitMiniBatch, matching its samples in parallel,
+// otherwise the samples are learned one by one with Fit. The categories removed while learning a mini-batch
+// (e.g. by a category cap) shift the indexes of its predictions, see FitMiniBatch: their events then carry ID 0.
+// The model must not be used by other goroutines while the trainer runs.
+func NewOnlineTrainer(model *FuzzyART, in <-chan []float64, batchSize int, miniBatch bool) (*OnlineTrainer, error) {
+	if batchSize < 1 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+	return &OnlineTrainer{model: model, in: in, events: make(chan TrainerEvent, batchSize), batchSize: batchSize, miniBatch: miniBatch}, nil
+}
+
+// Events returns the channel of the events, closed when Run returns.
+func (t *OnlineTrainer) Events() <-chan TrainerEvent {
+	return t.events
+}
+
+// Run learns the samples until the sample channel is closed, returning nil, or ctx is done, returning the context error.
+// The samples of the batch being emitted when ctx is done are learned, but their events can be dropped.
+func (t *OnlineTrainer) Run(ctx context.Context) error {
+	defer close(t.events)
+	batch := make([][]float64, 0, t.batchSize)
+	for {
+		batch = batch[:0]
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case a, ok := <-t.in:
+			if !ok {
+				return nil
+			}
+			batch = append(batch, a)
+		}
+		closed := false
+	fill:
+		for len(batch) < t.batchSize {
+			select {
+			case a, ok := <-t.in:
+				if !ok {
+					closed = true
+					break fill
+				}
+				batch = append(batch, a)
+			default:
+				break fill
+			}
+		}
+
+		for _, e := range t.fit(batch) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case t.events <- e:
+			}
+		}
+		if closed {
+			return nil
+		}
+	}
+}
+
+// fit learns the batch and returns its events.
+func (t *OnlineTrainer) fit(batch [][]float64) []TrainerEvent {
+	f := t.model
+	events := make([]TrainerEvent, len(batch))
+	if t.miniBatch {
+		lastID := f.lastID
+		created := make(map[uint64]bool)
+		for i, p := range f.FitMiniBatch(batch) {
+			events[i] = TrainerEvent{Sample: batch[i], Prediction: p, CategoryID: f.categoryIDOf(p.Category)}
+			// the samples matching a category don't create it, the first one learned by a new category does
+			if id := events[i].CategoryID; id > lastID && !created[id] {
+				events[i].Created, created[id] = true, true
+			}
+		}
+		return events
+	}
+	for i, a := range batch {
+		lastID := f.lastID
+		activation, j := f.Fit(a)
+		id := f.categoryIDOf(j)
+		events[i] = TrainerEvent{Sample: a, Prediction: Prediction{Activation: activation, Category: j}, CategoryID: id, Created: id > lastID}
+	}
+	return events
+}
+
+// categoryIDOf returns the ID of category j, 0 for -1 (e.g. a frozen empty model) or a removed category.
+func (f *FuzzyARTOf[T]) categoryIDOf(j int) uint64 {
+	if j < 0 || j >= len(f.categories) {
+		return 0
+	}
+	return f.categories[j].id
+}
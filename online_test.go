@@ -0,0 +1,79 @@
+package art
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOnlineTrainer(t *testing.T) {
+	samples := [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}, {0.11, 0.1, 0.1, 0.1}, {0.5, 0.5, 0.5, 0.5}, {0.9, 0.89, 0.9, 0.9}}
+	for _, miniBatch := range []bool{false, true} {
+		model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		in := make(chan []float64, len(samples))
+		for _, a := range samples {
+			in <- a
+		}
+		close(in)
+		trainer, err := NewOnlineTrainer(model, in, 2, miniBatch)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		done := make(chan error)
+		go func() { done <- trainer.Run(context.Background()) }()
+		var events []TrainerEvent
+		for e := range trainer.Events() {
+			events = append(events, e)
+		}
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+
+		wantIDs, wantCreated := []uint64{1, 2, 1, 3, 2}, []bool{true, true, false, true, false}
+		if len(events) != len(samples) {
+			t.Fatalf("mini-batch %t: expected %d events, got %d", miniBatch, len(samples), len(events))
+		}
+		for i, e := range events {
+			if e.CategoryID != wantIDs[i] || e.Created != wantCreated[i] || &e.Sample[0] != &samples[i][0] {
+				t.Errorf("mini-batch %t, sample %d: expected category %d (created %t), got %+v", miniBatch, i, wantIDs[i], wantCreated[i], e)
+			}
+		}
+		model.Close()
+	}
+
+	if _, err := NewOnlineTrainer(nil, nil, 0, false); err == nil {
+		t.Error("expected an error with an empty batch")
+	}
+}
+
+func TestOnlineTrainerCancel(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	in := make(chan []float64)
+	trainer, err := NewOnlineTrainer(model, in, 4, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- trainer.Run(ctx) }()
+	in <- []float64{0.1, 0.1, 0.1, 0.1}
+	if e := <-trainer.Events(); e.Category != 0 || !e.Created {
+		t.Errorf("expected the first sample to create category 0, got %+v", e)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+	if _, ok := <-trainer.Events(); ok {
+		t.Error("expected the events to be closed")
+	}
+}
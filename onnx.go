@@ -0,0 +1,202 @@
+package art
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/oblq/art/internal/protowire"
+)
+
+// The ONNX graph written by WriteONNX computes, for a batch of N inputs of M features:
+//
+//	coded         = Concat(input, 1 - input)                    complement coding, [N, 2M]
+//	intersection  = Min(Unsqueeze(coded, 1), weights)           fuzzy AND with every category, [N, C, 2M]
+//	norm          = ReduceSum(intersection, axis 2)             |A ∧ w|, [N, C]
+//	activations   = norm * choice_scale                         choice function, [N, C]
+//	category      = ArgMax(activations, axis 1)                 [N]
+//	resonance     = GatherElements(norm, category) / M          [N]
+//
+// choice_scale holds usagePrior(j)/(alpha+|w_j|) for every category, precomputed at export time.
+// Values are doubles, so that the results match the ones of Predict.
+
+const (
+	onnxIRVersion = 7
+	onnxOpset     = 13
+
+	onnxDouble = 11
+	onnxInt64  = 7
+
+	onnxAttributeInt = 2
+)
+
+// ModelProto, GraphProto, NodeProto, AttributeProto, TensorProto and ValueInfoProto field numbers, see onnx.proto.
+const (
+	onnxModelIRVersion     = 1
+	onnxModelProducerName  = 2
+	onnxModelGraph         = 7
+	onnxModelOpsetImport   = 8
+	onnxOpsetVersion       = 2
+	onnxGraphNode          = 1
+	onnxGraphName          = 2
+	onnxGraphInitializer   = 5
+	onnxGraphInput         = 11
+	onnxGraphOutput        = 12
+	onnxNodeInput          = 1
+	onnxNodeOutput         = 2
+	onnxNodeName           = 3
+	onnxNodeOpType         = 4
+	onnxNodeAttribute      = 5
+	onnxAttributeName      = 1
+	onnxAttributeI         = 3
+	onnxAttributeType      = 20
+	onnxTensorDims         = 1
+	onnxTensorDataType     = 2
+	onnxTensorName         = 8
+	onnxTensorRawData      = 9
+	onnxValueInfoName      = 1
+	onnxValueInfoType      = 2
+	onnxTypeTensor         = 1
+	onnxTypeTensorElemType = 1
+	onnxTypeTensorShape    = 2
+	onnxShapeDim           = 1
+	onnxDimValue           = 1
+	onnxDimParam           = 2
+)
+
+type onnxAttribute struct {
+	name  string
+	value int64
+}
+
+func onnxNode(opType string, inputs, outputs []string, attributes ...onnxAttribute) []byte {
+	var b []byte
+	for _, in := range inputs {
+		b = protowire.AppendString(b, onnxNodeInput, in)
+	}
+	for _, out := range outputs {
+		b = protowire.AppendString(b, onnxNodeOutput, out)
+	}
+	b = protowire.AppendString(b, onnxNodeName, outputs[0])
+	b = protowire.AppendString(b, onnxNodeOpType, opType)
+	for _, attr := range attributes {
+		var a []byte
+		a = protowire.AppendString(a, onnxAttributeName, attr.name)
+		a = protowire.AppendInt64(a, onnxAttributeI, attr.value)
+		a = protowire.AppendInt64(a, onnxAttributeType, onnxAttributeInt)
+		b = protowire.AppendBytes(b, onnxNodeAttribute, a)
+	}
+	return b
+}
+
+func onnxTensor(name string, dataType int64, dims []int64, raw []byte) []byte {
+	var b []byte
+	for _, d := range dims {
+		b = protowire.AppendInt64(b, onnxTensorDims, d)
+	}
+	b = protowire.AppendInt64(b, onnxTensorDataType, dataType)
+	b = protowire.AppendString(b, onnxTensorName, name)
+	return protowire.AppendBytes(b, onnxTensorRawData, raw)
+}
+
+func onnxDoubles(name string, dims []int64, v []float64) []byte {
+	raw := make([]byte, 0, 8*len(v))
+	for _, x := range v {
+		raw = binary.LittleEndian.AppendUint64(raw, math.Float64bits(x))
+	}
+	return onnxTensor(name, onnxDouble, dims, raw)
+}
+
+func onnxInt64s(name string, dims []int64, v []int64) []byte {
+	raw := make([]byte, 0, 8*len(v))
+	for _, x := range v {
+		raw = binary.LittleEndian.AppendUint64(raw, uint64(x))
+	}
+	return onnxTensor(name, onnxInt64, dims, raw)
+}
+
+// onnxValueInfo describes a tensor, dims are either int64 sizes or string symbolic names.
+func onnxValueInfo(name string, elemType int64, dims ...any) []byte {
+	var shape []byte
+	for _, d := range dims {
+		var dim []byte
+		switch d := d.(type) {
+		case int:
+			dim = protowire.AppendInt64(dim, onnxDimValue, int64(d))
+		case string:
+			dim = protowire.AppendString(dim, onnxDimParam, d)
+		}
+		shape = protowire.AppendBytes(shape, onnxShapeDim, dim)
+	}
+	var tensor []byte
+	tensor = protowire.AppendInt64(tensor, onnxTypeTensorElemType, elemType)
+	tensor = protowire.AppendBytes(tensor, onnxTypeTensorShape, shape)
+
+	var b []byte
+	b = protowire.AppendString(b, onnxValueInfoName, name)
+	return protowire.AppendBytes(b, onnxValueInfoType, protowire.AppendBytes(nil, onnxTypeTensor, tensor))
+}
+
+// WriteONNX writes an ONNX model (opset 13) computing the predictions of the model without learning,
+// to run them in ONNX Runtime where this package can't be deployed.
+// The graph takes a batch of inputs "input" [N, M] and returns "category" [N] (int64),
+// "resonance" [N] and "activations" [N, C], as returned by Predict and computed by the choice function.
+// Equal activations resolve to the lowest category index, that is TieBreakOldest.
+// The graph complement-codes the inputs, so models using WithEncoder can't be exported.
+func (f *FuzzyARTOf[T]) WriteONNX(w io.Writer) error {
+	if len(f.w) == 0 {
+		return errors.New("cannot export a model without categories")
+	}
+	if f.encoder != nil {
+		return errors.New("cannot export a model with an encoder, the ONNX graph complement-codes the inputs")
+	}
+	c, m := int64(len(f.w)), int64(2*f.M)
+
+	weights := make([]float64, 0, c*m)
+	scale := make([]float64, c)
+	for j, wj := range f.w {
+		weights = append(weights, float64s(wj)...)
+		scale[j] = f.usagePrior(j) / (f.alpha + f.categories[j].wNorm)
+	}
+
+	var g []byte
+	for _, node := range [][]byte{
+		onnxNode("Sub", []string{"one", "input"}, []string{"complement"}),
+		onnxNode("Concat", []string{"input", "complement"}, []string{"coded"}, onnxAttribute{"axis", 1}),
+		onnxNode("Unsqueeze", []string{"coded", "axis_1"}, []string{"coded_3d"}),
+		onnxNode("Min", []string{"coded_3d", "weights"}, []string{"intersection"}),
+		onnxNode("ReduceSum", []string{"intersection", "axis_2"}, []string{"norm"}, onnxAttribute{"keepdims", 0}),
+		onnxNode("Mul", []string{"norm", "choice_scale"}, []string{"activations"}),
+		onnxNode("ArgMax", []string{"activations"}, []string{"category_2d"}, onnxAttribute{"axis", 1}, onnxAttribute{"keepdims", 1}),
+		onnxNode("Squeeze", []string{"category_2d", "axis_1"}, []string{"category"}),
+		onnxNode("GatherElements", []string{"norm", "category_2d"}, []string{"winner_norm"}, onnxAttribute{"axis", 1}),
+		onnxNode("Div", []string{"winner_norm", "input_norm"}, []string{"resonance_2d"}),
+		onnxNode("Squeeze", []string{"resonance_2d", "axis_1"}, []string{"resonance"}),
+	} {
+		g = protowire.AppendBytes(g, onnxGraphNode, node)
+	}
+	g = protowire.AppendString(g, onnxGraphName, "fuzzy_art")
+	for _, init := range [][]byte{
+		onnxDoubles("one", nil, []float64{1}),
+		onnxDoubles("input_norm", nil, []float64{float64(f.M)}),
+		onnxDoubles("weights", []int64{c, m}, weights),
+		onnxDoubles("choice_scale", []int64{c}, scale),
+		onnxInt64s("axis_1", []int64{1}, []int64{1}),
+		onnxInt64s("axis_2", []int64{1}, []int64{2}),
+	} {
+		g = protowire.AppendBytes(g, onnxGraphInitializer, init)
+	}
+	g = protowire.AppendBytes(g, onnxGraphInput, onnxValueInfo("input", onnxDouble, "N", f.M))
+	g = protowire.AppendBytes(g, onnxGraphOutput, onnxValueInfo("category", onnxInt64, "N"))
+	g = protowire.AppendBytes(g, onnxGraphOutput, onnxValueInfo("resonance", onnxDouble, "N"))
+	g = protowire.AppendBytes(g, onnxGraphOutput, onnxValueInfo("activations", onnxDouble, "N", len(f.w)))
+
+	var b []byte
+	b = protowire.AppendInt64(b, onnxModelIRVersion, onnxIRVersion)
+	b = protowire.AppendString(b, onnxModelProducerName, "github.com/oblq/art")
+	b = protowire.AppendBytes(b, onnxModelGraph, g)
+	b = protowire.AppendBytes(b, onnxModelOpsetImport, protowire.AppendInt64(nil, onnxOpsetVersion, onnxOpset))
+	_, err := w.Write(b)
+	return err
+}
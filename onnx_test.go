@@ -0,0 +1,135 @@
+package art
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/oblq/art/internal/protowire"
+)
+
+// onnxGraph decodes the node op types and the double initializers of a model written by WriteONNX.
+func onnxGraph(t *testing.T, model []byte) (ops []string, initializers map[string][]float64) {
+	t.Helper()
+	initializers = make(map[string][]float64)
+	var graph []byte
+	d := protowire.NewDecoder(model)
+	for num, wt, ok := d.Next(); ok; num, wt, ok = d.Next() {
+		if num == onnxModelGraph {
+			graph = d.Bytes(wt)
+		} else {
+			d.Skip(wt)
+		}
+	}
+
+	d = protowire.NewDecoder(graph)
+	for num, wt, ok := d.Next(); ok; num, wt, ok = d.Next() {
+		switch num {
+		case onnxGraphNode:
+			nd := protowire.NewDecoder(d.Bytes(wt))
+			for num, wt, ok := nd.Next(); ok; num, wt, ok = nd.Next() {
+				if num == onnxNodeOpType {
+					ops = append(ops, nd.String(wt))
+				} else {
+					nd.Skip(wt)
+				}
+			}
+		case onnxGraphInitializer:
+			var name string
+			var dataType uint64
+			var raw []byte
+			td := protowire.NewDecoder(d.Bytes(wt))
+			for num, wt, ok := td.Next(); ok; num, wt, ok = td.Next() {
+				switch num {
+				case onnxTensorName:
+					name = td.String(wt)
+				case onnxTensorDataType:
+					dataType = td.Varint(wt)
+				case onnxTensorRawData:
+					raw = td.Bytes(wt)
+				default:
+					td.Skip(wt)
+				}
+			}
+			if dataType == onnxDouble {
+				for i := 0; i < len(raw); i += 8 {
+					initializers[name] = append(initializers[name], math.Float64frombits(binary.LittleEndian.Uint64(raw[i:])))
+				}
+			}
+		default:
+			d.Skip(wt)
+		}
+	}
+	if d.Err != nil {
+		t.Fatal(d.Err)
+	}
+	return ops, initializers
+}
+
+func TestWriteONNX(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithUsagePrior(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	var buf bytes.Buffer
+	if err := model.WriteONNX(&buf); err == nil {
+		t.Error("expected an error exporting a model without categories")
+	}
+
+	inputs := [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}, {0.12, 0.1, 0.1, 0.1}, {0.5, 0.1, 0.9, 0.5}}
+	for _, a := range inputs {
+		model.Fit(a)
+	}
+	if err := model.WriteONNX(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	ops, initializers := onnxGraph(t, buf.Bytes())
+	wantOps := []string{"Sub", "Concat", "Unsqueeze", "Min", "ReduceSum", "Mul", "ArgMax", "Squeeze", "GatherElements", "Div", "Squeeze"}
+	if !slices.Equal(ops, wantOps) {
+		t.Fatalf("expected ops %v, got %v", wantOps, ops)
+	}
+
+	// evaluate the graph by hand with the exported initializers
+	weights, scale := initializers["weights"], initializers["choice_scale"]
+	if len(weights) != len(model.w)*8 || len(scale) != len(model.w) {
+		t.Fatalf("unexpected initializer sizes: %d weights, %d scales", len(weights), len(scale))
+	}
+	for _, a := range append(inputs, []float64{0.3, 0.6, 0.2, 0.8}) {
+		coded := append(slices.Clone(a), 1-a[0], 1-a[1], 1-a[2], 1-a[3])
+		best, bestNorm, bestActivation := 0, 0.0, -1.0
+		for j := range scale {
+			var norm float64
+			for i, v := range coded {
+				norm += min(v, weights[j*8+i])
+			}
+			if activation := norm * scale[j]; activation > bestActivation {
+				best, bestNorm, bestActivation = j, norm, activation
+			}
+		}
+
+		resonance, category := model.Predict(a, false)
+		if best != category || math.Abs(bestNorm/initializers["input_norm"][0]-resonance) > 1e-12 {
+			t.Errorf("input %v: graph predicts category %d with resonance %f, model %d with %f",
+				a, best, bestNorm/4, category, resonance)
+		}
+	}
+}
+
+func TestWriteONNXEncoder(t *testing.T) {
+	model, err := NewFuzzyART(2, 0.9, 0.01, 1, WithEncoder(ComplementCoded{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.2, 0.9, 0.8})
+
+	if err := model.WriteONNX(io.Discard); err == nil {
+		t.Error("expected an error exporting a model with an encoder")
+	}
+}
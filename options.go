@@ -0,0 +1,4 @@
+package art
+
+// Option configures optional FuzzyARTOf features at construction.
+type Option func(f *fuzzyState) error
@@ -0,0 +1,32 @@
+package art
+
+import "fmt"
+
+// SetRho changes the vigilance parameter, e.g. to anneal it during streaming learning.
+// The new value applies from the next input.
+func (f *FuzzyARTOf[T]) SetRho(rho float64) error {
+	if rho < 0 || rho > 1 {
+		return fmt.Errorf("vigilance parameter (rho) must be between 0 and 1, got %f", rho)
+	}
+	f.rho = rho
+	return nil
+}
+
+// Rho returns the vigilance parameter.
+func (f *FuzzyARTOf[T]) Rho() float64 {
+	return f.rho
+}
+
+// SetBeta changes the learning rate, the new value applies from the next input.
+func (f *FuzzyARTOf[T]) SetBeta(beta float64) error {
+	if beta <= 0 || beta > 1 {
+		return fmt.Errorf("learning rate (beta) must be between 0 and 1, got %f", beta)
+	}
+	f.beta = beta
+	return nil
+}
+
+// Beta returns the learning rate.
+func (f *FuzzyARTOf[T]) Beta() float64 {
+	return f.beta
+}
@@ -0,0 +1,40 @@
+package art
+
+import "testing"
+
+func TestSetRhoBeta(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.5, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	for _, rho := range []float64{-0.1, 1.1} {
+		if err := model.SetRho(rho); err == nil {
+			t.Errorf("expected an error setting rho %f", rho)
+		}
+	}
+	for _, beta := range []float64{0, 1.1} {
+		if err := model.SetBeta(beta); err == nil {
+			t.Errorf("expected an error setting beta %f", beta)
+		}
+	}
+	if model.Rho() != 0.5 || model.Beta() != 1 {
+		t.Fatalf("invalid values changed the parameters to %f, %f", model.Rho(), model.Beta())
+	}
+
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	if err := model.SetRho(0.99); err != nil {
+		t.Fatal(err)
+	}
+	if err := model.SetBeta(0.5); err != nil {
+		t.Fatal(err)
+	}
+	model.Fit([]float64{0.2, 0.2, 0.2, 0.2})
+	if len(model.w) != 2 {
+		t.Errorf("expected the raised vigilance to create a category, got %d", len(model.w))
+	}
+	if err := model.SetParam("rho", 2); err == nil || model.Rho() != 0.99 {
+		t.Errorf("expected SetParam to validate rho, got %v", err)
+	}
+}
@@ -0,0 +1,608 @@
+package art
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"time"
+)
+
+// The binary model format is little endian:
+//
+//	magic   [4]byte "FART"
+//	version uint32
+//	sections, each one made of:
+//		tag     uint32
+//		length  uint64, the payload length
+//		payload [length]byte
+//	end tag uint32 0
+//
+// Unknown sections are skipped when loading, so that new sections
+// can be added without breaking older readers.
+var modelMagic = [4]byte{'F', 'A', 'R', 'T'}
+
+const modelVersion = 1
+
+// Sane maximums for the sizes read from a model header,
+// so that a corrupt or malicious file fails instead of allocating them.
+const (
+	// maxSectionLen is the maximum length of a section payload.
+	maxSectionLen uint64 = 1 << 32
+	// maxInputLen is the maximum number of features of a model.
+	maxInputLen = 1 << 24
+)
+
+const (
+	sectionEnd uint32 = iota
+	// rho, alpha, beta float64, M uint64
+	sectionParams
+	// categories uint64, then the weights of every category, 2M float64 each
+	sectionWeights
+	// for every category: count uint64, lastUsed unix nanoseconds int64, label string
+	sectionCategories
+	// names uint64, then every name string
+	sectionFeatureNames
+	// input length uint64, pipeline string
+	sectionSignature
+	// max categories uint64, strategy uint64
+	sectionCap
+	// policy uint64, seed uint64
+	sectionTieBreak
+	// strength float64
+	sectionUsagePrior
+	// last ID uint64, then the ID of every category uint64
+	sectionCategoryIDs
+	// epsilon float64
+	sectionMatchTracking
+	// for every category: creation unix nanoseconds int64, 0 if unknown
+	sectionCategoryCreated
+	// rate float64
+	sectionCommitmentRate
+	// for every category: vigilance float64, 0 for the vigilance of the model
+	sectionCategoryVigilance
+	// half-life uint64, min usage float64
+	sectionDecay
+)
+
+// sectionWriter encodes the payload of a section, strings are prefixed by their uint64 length.
+type sectionWriter struct {
+	bytes.Buffer
+}
+
+func (s *sectionWriter) uint64(v uint64) {
+	s.Write(binary.LittleEndian.AppendUint64(nil, v))
+}
+
+func (s *sectionWriter) float64(v float64) {
+	s.uint64(math.Float64bits(v))
+}
+
+func (s *sectionWriter) string(v string) {
+	s.uint64(uint64(len(v)))
+	s.WriteString(v)
+}
+
+// sectionReader decodes the payload of a section, the first error is kept in err.
+type sectionReader struct {
+	data []byte
+	err  error
+}
+
+func (s *sectionReader) uint64() uint64 {
+	if len(s.data) < 8 {
+		s.err = io.ErrUnexpectedEOF
+		s.data = nil
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(s.data)
+	s.data = s.data[8:]
+	return v
+}
+
+func (s *sectionReader) float64() float64 {
+	return math.Float64frombits(s.uint64())
+}
+
+func (s *sectionReader) string() string {
+	n := s.uint64()
+	if n > uint64(len(s.data)) {
+		s.err = io.ErrUnexpectedEOF
+		s.data = nil
+		return ""
+	}
+	v := string(s.data[:n])
+	s.data = s.data[n:]
+	return v
+}
+
+// persistedState is the serializable state of a FuzzyART, shared by the binary and the JSON formats.
+type persistedState struct {
+	Rho           float64         `json:"rho"`
+	Alpha         float64         `json:"alpha"`
+	Beta          float64         `json:"beta"`
+	M             int             `json:"m"`
+	W             [][]float64     `json:"w"`
+	Categories    []categoryState `json:"categories,omitempty"`
+	FeatureNames  []string        `json:"feature_names,omitempty"`
+	Signature     *InputSignature `json:"signature,omitempty"`
+	MaxCategories int             `json:"max_categories,omitempty"`
+	CapStrategy   CapStrategy     `json:"cap_strategy,omitempty"`
+	TieBreak      TieBreak        `json:"tie_break,omitempty"`
+	Seed          uint64          `json:"seed,omitempty"`
+	PriorStrength float64         `json:"prior_strength,omitempty"`
+	MatchTracking *float64        `json:"match_tracking,omitempty"`
+	IDs           []uint64        `json:"ids,omitempty"`
+	LastID        uint64          `json:"last_id,omitempty"`
+	// CommitmentRate is 0 for fast commitment.
+	CommitmentRate float64     `json:"commitment_rate,omitempty"`
+	Decay          *decayState `json:"decay,omitempty"`
+}
+
+type decayState struct {
+	HalfLife int     `json:"half_life"`
+	MinUsage float64 `json:"min_usage"`
+}
+
+type categoryState struct {
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"last_used"`
+	Label    string    `json:"label,omitempty"`
+	Created  time.Time `json:"created"`
+	Rho      float64   `json:"rho,omitempty"`
+}
+
+// state returns the serializable state of the model, sharing the float64 weights.
+func (f *FuzzyARTOf[T]) state() *persistedState {
+	s := &persistedState{
+		Rho:            f.rho,
+		Alpha:          f.alpha,
+		Beta:           f.beta,
+		M:              f.M,
+		W:              make([][]float64, len(f.w)),
+		Categories:     make([]categoryState, len(f.categories)),
+		FeatureNames:   f.featureNames,
+		MaxCategories:  f.maxCategories,
+		CapStrategy:    f.capStrategy,
+		TieBreak:       f.tieBreak,
+		Seed:           f.seed,
+		PriorStrength:  f.priorStrength,
+		IDs:            make([]uint64, len(f.categories)),
+		LastID:         f.lastID,
+		CommitmentRate: f.commitmentRate,
+	}
+	for j, w := range f.w {
+		s.W[j] = float64s(w)
+	}
+	for j, c := range f.categories {
+		s.Categories[j] = categoryState{Count: c.count, LastUsed: c.lastUsed, Label: c.label, Created: c.created, Rho: c.rho}
+		s.IDs[j] = c.id
+	}
+	if f.signature.InputLen != 0 {
+		s.Signature = &f.signature
+	}
+	if f.matchTracking {
+		s.MatchTracking = &f.epsilon
+	}
+	if f.halfLife > 0 {
+		s.Decay = &decayState{HalfLife: f.halfLife, MinUsage: f.minUsage}
+	}
+	return s
+}
+
+// restoreState resets f to the serialized state, validating it.
+func (f *FuzzyARTOf[T]) restoreState(s *persistedState) error {
+	var opts []Option
+	if s.FeatureNames != nil {
+		opts = append(opts, WithFeatureNames(s.FeatureNames))
+	}
+	if s.Signature != nil {
+		opts = append(opts, WithInputSignature(*s.Signature))
+	}
+	if s.MaxCategories > 0 {
+		opts = append(opts, WithMaxCategories(s.MaxCategories, s.CapStrategy))
+	}
+	if s.MatchTracking != nil {
+		opts = append(opts, WithMatchTracking(*s.MatchTracking))
+	}
+	if s.CommitmentRate != 0 {
+		opts = append(opts, WithCommitmentRate(s.CommitmentRate))
+	}
+	if s.Decay != nil {
+		opts = append(opts, WithDecay(s.Decay.HalfLife, s.Decay.MinUsage))
+	}
+	opts = append(opts, WithTieBreak(s.TieBreak), WithSeed(s.Seed), WithUsagePrior(s.PriorStrength))
+	if err := f.init(s.M, s.Rho, s.Alpha, s.Beta, opts...); err != nil {
+		return err
+	}
+
+	if s.Categories != nil && len(s.Categories) != len(s.W) {
+		return fmt.Errorf("expected the state of %d categories, got %d", len(s.W), len(s.Categories))
+	}
+	for j, w := range s.W {
+		if len(w) != 2*s.M {
+			return fmt.Errorf("category %d weights must be %d, got %d", j, 2*s.M, len(w))
+		}
+		f.appendNewCategory(valuesOf[T](w))
+		if s.Categories != nil {
+			c := &f.categories[j]
+			c.count, c.lastUsed, c.label = s.Categories[j].Count, s.Categories[j].LastUsed, s.Categories[j].Label
+			c.created = s.Categories[j].Created
+			c.usage, c.usageAt = float64(c.count), f.age
+			if rho := s.Categories[j].Rho; rho != 0 {
+				if err := f.SetCategoryVigilance(j, rho); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return f.restoreIDs(s.IDs, s.LastID)
+}
+
+// Save writes the model hyperparameters, weights and category state in a versioned binary format.
+// The attached metadata store, if any, is not saved.
+func (f *FuzzyARTOf[T]) Save(w io.Writer) error {
+	state := f.state()
+
+	bw := bufio.NewWriter(w)
+	bw.Write(modelMagic[:])
+	bw.Write(binary.LittleEndian.AppendUint32(nil, modelVersion))
+
+	writeSection := func(tag uint32, s *sectionWriter) {
+		bw.Write(binary.LittleEndian.AppendUint32(nil, tag))
+		bw.Write(binary.LittleEndian.AppendUint64(nil, uint64(s.Len())))
+		bw.Write(s.Bytes())
+	}
+
+	var s sectionWriter
+	s.float64(state.Rho)
+	s.float64(state.Alpha)
+	s.float64(state.Beta)
+	s.uint64(uint64(state.M))
+	writeSection(sectionParams, &s)
+
+	s.Reset()
+	s.uint64(uint64(len(state.W)))
+	for _, w := range state.W {
+		for _, v := range w {
+			s.float64(v)
+		}
+	}
+	writeSection(sectionWeights, &s)
+
+	s.Reset()
+	for _, c := range state.Categories {
+		s.uint64(uint64(c.Count))
+		s.uint64(uint64(c.LastUsed.UnixNano()))
+		s.string(c.Label)
+	}
+	writeSection(sectionCategories, &s)
+
+	s.Reset()
+	for _, c := range state.Categories {
+		var ns int64
+		if !c.Created.IsZero() {
+			ns = c.Created.UnixNano()
+		}
+		s.uint64(uint64(ns))
+	}
+	writeSection(sectionCategoryCreated, &s)
+
+	if slices.ContainsFunc(state.Categories, func(c categoryState) bool { return c.Rho != 0 }) {
+		s.Reset()
+		for _, c := range state.Categories {
+			s.float64(c.Rho)
+		}
+		writeSection(sectionCategoryVigilance, &s)
+	}
+
+	if state.FeatureNames != nil {
+		s.Reset()
+		s.uint64(uint64(len(state.FeatureNames)))
+		for _, name := range state.FeatureNames {
+			s.string(name)
+		}
+		writeSection(sectionFeatureNames, &s)
+	}
+
+	if state.Signature != nil {
+		s.Reset()
+		s.uint64(uint64(state.Signature.InputLen))
+		s.string(state.Signature.Pipeline)
+		writeSection(sectionSignature, &s)
+	}
+
+	if state.MaxCategories > 0 {
+		s.Reset()
+		s.uint64(uint64(state.MaxCategories))
+		s.uint64(uint64(state.CapStrategy))
+		writeSection(sectionCap, &s)
+	}
+
+	if state.TieBreak != TieBreakOldest || state.Seed != 0 {
+		s.Reset()
+		s.uint64(uint64(state.TieBreak))
+		s.uint64(state.Seed)
+		writeSection(sectionTieBreak, &s)
+	}
+
+	if state.PriorStrength != 0 {
+		s.Reset()
+		s.float64(state.PriorStrength)
+		writeSection(sectionUsagePrior, &s)
+	}
+
+	if state.MatchTracking != nil {
+		s.Reset()
+		s.float64(*state.MatchTracking)
+		writeSection(sectionMatchTracking, &s)
+	}
+
+	if state.CommitmentRate != 0 {
+		s.Reset()
+		s.float64(state.CommitmentRate)
+		writeSection(sectionCommitmentRate, &s)
+	}
+
+	if state.Decay != nil {
+		s.Reset()
+		s.uint64(uint64(state.Decay.HalfLife))
+		s.float64(state.Decay.MinUsage)
+		writeSection(sectionDecay, &s)
+	}
+
+	s.Reset()
+	s.uint64(state.LastID)
+	for _, id := range state.IDs {
+		s.uint64(id)
+	}
+	writeSection(sectionCategoryIDs, &s)
+
+	bw.Write(binary.LittleEndian.AppendUint32(nil, sectionEnd))
+	return bw.Flush()
+}
+
+// LoadFuzzyART reads a model written by Save.
+func LoadFuzzyART(r io.Reader) (*FuzzyART, error) {
+	return LoadFuzzyARTOf[float64](r)
+}
+
+// LoadFuzzyARTOf works like LoadFuzzyART for a model with weights of type T:
+// the saved weights are float64, e.g. LoadFuzzyARTOf[float32] rounds them to serve a large model in half the memory.
+func LoadFuzzyARTOf[T Float](r io.Reader) (*FuzzyARTOf[T], error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("reading model header: %w", err)
+	}
+	if [4]byte(header[:4]) != modelMagic {
+		return nil, fmt.Errorf("not a FuzzyART model, magic %q", header[:4])
+	}
+	if v := binary.LittleEndian.Uint32(header[4:]); v != modelVersion {
+		return nil, fmt.Errorf("unsupported model version %d", v)
+	}
+
+	sections := make(map[uint32][]byte)
+	for {
+		tagBuf := make([]byte, 4)
+		if _, err := io.ReadFull(br, tagBuf); err != nil {
+			return nil, fmt.Errorf("reading section tag: %w", err)
+		}
+		tag := binary.LittleEndian.Uint32(tagBuf)
+		if tag == sectionEnd {
+			break
+		}
+		lenBuf := make([]byte, 8)
+		if _, err := io.ReadFull(br, lenBuf); err != nil {
+			return nil, fmt.Errorf("reading section %d length: %w", tag, err)
+		}
+		n := binary.LittleEndian.Uint64(lenBuf)
+		if n > maxSectionLen {
+			return nil, fmt.Errorf("section %d length %d exceeds the maximum %d", tag, n, maxSectionLen)
+		}
+		// read through a LimitReader, so that the payload buffer grows
+		// with the actual input instead of the length in the header
+		payload, err := io.ReadAll(io.LimitReader(br, int64(n)))
+		if err != nil {
+			return nil, fmt.Errorf("reading section %d: %w", tag, err)
+		}
+		if uint64(len(payload)) != n {
+			return nil, fmt.Errorf("reading section %d: %w", tag, io.ErrUnexpectedEOF)
+		}
+		sections[tag] = payload
+	}
+
+	state, err := decodeSections(sections)
+	if err != nil {
+		return nil, err
+	}
+	f := new(FuzzyARTOf[T])
+	if err := f.restoreState(state); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// decodeSections decodes the model state from its sections payloads.
+func decodeSections(sections map[uint32][]byte) (*persistedState, error) {
+	params, ok := sections[sectionParams]
+	if !ok {
+		return nil, errors.New("model has no parameters section")
+	}
+	state := new(persistedState)
+	s := &sectionReader{data: params}
+	state.Rho, state.Alpha, state.Beta = s.float64(), s.float64(), s.float64()
+	m := s.uint64()
+	if s.err == nil && (m == 0 || m > maxInputLen) {
+		s.err = fmt.Errorf("input length %d out of range [1, %d]", m, maxInputLen)
+	}
+	if s.err != nil {
+		return nil, fmt.Errorf("reading parameters: %w", s.err)
+	}
+	state.M = int(m)
+
+	s = &sectionReader{data: sections[sectionWeights]}
+	n := s.uint64()
+	// n is checked against the payload before multiplying, to avoid overflows
+	rowLen := uint64(2*state.M) * 8
+	if s.err == nil && (n > uint64(len(s.data))/rowLen || uint64(len(s.data)) != n*rowLen) {
+		s.err = fmt.Errorf("expected %d categories of %d weights", n, 2*state.M)
+	}
+	for range n {
+		if s.err != nil {
+			break
+		}
+		w := make([]float64, 2*state.M)
+		for i := range w {
+			w[i] = s.float64()
+		}
+		state.W = append(state.W, w)
+	}
+	if s.err != nil {
+		return nil, fmt.Errorf("reading weights: %w", s.err)
+	}
+
+	if data, ok := sections[sectionCategories]; ok {
+		s = &sectionReader{data: data}
+		state.Categories = make([]categoryState, len(state.W))
+		for j := range state.Categories {
+			c := &state.Categories[j]
+			c.Count = int(s.uint64())
+			c.LastUsed = time.Unix(0, int64(s.uint64()))
+			c.Label = s.string()
+		}
+		if s.err != nil {
+			return nil, fmt.Errorf("reading categories: %w", s.err)
+		}
+
+		if data, ok := sections[sectionCategoryCreated]; ok {
+			s = &sectionReader{data: data}
+			for j := range state.Categories {
+				if ns := int64(s.uint64()); ns != 0 {
+					state.Categories[j].Created = time.Unix(0, ns)
+				}
+			}
+			if s.err != nil {
+				return nil, fmt.Errorf("reading category creation times: %w", s.err)
+			}
+		}
+
+		if data, ok := sections[sectionCategoryVigilance]; ok {
+			s = &sectionReader{data: data}
+			for j := range state.Categories {
+				state.Categories[j].Rho = s.float64()
+			}
+			if s.err != nil {
+				return nil, fmt.Errorf("reading category vigilance: %w", s.err)
+			}
+		}
+	}
+
+	if data, ok := sections[sectionFeatureNames]; ok {
+		s = &sectionReader{data: data}
+		n := s.uint64()
+		// every name takes at least its 8 bytes length
+		if n > uint64(len(s.data))/8 {
+			return nil, fmt.Errorf("reading feature names: %d names in %d bytes: %w", n, len(s.data), io.ErrUnexpectedEOF)
+		}
+		state.FeatureNames = make([]string, n)
+		for i := range state.FeatureNames {
+			state.FeatureNames[i] = s.string()
+		}
+		if s.err != nil {
+			return nil, fmt.Errorf("reading feature names: %w", s.err)
+		}
+	}
+
+	if data, ok := sections[sectionSignature]; ok {
+		s = &sectionReader{data: data}
+		state.Signature = &InputSignature{InputLen: int(s.uint64()), Pipeline: s.string()}
+		if s.err != nil {
+			return nil, fmt.Errorf("reading input signature: %w", s.err)
+		}
+	}
+
+	if data, ok := sections[sectionCap]; ok {
+		s = &sectionReader{data: data}
+		state.MaxCategories, state.CapStrategy = int(s.uint64()), CapStrategy(s.uint64())
+		if s.err != nil {
+			return nil, fmt.Errorf("reading category cap: %w", s.err)
+		}
+	}
+
+	if data, ok := sections[sectionTieBreak]; ok {
+		s = &sectionReader{data: data}
+		state.TieBreak, state.Seed = TieBreak(s.uint64()), s.uint64()
+		if s.err != nil {
+			return nil, fmt.Errorf("reading tie-break policy: %w", s.err)
+		}
+	}
+
+	if data, ok := sections[sectionUsagePrior]; ok {
+		s = &sectionReader{data: data}
+		state.PriorStrength = s.float64()
+		if s.err != nil {
+			return nil, fmt.Errorf("reading usage prior: %w", s.err)
+		}
+	}
+
+	if data, ok := sections[sectionMatchTracking]; ok {
+		s = &sectionReader{data: data}
+		epsilon := s.float64()
+		if s.err != nil {
+			return nil, fmt.Errorf("reading match tracking: %w", s.err)
+		}
+		state.MatchTracking = &epsilon
+	}
+
+	if data, ok := sections[sectionCommitmentRate]; ok {
+		s = &sectionReader{data: data}
+		state.CommitmentRate = s.float64()
+		if s.err != nil {
+			return nil, fmt.Errorf("reading commitment rate: %w", s.err)
+		}
+	}
+
+	if data, ok := sections[sectionDecay]; ok {
+		s = &sectionReader{data: data}
+		state.Decay = &decayState{HalfLife: int(s.uint64()), MinUsage: s.float64()}
+		if s.err != nil {
+			return nil, fmt.Errorf("reading decay: %w", s.err)
+		}
+	}
+
+	if data, ok := sections[sectionCategoryIDs]; ok {
+		s = &sectionReader{data: data}
+		state.LastID = s.uint64()
+		state.IDs = make([]uint64, len(state.W))
+		for j := range state.IDs {
+			state.IDs[j] = s.uint64()
+		}
+		if s.err != nil {
+			return nil, fmt.Errorf("reading category IDs: %w", s.err)
+		}
+	}
+
+	return state, nil
+}
+
+// MarshalJSON encodes the model hyperparameters, weights and category state,
+// for interoperability with non-Go tooling and human-inspectable checkpoints.
+func (f *FuzzyARTOf[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.state())
+}
+
+// UnmarshalJSON decodes a model encoded by MarshalJSON, replacing the content of f.
+// f should be a new zero value (or a model no longer in use).
+func (f *FuzzyARTOf[T]) UnmarshalJSON(data []byte) error {
+	state := new(persistedState)
+	if err := json.Unmarshal(data, state); err != nil {
+		return err
+	}
+	return f.restoreState(state)
+}
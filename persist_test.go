@@ -0,0 +1,153 @@
+package art
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 0.5,
+		WithFeatureNames([]string{"a", "b", "c", "d"}),
+		WithInputSignature(NewInputSignature(4, "minmax")),
+		WithMaxCategories(10, CapMerge))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, v := range []float64{0.1, 0.15, 0.9} {
+		model.Fit([]float64{v, v, v, v})
+	}
+	if err := model.ImportClusters([][]float64{{0.5, 0.5, 0.5, 0.5}}, []int{7}, []string{"imported"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := model.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFuzzyART(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+
+	if loaded.rho != model.rho || loaded.alpha != model.alpha || loaded.beta != model.beta || loaded.M != model.M {
+		t.Errorf("hyperparameters differ")
+	}
+	if len(loaded.w) != len(model.w) {
+		t.Fatalf("expected %d categories, got %d", len(model.w), len(loaded.w))
+	}
+	for j := range model.w {
+		if !slices.Equal(loaded.w[j], model.w[j]) {
+			t.Errorf("category %d weights differ", j)
+		}
+		if loaded.Count(j) != model.Count(j) || loaded.Label(j) != model.Label(j) ||
+			!loaded.categories[j].lastUsed.Equal(model.categories[j].lastUsed) {
+			t.Errorf("category %d state differs", j)
+		}
+	}
+	if !slices.Equal(loaded.FeatureNames(), model.FeatureNames()) || loaded.InputSignature() != model.InputSignature() ||
+		loaded.maxCategories != 10 || loaded.capStrategy != CapMerge {
+		t.Errorf("options differ")
+	}
+
+	a := []float64{0.12, 0.12, 0.12, 0.12}
+	r1, j1 := model.Predict(a, false)
+	r2, j2 := loaded.Predict(a, false)
+	if r1 != r2 || j1 != j2 {
+		t.Errorf("predictions differ: %d (%f) != %d (%f)", j1, r1, j2, r2)
+	}
+
+	if _, err := LoadFuzzyART(bytes.NewReader([]byte("nope"))); err == nil {
+		t.Error("invalid data should be rejected")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 0.5, WithFeatureNames([]string{"a", "b", "c", "d"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, v := range []float64{0.1, 0.15, 0.9} {
+		model.Fit([]float64{v, v, v, v})
+	}
+
+	data, err := json.Marshal(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := new(FuzzyART)
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+
+	if len(loaded.w) != len(model.w) || loaded.rho != model.rho || !slices.Equal(loaded.FeatureNames(), model.FeatureNames()) {
+		t.Fatalf("decoded model differs: %s", data)
+	}
+	for j := range model.w {
+		if !slices.Equal(loaded.w[j], model.w[j]) || loaded.Count(j) != model.Count(j) {
+			t.Errorf("category %d differs", j)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"rho": 2, "alpha": 0.01, "beta": 1, "m": 4}`), new(FuzzyART)); err == nil {
+		t.Error("invalid hyperparameters should be rejected")
+	}
+}
+
+func TestLoadCorrupt(t *testing.T) {
+	type section struct {
+		tag uint32
+		// length overrides the payload length in the header if not zero
+		length  uint64
+		payload []uint64
+	}
+	encode := func(sections ...section) []byte {
+		buf := append(modelMagic[:], binary.LittleEndian.AppendUint32(nil, modelVersion)...)
+		for _, s := range sections {
+			var w sectionWriter
+			for _, v := range s.payload {
+				w.uint64(v)
+			}
+			length := uint64(w.Len())
+			if s.length != 0 {
+				length = s.length
+			}
+			buf = binary.LittleEndian.AppendUint32(buf, s.tag)
+			buf = binary.LittleEndian.AppendUint64(buf, length)
+			buf = append(buf, w.Bytes()...)
+		}
+		return binary.LittleEndian.AppendUint32(buf, sectionEnd)
+	}
+	params := func(m uint64) section {
+		return section{tag: sectionParams, payload: []uint64{
+			math.Float64bits(0.8), math.Float64bits(0.01), math.Float64bits(1), m}}
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"truncated header", modelMagic[:]},
+		{"huge section length", encode(section{tag: sectionParams, length: 1 << 62})},
+		{"section longer than the input", encode(section{tag: sectionParams, length: 1 << 20})},
+		{"zero input length", encode(params(0))},
+		{"huge input length", encode(params(1 << 40))},
+		{"huge category count", encode(params(1), section{tag: sectionWeights, payload: []uint64{1 << 60}})},
+		{"missing weights", encode(params(1), section{tag: sectionWeights, payload: []uint64{2, 0, 0}})},
+		{"huge feature names count", encode(params(1), section{tag: sectionFeatureNames, payload: []uint64{1 << 60}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadFuzzyART(bytes.NewReader(tt.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package art
+
+import (
+	"context"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// predictBatchSize is the number of samples predicted by each goroutine of PredictBatch.
+const predictBatchSize = 64
+
+// Prediction is the result of Predict without learning, for a sample of PredictBatch.
+type Prediction struct {
+	Activation float64
+	Category   int
+}
+
+// PredictBatch works like Predict without learning for every sample,
+// predicting them in parallel across samples rather than across the categories of a single sample.
+// The model must not be modified by another goroutine meanwhile.
+// If the model has no categories yet, every category index is -1.
+func (f *FuzzyARTOf[T]) PredictBatch(samples [][]float64) []Prediction {
+	predictions, _ := f.PredictBatchCtx(context.Background(), samples)
+	return predictions
+}
+
+// PredictBatchCtx works like PredictBatch, but stops predicting once ctx is done, returning the context error.
+// The samples that were not predicted have category index -1.
+func (f *FuzzyARTOf[T]) PredictBatchCtx(ctx context.Context, samples [][]float64) ([]Prediction, error) {
+	predictions := make([]Prediction, len(samples))
+	for i := range predictions {
+		predictions[i].Category = -1
+	}
+	if len(f.w) == 0 {
+		return predictions, nil
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, runtime.NumCPU())
+	for start := 0; start < len(samples); start += predictBatchSize {
+		if ctx.Err() != nil {
+			break
+		}
+		end := min(start+predictBatchSize, len(samples))
+		// every batch has its own source of tie keys, drawn in order to be reproducible with WithSeed
+		var rng *rand.Rand
+		if f.tieBreak == TieBreakRandom {
+			rng = rand.New(rand.NewPCG(f.random().Uint64(), f.random().Uint64()))
+		}
+
+		wg.Add(1)
+		workers <- struct{}{}
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			fi, fiNorms := f.newVector(), make([]T, len(f.w))
+			for i := start; i < end; i++ {
+				if ctx.Err() != nil {
+					return
+				}
+				predictions[i] = f.predictOne(samples[i], fi, fiNorms, rng)
+			}
+		}()
+	}
+	wg.Wait()
+	return predictions, ctx.Err()
+}
+
+// predictOne returns the winning category of a, without touching the shared activation list.
+// fi and fiNorms are the buffers of allInputNorms, complement coding is fused in the kernels without an Encoder.
+func (f *FuzzyARTOf[T]) predictOne(a []float64, fi, fiNorms []T, rng *rand.Rand) Prediction {
+	if f.latency != nil {
+		defer f.latency.predict.observe(time.Now())
+	}
+	x, A := valuesOf[T](a), f.encodedInput(a)
+	f.allInputNorms(x, A, fiNorms, fi)
+	var best, t fuzzyActivation[T]
+	for j, fiNorm := range fiNorms[:len(f.w)] {
+		t.j = j
+		t.fiNorm, t.wNorm = float64(fiNorm), f.categories[j].wNorm
+		t.activation = t.fiNorm / (f.alpha + t.wNorm) * f.usagePrior(j)
+		if rng != nil {
+			t.tieKey = rng.Uint64()
+		}
+		// categories are visited in index order, remaining ties go to the oldest one
+		if j == 0 || f.beats(&t, &best) {
+			best = t
+		}
+	}
+	return Prediction{
+		Activation: f.normalizedActivation(best.fiNorm, f.sampleNorm(x, A)),
+		Category:   best.j,
+	}
+}
@@ -0,0 +1,71 @@
+package art
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPredictBatch(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	samples := make([][]float64, 300)
+	for i := range samples {
+		// quantized values produce ties
+		samples[i] = []float64{float64(rng.IntN(4)) / 4, float64(rng.IntN(4)) / 4, rng.Float64(), rng.Float64()}
+	}
+
+	for _, policy := range []TieBreak{TieBreakOldest, TieBreakLargest, TieBreakSmallestNorm} {
+		model, err := NewFuzzyART(4, 0.8, 0.01, 0.5, WithTieBreak(policy), WithUsagePrior(0.05))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := model.PredictBatch(samples[:2]); got[0].Category != -1 || got[1].Category != -1 {
+			t.Errorf("expected no category from an empty model, got %v", got)
+		}
+		for _, a := range samples[:200] {
+			model.Fit(a)
+		}
+
+		predictions := model.PredictBatch(samples)
+		if len(predictions) != len(samples) {
+			t.Fatalf("expected %d predictions, got %d", len(samples), len(predictions))
+		}
+		for i, a := range samples {
+			activation, j := model.Predict(a, false)
+			if predictions[i] != (Prediction{Activation: activation, Category: j}) {
+				t.Errorf("%s: sample %d predicted %+v, Predict returns %d (%f)", policy, i, predictions[i], j, activation)
+			}
+		}
+		model.Close()
+	}
+}
+
+func TestPredictBatchRandomTieBreakSeed(t *testing.T) {
+	predict := func() []Prediction {
+		model, err := NewFuzzyART(4, 0.5, 0.01, 1, WithTieBreak(TieBreakRandom), WithSeed(9))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer model.Close()
+		// equal categories always tie
+		for range 3 {
+			model.appendNewCategory([]float64{0.2, 0.2, 0.2, 0.2, 0.5, 0.5, 0.5, 0.5})
+		}
+		samples := make([][]float64, 200)
+		for i := range samples {
+			samples[i] = []float64{0.3, 0.3, 0.3, 0.3}
+		}
+		return model.PredictBatch(samples)
+	}
+
+	first, second := predict(), predict()
+	seen := make(map[int]bool)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("sample %d: expected the same prediction with the same seed, got %+v and %+v", i, first[i], second[i])
+		}
+		seen[first[i].Category] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected ties broken across all 3 categories, got %v", seen)
+	}
+}
@@ -0,0 +1,76 @@
+package art
+
+import (
+	"slices"
+	"time"
+)
+
+// activationBound returns an upper bound of the choice function of category j:
+// since |A∧w| <= min(|A|, |w|) and |w| <= |A| = M for complement-coded weights,
+// T = |A∧w| / (alpha + |w|) <= |w| / (alpha + |w|), which only depends on the category.
+func (f *FuzzyARTOf[T]) activationBound(j int) float64 {
+	wNorm := f.categories[j].wNorm
+	return wNorm / (f.alpha + wNorm) * f.usagePrior(j)
+}
+
+// coarseIndex returns the category indexes sorted by activationBound, the highest first,
+// older categories first in case of equal bounds.
+func (f *FuzzyARTOf[T]) coarseIndex() []int {
+	if !f.coarseDirty && len(f.coarse) == len(f.w) {
+		return f.coarse
+	}
+
+	f.coarse = f.coarse[:0]
+	for j := range f.w {
+		f.coarse = append(f.coarse, j)
+	}
+	slices.SortStableFunc(f.coarse, func(i, j int) int {
+		bi, bj := f.activationBound(i), f.activationBound(j)
+		if bi > bj {
+			return -1
+		}
+		if bi < bj {
+			return 1
+		}
+		return 0
+	})
+	f.coarseDirty = false
+	return f.coarse
+}
+
+// PredictWithin works like Predict without learning, but returns within the given time budget.
+// Categories are activated in the coarse index order, the most promising first,
+// and the search stops as soon as no remaining category can beat the best one found.
+// When the budget expires first, the best category found so far is returned flagged as approximate.
+// At least one category is always activated, so the budget can be slightly exceeded.
+// If the model has no categories yet, the category index is -1.
+func (f *FuzzyARTOf[T]) PredictWithin(a []float64, budget time.Duration) (categoryActivation float64, categoryIndex int, approximate bool) {
+	deadline := time.Now().Add(budget)
+	if len(f.w) == 0 {
+		return 0, -1, false
+	}
+
+	A := f.complementCode(a)
+	aNorm := f.sampleNorm(valuesOf[T](a), A)
+	fi := make([]T, len(A))
+
+	categoryIndex = -1
+	var bestActivation, bestFiNorm float64
+	for k, j := range f.coarseIndex() {
+		if categoryIndex != -1 && f.activationBound(j) < bestActivation {
+			break
+		}
+		if k > 0 && time.Now().After(deadline) {
+			approximate = true
+			break
+		}
+
+		fiNorm := f.intersectionNorm(A, j, fi)
+		activation := fiNorm / (f.alpha + f.categories[j].wNorm) * f.usagePrior(j)
+		if categoryIndex == -1 || activation > bestActivation || (activation == bestActivation && j < categoryIndex) {
+			categoryIndex, bestActivation, bestFiNorm = j, activation, fiNorm
+		}
+	}
+
+	return f.normalizedActivation(bestFiNorm, aNorm), categoryIndex, approximate
+}
@@ -0,0 +1,36 @@
+package art
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestPredictWithin(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	sample := func() []float64 {
+		return []float64{rng.Float64(), rng.Float64(), rng.Float64(), rng.Float64()}
+	}
+	for range 500 {
+		model.Fit(sample())
+	}
+
+	for range 100 {
+		a := sample()
+		wantResonance, want := model.Predict(a, false)
+		resonance, j, approximate := model.PredictWithin(a, time.Hour)
+		if approximate || j != want || resonance != wantResonance {
+			t.Fatalf("expected exact category %d (%f), got %d (%f, approximate %t)", want, wantResonance, j, resonance, approximate)
+		}
+	}
+
+	if _, j, approximate := model.PredictWithin(sample(), 0); j == -1 || !approximate {
+		t.Errorf("expired budget should return an approximate category, got %d (approximate %t)", j, approximate)
+	}
+}
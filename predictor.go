@@ -0,0 +1,130 @@
+package art
+
+import (
+	"fmt"
+
+	"github.com/oblq/art/internal/simd"
+)
+
+// Predictor is a read-only copy of a trained model predicting as Predict without learning,
+// storing the weights as T: a Predictor[float32] halves the memory of a large model's weights,
+// e.g. to serve it, while a Predictor[float64] predicts exactly as a model using WithDeterministic.
+// float32 rounds the weights and the norms (about 7 significant digits), which can flip near ties.
+// A Predictor[float32] runs the float32 kernels of the SIMD backend, twice as wide as the float64 ones, when it has them;
+// a Predictor[float64] runs the sequential kernels of WithDeterministic. The predictions are safe for concurrent use.
+// TieBreakRandom breaks ties toward the oldest category.
+type Predictor[T Float] struct {
+	m       int
+	alpha   T
+	encoder Encoder
+	// weights holds the complement-coded weights of the categories, 2M values per category
+	weights []T
+	wNorm   []T
+	// prior is the usage prior multiplier of every category, see WithUsagePrior
+	prior    []T
+	count    []int
+	tieBreak TieBreak
+}
+
+// NewPredictor returns a copy of the categories of f with weights of type T, e.g. NewPredictor[float32](f).
+// The predictor doesn't follow the changes of the model.
+func NewPredictor[T Float](f *FuzzyART) *Predictor[T] {
+	n := len(f.w)
+	p := &Predictor[T]{
+		m:        f.M,
+		alpha:    T(f.alpha),
+		encoder:  f.encoder,
+		weights:  make([]T, 0, n*2*f.M),
+		wNorm:    make([]T, n),
+		prior:    make([]T, n),
+		count:    make([]int, n),
+		tieBreak: f.tieBreak,
+	}
+	for j, w := range f.w {
+		for _, v := range w {
+			p.weights = append(p.weights, T(v))
+		}
+		p.wNorm[j] = simd.SumOf(p.weights[j*2*f.M:])
+		p.prior[j] = T(f.usagePrior(j))
+		p.count[j] = f.categories[j].count
+	}
+	return p
+}
+
+// CategoryCount returns the number of categories of the predictor.
+func (p *Predictor[T]) CategoryCount() int {
+	return len(p.wNorm)
+}
+
+// Predict works like FuzzyART.Predict without learning.
+// If the predictor has no categories, the category index is -1.
+func (p *Predictor[T]) Predict(a []float64) (categoryActivation float64, categoryIndex int) {
+	if len(p.wNorm) == 0 {
+		return 0, -1
+	}
+	A := p.code(a)
+	fiNorms := make([]T, len(p.wNorm))
+	p.intersectionNorms(A, fiNorms)
+	var bestActivation, bestFiNorm T
+	best := -1
+	for j, fiNorm := range fiNorms {
+		activation := fiNorm / (p.alpha + p.wNorm[j]) * p.prior[j]
+		if best == -1 || activation > bestActivation || (activation == bestActivation && p.breakTie(j, best) < 0) {
+			best, bestActivation, bestFiNorm = j, activation, fiNorm
+		}
+	}
+
+	aNorm := simd.SumOf(A)
+	if bestFiNorm == 0 && aNorm == 0 {
+		return 1, best
+	}
+	return float64(bestFiNorm / aNorm), best
+}
+
+// intersectionNorms sets fiNorms to the fuzzy intersection norm of A with every category,
+// with the float32 kernels for float32 weights.
+func (p *Predictor[T]) intersectionNorms(A, fiNorms []T) {
+	if _, ok := any(A).([]float32); ok {
+		simd.SharedOf[T]().FuzzyIntersectionNormBatch(A, p.weights, len(fiNorms), fiNorms)
+		return
+	}
+	simd.FuzzyIntersectionNormBatchOf(A, p.weights, len(fiNorms), fiNorms)
+}
+
+// code returns the input coded as the weights of the model, see FuzzyART.complementCode.
+func (p *Predictor[T]) code(a []float64) []T {
+	if p.encoder != nil {
+		encoded := p.encoder.Encode(a)
+		if len(encoded) != 2*p.m {
+			panic(fmt.Sprintf("encoder must return %d values, got %d", 2*p.m, len(encoded)))
+		}
+		A := make([]T, len(encoded))
+		for i, v := range encoded {
+			A[i] = T(v)
+		}
+		return A
+	}
+	A := make([]T, 2*len(a))
+	for i, v := range a {
+		A[i], A[i+len(a)] = T(v), T(1-v)
+	}
+	return A
+}
+
+// breakTie works like FuzzyART.breakTie for categories a and b, the index breaking the remaining ties.
+func (p *Predictor[T]) breakTie(a, b int) int {
+	switch p.tieBreak {
+	case TieBreakLargest:
+		if c := p.count[b] - p.count[a]; c != 0 {
+			return c
+		}
+	case TieBreakSmallestNorm:
+		if p.wNorm[a] < p.wNorm[b] {
+			return -1
+		}
+		if p.wNorm[a] > p.wNorm[b] {
+			return 1
+		}
+	}
+	return a - b
+}
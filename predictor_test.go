@@ -0,0 +1,53 @@
+package art
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPredictor(t *testing.T) {
+	rng := rand.New(rand.NewPCG(8, 9))
+	samples := make([][]float64, 400)
+	for i := range samples {
+		samples[i] = make([]float64, 12)
+		for k := range samples[i] {
+			samples[i][k] = rng.Float64()
+		}
+	}
+
+	for _, policy := range []TieBreak{TieBreakOldest, TieBreakLargest, TieBreakSmallestNorm} {
+		model, err := NewFuzzyART(12, 0.7, 0.01, 0.5, WithDeterministic(true), WithTieBreak(policy), WithUsagePrior(0.05))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, j := NewPredictor[float64](model).Predict(samples[0]); j != -1 {
+			t.Errorf("expected no category from an empty predictor, got %d", j)
+		}
+		for _, a := range samples[:300] {
+			model.Fit(a)
+		}
+
+		exact, compact := NewPredictor[float64](model), NewPredictor[float32](model)
+		if exact.CategoryCount() != model.CategoryCount() || compact.CategoryCount() != model.CategoryCount() {
+			t.Fatalf("expected %d categories, got %d and %d", model.CategoryCount(), exact.CategoryCount(), compact.CategoryCount())
+		}
+		mismatches := 0
+		for i, a := range samples {
+			activation, j := model.Predict(a, false)
+			if got, k := exact.Predict(a); got != activation || k != j {
+				t.Errorf("%s: sample %d predicted %d (%f) by the float64 predictor, %d (%f) by the model", policy, i, k, got, j, activation)
+			}
+			got, k := compact.Predict(a)
+			if k != j {
+				mismatches++
+			} else if math.Abs(got-activation) > 1e-6 {
+				t.Errorf("%s: sample %d activation %f by the float32 predictor, %f by the model", policy, i, got, activation)
+			}
+		}
+		if mismatches > len(samples)/100 {
+			t.Errorf("%s: expected the float32 predictor to match the model, got %d mismatches", policy, mismatches)
+		}
+		model.Close()
+	}
+}
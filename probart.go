@@ -0,0 +1,85 @@
+package art
+
+import (
+	"maps"
+	"slices"
+)
+
+// PROBART implements PROBART (Marriott & Harrison, 1995), a probabilistic variant of Simplified Fuzzy ARTMAP.
+// There is no match tracking: the inputs are clustered by FuzzyART and every category
+// counts how many samples of each class it learned, so that predictions
+// are class probabilities instead of a single label.
+// Noisy labels don't cause the category proliferation of match tracking, they just lower the probabilities.
+type PROBART struct {
+	fuzzy *FuzzyART
+
+	// counts stores how many samples of each class every category learned
+	counts []map[int]int
+}
+
+func NewPROBART(inputLen int, rho, alpha, beta float64) (*PROBART, error) {
+	fuzzy, err := NewFuzzyART(inputLen, rho, alpha, beta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PROBART{
+		fuzzy:  fuzzy,
+		counts: make([]map[int]int, 0),
+	}, nil
+}
+
+// Fit learns the input and counts its class label on the learning category, whose index is returned.
+func (p *PROBART) Fit(a []float64, label int) (categoryIndex int) {
+	_, categoryIndex = p.fuzzy.Fit(a)
+	if categoryIndex == len(p.counts) {
+		p.counts = append(p.counts, make(map[int]int))
+	}
+	p.counts[categoryIndex][label]++
+	return categoryIndex
+}
+
+// Probabilities returns the class probabilities of the best matching category and its index,
+// or nil, -1 if the model has no categories yet.
+func (p *PROBART) Probabilities(a []float64) (probabilities map[int]float64, categoryIndex int) {
+	if len(p.counts) == 0 {
+		return nil, -1
+	}
+	_, categoryIndex = p.fuzzy.Predict(a, false)
+
+	var total int
+	for _, n := range p.counts[categoryIndex] {
+		total += n
+	}
+	probabilities = make(map[int]float64, len(p.counts[categoryIndex]))
+	for label, n := range p.counts[categoryIndex] {
+		probabilities[label] = float64(n) / float64(total)
+	}
+	return probabilities, categoryIndex
+}
+
+// Predict returns the most probable class label, its probability and the index of the best matching category,
+// or -1, 0, -1 if the model has no categories yet. Ties are broken in favor of the lowest label.
+func (p *PROBART) Predict(a []float64) (label int, probability float64, categoryIndex int) {
+	probabilities, categoryIndex := p.Probabilities(a)
+	if categoryIndex == -1 {
+		return -1, 0, -1
+	}
+
+	label = -1
+	for _, l := range slices.Sorted(maps.Keys(probabilities)) {
+		if label == -1 || probabilities[l] > probability {
+			label, probability = l, probabilities[l]
+		}
+	}
+	return label, probability, categoryIndex
+}
+
+// CategoryCount returns the number of categories.
+func (p *PROBART) CategoryCount() int {
+	return len(p.counts)
+}
+
+func (p *PROBART) Close() {
+	p.fuzzy.Close()
+}
@@ -0,0 +1,28 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPROBART(t *testing.T) {
+	model, err := NewPROBART(4, 0.8, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	a := []float64{0.2, 0.2, 0.2, 0.2}
+	// noisy labels: 3 out of 4 samples of the same cluster are labeled 1
+	for _, label := range []int{1, 0, 1, 1} {
+		model.Fit(a, label)
+	}
+	if n := model.CategoryCount(); n != 1 {
+		t.Errorf("noisy labels should not create categories, got %d", n)
+	}
+
+	label, p, j := model.Predict(a)
+	if label != 1 || math.Abs(p-0.75) > 1e-9 || j != 0 {
+		t.Errorf("expected label 1 with probability 0.75 in category 0, got %d, %f, %d", label, p, j)
+	}
+}
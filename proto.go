@@ -0,0 +1,342 @@
+package art
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/oblq/art/internal/protowire"
+)
+
+// The Protocol Buffers messages are defined in proto/art.proto,
+// and encoded by hand so that the package doesn't depend on the protobuf runtime.
+// Any protobuf implementation can decode them with the generated code of that schema.
+// Only FuzzyART and SFAM have a message so far, MarshalProto rejects the other variants.
+
+// field numbers of the FuzzyART message
+const (
+	protoRho = iota + 1
+	protoAlpha
+	protoBeta
+	protoM
+	protoCategories
+	protoFeatureNames
+	protoSignature
+	protoMaxCategories
+	protoCapStrategy
+	protoTieBreak
+	protoSeed
+	protoPriorStrength
+	protoLastCategoryID
+	protoMatchTracking
+	protoCommitmentRate
+	protoDecay
+)
+
+// field numbers of the Category message
+const (
+	protoCategoryWeights = iota + 1
+	protoCategoryCount
+	protoCategoryLastUsed
+	protoCategoryLabel
+	protoCategoryID
+	protoCategoryCreated
+	protoCategoryRho
+)
+
+// field numbers of the InputSignature message
+const (
+	protoSignatureInputLen = iota + 1
+	protoSignaturePipeline
+)
+
+// field numbers of the MatchTracking message
+const protoMatchTrackingEpsilon = 1
+
+// field numbers of the Decay message
+const (
+	protoDecayHalfLife = iota + 1
+	protoDecayMinUsage
+)
+
+// field numbers of the SFAM message
+const (
+	protoSFAMFuzzy = iota + 1
+	protoSFAMEpsilon
+	protoSFAMLabels
+)
+
+func (s *persistedState) appendProto(b []byte) []byte {
+	b = protowire.AppendDouble(b, protoRho, s.Rho)
+	b = protowire.AppendDouble(b, protoAlpha, s.Alpha)
+	b = protowire.AppendDouble(b, protoBeta, s.Beta)
+	b = protowire.AppendVarint(b, protoM, uint64(s.M))
+	var c []byte
+	for j, w := range s.W {
+		c = protowire.AppendPackedDoubles(c[:0], protoCategoryWeights, w)
+		if s.Categories != nil {
+			cs := s.Categories[j]
+			c = protowire.AppendVarint(c, protoCategoryCount, uint64(cs.Count))
+			if !cs.LastUsed.IsZero() {
+				c = protowire.AppendVarint(c, protoCategoryLastUsed, uint64(cs.LastUsed.UnixNano()))
+			}
+			c = protowire.AppendString(c, protoCategoryLabel, cs.Label)
+			if !cs.Created.IsZero() {
+				c = protowire.AppendVarint(c, protoCategoryCreated, uint64(cs.Created.UnixNano()))
+			}
+			if cs.Rho != 0 {
+				c = protowire.AppendDouble(c, protoCategoryRho, cs.Rho)
+			}
+		}
+		if s.IDs != nil {
+			c = protowire.AppendVarint(c, protoCategoryID, s.IDs[j])
+		}
+		b = protowire.AppendBytes(b, protoCategories, c)
+	}
+	for _, name := range s.FeatureNames {
+		b = protowire.AppendBytes(b, protoFeatureNames, []byte(name))
+	}
+	if s.Signature != nil {
+		var sig []byte
+		sig = protowire.AppendVarint(sig, protoSignatureInputLen, uint64(s.Signature.InputLen))
+		sig = protowire.AppendString(sig, protoSignaturePipeline, s.Signature.Pipeline)
+		b = protowire.AppendBytes(b, protoSignature, sig)
+	}
+	b = protowire.AppendVarint(b, protoMaxCategories, uint64(s.MaxCategories))
+	b = protowire.AppendVarint(b, protoCapStrategy, uint64(s.CapStrategy))
+	b = protowire.AppendVarint(b, protoTieBreak, uint64(s.TieBreak))
+	b = protowire.AppendVarint(b, protoSeed, s.Seed)
+	b = protowire.AppendDouble(b, protoPriorStrength, s.PriorStrength)
+	b = protowire.AppendVarint(b, protoLastCategoryID, s.LastID)
+	if s.MatchTracking != nil {
+		// the message is always encoded, so that a zero epsilon is kept
+		b = protowire.AppendBytes(b, protoMatchTracking, protowire.AppendDouble(nil, protoMatchTrackingEpsilon, *s.MatchTracking))
+	}
+	b = protowire.AppendDouble(b, protoCommitmentRate, s.CommitmentRate)
+	if s.Decay != nil {
+		var decay []byte
+		decay = protowire.AppendVarint(decay, protoDecayHalfLife, uint64(s.Decay.HalfLife))
+		decay = protowire.AppendDouble(decay, protoDecayMinUsage, s.Decay.MinUsage)
+		b = protowire.AppendBytes(b, protoDecay, decay)
+	}
+	return b
+}
+
+func decodeProtoState(data []byte) (*persistedState, error) {
+	s := new(persistedState)
+	d := protowire.NewDecoder(data)
+	for num, t, ok := d.Next(); ok; num, t, ok = d.Next() {
+		switch num {
+		case protoRho:
+			s.Rho = d.Double(t)
+		case protoAlpha:
+			s.Alpha = d.Double(t)
+		case protoBeta:
+			s.Beta = d.Double(t)
+		case protoM:
+			s.M = int(d.Varint(t))
+		case protoCategories:
+			w, c, id, err := decodeProtoCategory(d.Bytes(t))
+			if err != nil {
+				return nil, fmt.Errorf("decoding category %d: %w", len(s.W), err)
+			}
+			s.W = append(s.W, w)
+			s.Categories = append(s.Categories, c)
+			s.IDs = append(s.IDs, id)
+		case protoFeatureNames:
+			s.FeatureNames = append(s.FeatureNames, d.String(t))
+		case protoSignature:
+			s.Signature = new(InputSignature)
+			sd := protowire.NewDecoder(d.Bytes(t))
+			for num, t, ok := sd.Next(); ok; num, t, ok = sd.Next() {
+				switch num {
+				case protoSignatureInputLen:
+					s.Signature.InputLen = int(sd.Varint(t))
+				case protoSignaturePipeline:
+					s.Signature.Pipeline = sd.String(t)
+				default:
+					sd.Skip(t)
+				}
+			}
+			if sd.Err != nil {
+				return nil, fmt.Errorf("decoding signature: %w", sd.Err)
+			}
+		case protoMaxCategories:
+			s.MaxCategories = int(d.Varint(t))
+		case protoCapStrategy:
+			s.CapStrategy = CapStrategy(d.Varint(t))
+		case protoTieBreak:
+			s.TieBreak = TieBreak(d.Varint(t))
+		case protoSeed:
+			s.Seed = d.Varint(t)
+		case protoPriorStrength:
+			s.PriorStrength = d.Double(t)
+		case protoLastCategoryID:
+			s.LastID = d.Varint(t)
+		case protoMatchTracking:
+			var epsilon float64
+			md := protowire.NewDecoder(d.Bytes(t))
+			for num, t, ok := md.Next(); ok; num, t, ok = md.Next() {
+				if num == protoMatchTrackingEpsilon {
+					epsilon = md.Double(t)
+				} else {
+					md.Skip(t)
+				}
+			}
+			if md.Err != nil {
+				return nil, fmt.Errorf("decoding match tracking: %w", md.Err)
+			}
+			s.MatchTracking = &epsilon
+		case protoCommitmentRate:
+			s.CommitmentRate = d.Double(t)
+		case protoDecay:
+			s.Decay = new(decayState)
+			dd := protowire.NewDecoder(d.Bytes(t))
+			for num, t, ok := dd.Next(); ok; num, t, ok = dd.Next() {
+				switch num {
+				case protoDecayHalfLife:
+					s.Decay.HalfLife = int(dd.Varint(t))
+				case protoDecayMinUsage:
+					s.Decay.MinUsage = dd.Double(t)
+				default:
+					dd.Skip(t)
+				}
+			}
+			if dd.Err != nil {
+				return nil, fmt.Errorf("decoding decay: %w", dd.Err)
+			}
+		default:
+			d.Skip(t)
+		}
+	}
+	if d.Err != nil {
+		return nil, d.Err
+	}
+	// messages encoded without IDs get new ones
+	if !slices.ContainsFunc(s.IDs, func(id uint64) bool { return id != 0 }) {
+		s.IDs = nil
+	}
+	return s, nil
+}
+
+func decodeProtoCategory(data []byte) ([]float64, categoryState, uint64, error) {
+	var w []float64
+	var c categoryState
+	var id uint64
+	d := protowire.NewDecoder(data)
+	for num, t, ok := d.Next(); ok; num, t, ok = d.Next() {
+		switch num {
+		case protoCategoryWeights:
+			w = d.Doubles(t, w)
+		case protoCategoryCount:
+			c.Count = int(d.Varint(t))
+		case protoCategoryLastUsed:
+			c.LastUsed = time.Unix(0, int64(d.Varint(t)))
+		case protoCategoryLabel:
+			c.Label = d.String(t)
+		case protoCategoryID:
+			id = d.Varint(t)
+		case protoCategoryCreated:
+			c.Created = time.Unix(0, int64(d.Varint(t)))
+		case protoCategoryRho:
+			c.Rho = d.Double(t)
+		default:
+			d.Skip(t)
+		}
+	}
+	return w, c, id, d.Err
+}
+
+// ToProto encodes the model as an art.v1.FuzzyART protobuf message, see proto/art.proto.
+// The attached metadata store, if any, is not encoded.
+func (f *FuzzyARTOf[T]) ToProto() []byte {
+	return f.state().appendProto(nil)
+}
+
+// FromProto decodes a model encoded by ToProto, fields unknown to this version are skipped.
+func FromProto(data []byte) (*FuzzyART, error) {
+	state, err := decodeProtoState(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding FuzzyART message: %w", err)
+	}
+	f := new(FuzzyART)
+	if err := f.restoreState(state); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ToProto encodes the classifier as an art.v1.SFAM protobuf message, see proto/art.proto.
+func (s *SFAM) ToProto() []byte {
+	b := protowire.AppendBytes(nil, protoSFAMFuzzy, s.fuzzy.ToProto())
+	b = protowire.AppendDouble(b, protoSFAMEpsilon, s.epsilon)
+	labels := make([]uint64, len(s.labels))
+	for j, label := range s.labels {
+		labels[j] = uint64(label)
+	}
+	return protowire.AppendPackedVarints(b, protoSFAMLabels, labels)
+}
+
+// SFAMFromProto decodes a classifier encoded by SFAM.ToProto.
+func SFAMFromProto(data []byte) (*SFAM, error) {
+	s := &SFAM{labels: make([]int, 0)}
+	var labels []uint64
+	d := protowire.NewDecoder(data)
+	for num, t, ok := d.Next(); ok; num, t, ok = d.Next() {
+		switch num {
+		case protoSFAMFuzzy:
+			fuzzy, err := FromProto(d.Bytes(t))
+			if err != nil {
+				return nil, err
+			}
+			if s.fuzzy != nil {
+				s.fuzzy.Close()
+			}
+			s.fuzzy = fuzzy
+		case protoSFAMEpsilon:
+			s.epsilon = d.Double(t)
+		case protoSFAMLabels:
+			labels = d.Varints(t, labels)
+		default:
+			d.Skip(t)
+		}
+	}
+
+	err := d.Err
+	switch {
+	case err != nil:
+	case s.fuzzy == nil:
+		err = fmt.Errorf("missing FuzzyART message")
+	case len(labels) != len(s.fuzzy.w):
+		err = fmt.Errorf("expected %d labels, got %d", len(s.fuzzy.w), len(labels))
+	case s.epsilon <= -1 || s.epsilon >= 1:
+		err = fmt.Errorf("match tracking parameter (epsilon) must be between -1 and 1, got %f", s.epsilon)
+	}
+	if err != nil {
+		if s.fuzzy != nil {
+			s.fuzzy.Close()
+		}
+		return nil, fmt.Errorf("decoding SFAM message: %w", err)
+	}
+	for _, label := range labels {
+		s.labels = append(s.labels, int(label))
+	}
+	return s, nil
+}
+
+// ErrProtoUnsupported is returned by MarshalProto for the variants without a message in proto/art.proto.
+var ErrProtoUnsupported = errors.New("no protobuf message for this model variant")
+
+// MarshalProto encodes a model or classifier with the message of its variant in proto/art.proto.
+// Only FuzzyART and SFAM are supported, the other variants return ErrProtoUnsupported.
+func MarshalProto(m any) ([]byte, error) {
+	switch m := m.(type) {
+	case *FuzzyART:
+		return m.ToProto(), nil
+	case *SFAM:
+		return m.ToProto(), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrProtoUnsupported, m)
+	}
+}
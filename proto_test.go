@@ -0,0 +1,136 @@
+package art
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/oblq/art/internal/protowire"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1,
+		WithFeatureNames([]string{"a", "b", "c", "d"}),
+		WithInputSignature(NewInputSignature(4, "scale")),
+		WithTieBreak(TieBreakLargest),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}, {0.12, 0.1, 0.1, 0.1}} {
+		model.Fit(a)
+	}
+	model.categories[1].label = "high"
+
+	data := model.ToProto()
+	// fields added by future versions must be skipped
+	data = protowire.AppendString(data, 1000, "unknown")
+
+	decoded, err := FromProto(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Close()
+
+	if !bytes.Equal(decoded.ToProto(), model.ToProto()) {
+		t.Error("decoded model encodes differently")
+	}
+	if !slices.EqualFunc(decoded.w, model.w, slices.Equal) {
+		t.Errorf("weights differ: %v != %v", decoded.w, model.w)
+	}
+	if decoded.Label(1) != "high" || decoded.Count(0) != 2 {
+		t.Errorf("category state not restored: label %q, count %d", decoded.Label(1), decoded.Count(0))
+	}
+	if decoded.InputSignature() != model.InputSignature() || decoded.tieBreak != TieBreakLargest {
+		t.Error("options not restored")
+	}
+
+	if _, err := FromProto(data[:len(data)-3]); err == nil {
+		t.Error("expected an error decoding a truncated message")
+	}
+}
+
+func TestProtoWireFormat(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.5, 0.5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.25, 0.25, 0.25, 0.25})
+
+	want := []byte{
+		0x09, 0, 0, 0, 0, 0, 0, 0xe0, 0x3f, // rho = 0.5
+		0x11, 0, 0, 0, 0, 0, 0, 0xe0, 0x3f, // alpha = 0.5
+		0x19, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, // beta = 1
+		0x20, 0x04, // m = 4
+		0x2a, // categories, length-delimited
+	}
+	// the rest depends on the clock through last_used
+	if got := model.ToProto(); !bytes.HasPrefix(got, want) {
+		t.Errorf("unexpected encoding:\n got % x\nwant % x...", got, want)
+	}
+}
+
+func TestSFAMProtoRoundTrip(t *testing.T) {
+	model, err := NewSFAM(4, 0.8, 0.01, 1, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1}, 3)
+	model.Fit([]float64{0.9, 0.9, 0.9, 0.9}, -1)
+
+	decoded, err := SFAMFromProto(model.ToProto())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Close()
+	if !slices.Equal(decoded.labels, model.labels) || decoded.epsilon != model.epsilon {
+		t.Errorf("expected labels %v and epsilon %f, got %v and %f", model.labels, model.epsilon, decoded.labels, decoded.epsilon)
+	}
+	if label, _ := decoded.Predict([]float64{0.9, 0.85, 0.9, 0.9}); label != -1 {
+		t.Errorf("expected label -1, got %d", label)
+	}
+}
+
+func TestMarshalProto(t *testing.T) {
+	fuzzy, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fuzzy.Close()
+	sfam, err := NewSFAM(4, 0.8, 0.01, 1, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sfam.Close()
+	art2a, err := NewART2A(4, 0.9, 0.1, 0.5, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer art2a.Close()
+
+	tests := []struct {
+		name  string
+		model any
+		want  []byte
+		err   error
+	}{
+		{"FuzzyART", fuzzy, fuzzy.ToProto(), nil},
+		{"SFAM", sfam, sfam.ToProto(), nil},
+		{"ART2A", art2a, nil, ErrProtoUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalProto(tt.model)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("expected % x, got % x", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package art
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/oblq/art/internal/dataset"
+	"github.com/oblq/art/internal/simd"
+)
+
+const (
+	providerMatrixData        = "testdata/mnist_train.csv"
+	providerMatrixPerDigit    = 100
+	providerMatrixMaxMismatch = 0.01
+)
+
+// TestProviderMatrix trains FuzzyART on a MNIST subset with every selectable SIMD backend
+// and checks that they assign the same categories as the deterministic kernels,
+// within providerMatrixMaxMismatch: different summation orders may flip near ties.
+// Deterministic models must assign exactly the same categories whatever the backend.
+//
+//	make get-mnist verify
+func TestProviderMatrix(t *testing.T) {
+	if _, err := os.Stat(providerMatrixData); err != nil {
+		t.Skipf("%s not found, run make get-mnist", providerMatrixData)
+	}
+	data, err := dataset.GetData(providerMatrixData, providerMatrixPerDigit, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var samples [][]float64
+	for d := range 10 {
+		samples = append(samples, data[strconv.Itoa(d)]...)
+	}
+
+	previous := SIMDBackend()
+	t.Cleanup(func() {
+		if err := UseSIMD(previous); err != nil {
+			t.Error(err)
+		}
+	})
+
+	train := func(opts ...Option) []int {
+		model, err := NewFuzzyART(28*28, 0.9, 0.01, 1, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer model.Close()
+		assignments := make([]int, len(samples))
+		for i, a := range samples {
+			_, assignments[i] = model.Fit(a)
+		}
+		return assignments
+	}
+	reference := train(WithDeterministic(true))
+
+	for _, name := range SIMDBackends() {
+		t.Run(name, func(t *testing.T) {
+			if err := UseSIMD(name); err != nil {
+				t.Fatal(err)
+			}
+			if got := train(WithDeterministic(true)); !slices.Equal(got, reference) {
+				t.Error("deterministic models must not depend on the backend")
+			}
+
+			mismatches := 0
+			for i, j := range train() {
+				if j != reference[i] {
+					mismatches++
+				}
+			}
+			t.Logf("%s (%T): %d mismatches", name, simd.Shared, mismatches)
+			if rate := float64(mismatches) / float64(len(samples)); rate > providerMatrixMaxMismatch {
+				t.Errorf("%s assigned %.2f%% of the samples differently from the deterministic kernels", name, 100*rate)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package art
+
+import "fmt"
+
+// Prune removes the categories that learned fewer than minSamples samples
+// in at least minAge learning steps since they were created, that is inputs learned by the model,
+// e.g. the noise-only categories accumulated by a long-running online training.
+// Younger categories are kept, so that they have time to grow.
+// Categories loaded or imported into the model count their age since then.
+// It returns the index remapping of the remaining categories: old index -> new index, -1 for pruned categories.
+func (f *FuzzyARTOf[T]) Prune(minSamples int, minAge int) (remap []int) {
+	pruned := make([]bool, len(f.w))
+	found := false
+	for j, c := range f.categories {
+		if c.count < minSamples && f.age-c.born >= minAge {
+			pruned[j], found = true, true
+		}
+	}
+	if !found {
+		return identityRemap(len(f.w))
+	}
+
+	// errors of the metadata store are returned by the next metadata operation
+	remap, _ = f.compactMetadata(func(j int) bool { return !pruned[j] })
+	return remap
+}
+
+// DeleteCategory removes category j, the following categories shift down by one.
+// It returns the index remapping: old index -> new index, -1 for the deleted category,
+// so that indexes stored outside the model can be kept consistent.
+func (f *FuzzyARTOf[T]) DeleteCategory(j int) (remap []int, err error) {
+	if j < 0 || j >= len(f.w) {
+		return nil, fmt.Errorf("category index must be between 0 and %d, got %d", len(f.w)-1, j)
+	}
+	return f.compactMetadata(func(k int) bool { return k != j })
+}
+
+// Reset removes all the categories, keeping the hyperparameters, the options and the worker pool,
+// e.g. between the episodes of an experiment. Category IDs are not reused, see CategoryID.
+// The attached metadata store, if any, is emptied.
+func (f *FuzzyARTOf[T]) Reset() {
+	if len(f.w) == 0 {
+		return
+	}
+	// errors of the metadata store are returned by the next metadata operation
+	f.compactMetadata(func(int) bool { return false })
+}
@@ -0,0 +1,107 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPrune(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithMetadataStore(NewMemoryStore()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	// category 0 learns 4 samples, 1 is noise, 2 learns 2 samples, 3 is recent noise
+	for _, a := range [][]float64{
+		{0.1, 0.1, 0.1, 0.1}, {0.1, 0.1, 0.1, 0.1}, {0.5, 0.9, 0.1, 0.5}, {0.1, 0.1, 0.1, 0.1},
+		{0.9, 0.9, 0.9, 0.9}, {0.9, 0.9, 0.9, 0.9}, {0.1, 0.1, 0.1, 0.1}, {0.1, 0.9, 0.1, 0.9},
+	} {
+		model.Fit(a)
+	}
+	if len(model.w) != 4 {
+		t.Fatalf("expected 4 categories, got %d", len(model.w))
+	}
+	if err := model.SetMetadata(2, []byte("kept")); err != nil {
+		t.Fatal(err)
+	}
+
+	if remap := model.Prune(1, 0); !slices.Equal(remap, []int{0, 1, 2, 3}) {
+		t.Errorf("expected no category pruned, got remap %v", remap)
+	}
+	remap := model.Prune(2, 2)
+	if !slices.Equal(remap, []int{0, -1, 1, 2}) {
+		t.Fatalf("expected only category 1 pruned, got remap %v", remap)
+	}
+	if len(model.w) != 3 || model.Count(0) != 4 || model.Count(1) != 2 || model.Count(2) != 1 {
+		t.Errorf("unexpected categories after pruning: %d", len(model.w))
+	}
+	if v, err := model.Metadata(1); err != nil || string(v) != "kept" {
+		t.Errorf("expected the metadata to follow the category, got %q (%v)", v, err)
+	}
+
+	if _, j := model.Predict([]float64{0.1, 0.9, 0.1, 0.9}, false); j != 2 {
+		t.Errorf("expected the recent category at index 2, got %d", j)
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithMetadataStore(NewMemoryStore()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.5, 0.5, 0.5, 0.5}, {0.9, 0.9, 0.9, 0.9}} {
+		model.Fit(a)
+	}
+	if err := model.SetMetadata(2, []byte("last")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := model.DeleteCategory(3); err == nil {
+		t.Error("expected an error deleting a category out of range")
+	}
+	remap, err := model.DeleteCategory(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(remap, []int{0, -1, 1}) {
+		t.Errorf("expected remap [0 -1 1], got %v", remap)
+	}
+	if len(model.w) != 2 || model.w[1][0] != 0.9 {
+		t.Errorf("unexpected categories after deleting: %v", model.w)
+	}
+	if v, err := model.Metadata(1); err != nil || string(v) != "last" {
+		t.Errorf("expected the metadata to follow the category, got %q (%v)", v, err)
+	}
+}
+
+func TestReset(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 0.5, WithMetadataStore(NewMemoryStore()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}} {
+		model.Fit(a)
+	}
+	if err := model.SetMetadata(1, []byte("gone")); err != nil {
+		t.Fatal(err)
+	}
+
+	model.Reset()
+	if len(model.w) != 0 || model.Rho() != 0.9 || model.Beta() != 0.5 {
+		t.Fatalf("expected an empty model with the same parameters, got %d categories", len(model.w))
+	}
+	if _, j := model.Predict([]float64{0.1, 0.1, 0.1, 0.1}, false); j != -1 {
+		t.Errorf("expected no category, got %d", j)
+	}
+
+	_, j := model.Fit([]float64{0.5, 0.5, 0.5, 0.5})
+	if j != 0 || model.CategoryID(0) != 3 {
+		t.Errorf("expected a new category 0 with ID 3, got %d with ID %d", j, model.CategoryID(0))
+	}
+	if v, err := model.Metadata(0); err != nil || v != nil {
+		t.Errorf("expected the metadata to be removed, got %q", v)
+	}
+}
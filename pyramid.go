@@ -0,0 +1,121 @@
+package art
+
+import "fmt"
+
+// ImagePyramid encodes a grayscale image as a multi-resolution pyramid.
+// Every level halves the resolution of the previous one by averaging 2x2 blocks,
+// e.g.: a 28x28 image with 3 levels gives 28x28, 14x14 and 7x7 images.
+// Coarser levels are less sensitive to small translations of the input,
+// so matching on all the levels at once makes the categories more robust.
+type ImagePyramid struct {
+	width  int
+	height int
+
+	// sizes of every level, [width, height]
+	sizes [][2]int
+}
+
+func NewImagePyramid(width, height, levels int) (*ImagePyramid, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("image size must be positive, got %dx%d", width, height)
+	}
+	if levels <= 0 {
+		return nil, fmt.Errorf("pyramid levels must be positive, got %d", levels)
+	}
+
+	p := &ImagePyramid{width: width, height: height}
+	w, h := width, height
+	for range levels {
+		p.sizes = append(p.sizes, [2]int{w, h})
+		if w == 1 && h == 1 {
+			break
+		}
+		w, h = (w+1)/2, (h+1)/2
+	}
+
+	return p, nil
+}
+
+// Levels returns the number of levels, it can be lower than the requested one
+// when the image can't be downsampled further.
+func (p *ImagePyramid) Levels() int {
+	return len(p.sizes)
+}
+
+// LevelLen returns the number of pixels of the given level.
+func (p *ImagePyramid) LevelLen(level int) int {
+	return p.sizes[level][0] * p.sizes[level][1]
+}
+
+// Len returns the length of the concatenated encoding.
+func (p *ImagePyramid) Len() int {
+	n := 0
+	for l := range p.sizes {
+		n += p.LevelLen(l)
+	}
+	return n
+}
+
+// downsample averages the 2x2 blocks of img (w x h),
+// blocks on odd borders average only the available pixels.
+func downsample(img []float64, w, h int) []float64 {
+	dw, dh := (w+1)/2, (h+1)/2
+	out := make([]float64, dw*dh)
+	for y := range dh {
+		for x := range dw {
+			var sum float64
+			var n int
+			for yy := 2 * y; yy < min(2*y+2, h); yy++ {
+				for xx := 2 * x; xx < min(2*x+2, w); xx++ {
+					sum += img[yy*w+xx]
+					n++
+				}
+			}
+			out[y*dw+x] = sum / float64(n)
+		}
+	}
+	return out
+}
+
+// EncodeChannels returns every level of the pyramid as a separate slice,
+// the finest level first. Levels can be used as FusionART channels.
+func (p *ImagePyramid) EncodeChannels(img []float64) [][]float64 {
+	if len(img) != p.width*p.height {
+		panic(fmt.Sprintf("image length must be %d, got %d", p.width*p.height, len(img)))
+	}
+
+	levels := make([][]float64, len(p.sizes))
+	levels[0] = img
+	for l := 1; l < len(p.sizes); l++ {
+		levels[l] = downsample(levels[l-1], p.sizes[l-1][0], p.sizes[l-1][1])
+	}
+	return levels
+}
+
+// Encode returns all the levels of the pyramid concatenated, the finest level first.
+// The result has length Len() and can be used as FuzzyART input.
+func (p *ImagePyramid) Encode(img []float64) []float64 {
+	out := make([]float64, 0, p.Len())
+	for _, level := range p.EncodeChannels(img) {
+		out = append(out, level...)
+	}
+	return out
+}
+
+// FusionChannels returns a FusionART channel for every level of the pyramid,
+// with the same vigilance, choice parameter and learning rate,
+// and equal contributions to the choice function.
+// The result of EncodeChannels can then be passed directly to FusionART.Fit.
+func (p *ImagePyramid) FusionChannels(rho, alpha, beta float64) []FusionChannel {
+	channels := make([]FusionChannel, len(p.sizes))
+	for l := range p.sizes {
+		channels[l] = FusionChannel{
+			InputLen: p.LevelLen(l),
+			Rho:      rho,
+			Alpha:    alpha,
+			Beta:     beta,
+			Gamma:    1 / float64(len(p.sizes)),
+		}
+	}
+	return channels
+}
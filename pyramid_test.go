@@ -0,0 +1,99 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestImagePyramidEncode(t *testing.T) {
+	img := make([]float64, 9)
+	for i := range img {
+		img[i] = float64(i) / 8
+	}
+	p, err := NewImagePyramid(3, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Levels() != 3 || p.Len() != 9+4+1 {
+		t.Fatalf("expected 3 levels of 14 values, got %d of %d", p.Levels(), p.Len())
+	}
+
+	// the blocks on the odd borders average the available pixels only
+	want := [][]float64{img, {0.25, 0.4375, 0.8125, 1}, {0.625}}
+	if got := p.EncodeChannels(img); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("expected levels %v, got %v", want, got)
+	}
+	if got := p.Encode(img); !slices.Equal(got, slices.Concat(want...)) {
+		t.Errorf("expected %v, got %v", slices.Concat(want...), got)
+	}
+}
+
+func TestNewImagePyramid(t *testing.T) {
+	tests := []struct {
+		width, height, levels int
+		want                  [][2]int
+		err                   bool
+	}{
+		{4, 4, 2, [][2]int{{4, 4}, {2, 2}}, false},
+		// the levels stop at a single pixel
+		{4, 4, 10, [][2]int{{4, 4}, {2, 2}, {1, 1}}, false},
+		{5, 1, 3, [][2]int{{5, 1}, {3, 1}, {2, 1}}, false},
+		{0, 4, 1, nil, true},
+		{4, 4, 0, nil, true},
+	}
+	for _, tt := range tests {
+		p, err := NewImagePyramid(tt.width, tt.height, tt.levels)
+		if (err != nil) != tt.err {
+			t.Errorf("%dx%d, %d levels: unexpected error %v", tt.width, tt.height, tt.levels, err)
+			continue
+		}
+		if err == nil && !slices.Equal(p.sizes, tt.want) {
+			t.Errorf("%dx%d, %d levels: expected sizes %v, got %v", tt.width, tt.height, tt.levels, tt.want, p.sizes)
+		}
+	}
+}
+
+func TestImagePyramidFusionART(t *testing.T) {
+	p, err := NewImagePyramid(4, 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := NewFusionART(p.FusionChannels(0.8, 0.01, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	// a bright square in the top-left corner and one in the opposite corner
+	square := func(x0, y0 int) []float64 {
+		img := make([]float64, 16)
+		for y := y0; y < y0+2; y++ {
+			for x := x0; x < x0+2; x++ {
+				img[y*4+x] = 1
+			}
+		}
+		return img
+	}
+	tests := []struct {
+		name string
+		img  []float64
+		want int
+	}{
+		{"top-left", square(0, 0), 0},
+		{"same image", square(0, 0), 0},
+		{"bottom-right", square(2, 2), 1},
+	}
+	for _, tt := range tests {
+		if _, j := model.Fit(p.EncodeChannels(tt.img)); j != tt.want {
+			t.Errorf("%s: expected category %d, got %d", tt.name, tt.want, j)
+		}
+	}
+
+	var gammas float64
+	for _, c := range model.Channels() {
+		gammas += c.Gamma
+	}
+	if gammas != 1 {
+		t.Errorf("expected the gammas to sum to 1, got %f", gammas)
+	}
+}
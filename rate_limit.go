@@ -0,0 +1,82 @@
+package art
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimitConfig configures a RateLimitedLearner, the zero value of a field disables its limit.
+type RateLimitConfig struct {
+	// Rate caps the learning to Rate updates per second on average, with bursts of up to Burst updates (token bucket).
+	Rate  float64
+	Burst int
+	// Window only learns the samples observed within Window before the current time, e.g. after a queue backlog.
+	Window time.Duration
+}
+
+// RateLimitedLearner wraps a model learning production traffic, capping the learning rate and ignoring stale samples,
+// so that bursts of duplicate traffic don't take over the model. The samples over the limits are predicted without learning.
+type RateLimitedLearner struct {
+	model  *FuzzyART
+	config RateLimitConfig
+	// tokens is the number of updates available at refill, up to Burst
+	tokens float64
+	refill time.Time
+	// now is the clock, replaced by the tests
+	now              func() time.Time
+	learned, skipped int
+}
+
+// NewRateLimitedLearner wraps model, which should then be trained through the learner.
+func NewRateLimitedLearner(model *FuzzyART, config RateLimitConfig) (*RateLimitedLearner, error) {
+	if config.Rate < 0 {
+		return nil, fmt.Errorf("rate must not be negative, got %f", config.Rate)
+	}
+	if config.Rate > 0 && config.Burst < 1 {
+		return nil, fmt.Errorf("burst must be positive with a rate, got %d", config.Burst)
+	}
+	if config.Window < 0 {
+		return nil, fmt.Errorf("window must not be negative, got %s", config.Window)
+	}
+	return &RateLimitedLearner{model: model, config: config, tokens: float64(config.Burst), now: time.Now}, nil
+}
+
+// Fit learns the sample observed at time `at` as FuzzyART.Fit, unless it is older than the window
+// or the rate is exceeded: the sample is then predicted without learning and learned is false.
+func (r *RateLimitedLearner) Fit(a []float64, at time.Time) (categoryActivation float64, categoryIndex int, learned bool) {
+	now := r.now()
+	if (r.config.Window > 0 && now.Sub(at) > r.config.Window) || !r.take(now) {
+		r.skipped++
+		categoryActivation, categoryIndex = r.model.Predict(a, false)
+		return categoryActivation, categoryIndex, false
+	}
+	r.learned++
+	categoryActivation, categoryIndex = r.model.Fit(a)
+	return categoryActivation, categoryIndex, true
+}
+
+// take consumes an update of the token bucket, reporting whether one was available.
+func (r *RateLimitedLearner) take(now time.Time) bool {
+	if r.config.Rate == 0 {
+		return true
+	}
+	if !r.refill.IsZero() {
+		r.tokens = min(r.tokens+now.Sub(r.refill).Seconds()*r.config.Rate, float64(r.config.Burst))
+	}
+	r.refill = now
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// Learned returns the number of samples learned.
+func (r *RateLimitedLearner) Learned() int {
+	return r.learned
+}
+
+// Skipped returns the number of samples predicted without learning, over the rate or out of the window.
+func (r *RateLimitedLearner) Skipped() int {
+	return r.skipped
+}
@@ -0,0 +1,43 @@
+package art
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimitedLearner(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	learner, err := NewRateLimitedLearner(model, RateLimitConfig{Rate: 2, Burst: 2, Window: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	learner.now = func() time.Time { return now }
+
+	a := []float64{0.1, 0.1, 0.1, 0.1}
+	for i, want := range []bool{true, true, false, false} {
+		if _, _, learned := learner.Fit(a, now); learned != want {
+			t.Errorf("burst sample %d: expected learned %t", i, want)
+		}
+	}
+	// 2 updates per second refill an update every half second
+	now = now.Add(500 * time.Millisecond)
+	if _, _, learned := learner.Fit(a, now); !learned {
+		t.Error("expected an update after the refill")
+	}
+	now = now.Add(time.Hour)
+	if _, j, learned := learner.Fit(a, now.Add(-2*time.Minute)); learned || j != 0 {
+		t.Errorf("expected a stale sample predicted in category 0 without learning, got %d (learned %t)", j, learned)
+	}
+	if learner.Learned() != 3 || learner.Skipped() != 3 || model.Count(0) != 3 {
+		t.Errorf("expected 3 learned and 3 skipped samples, got %d and %d (count %d)", learner.Learned(), learner.Skipped(), model.Count(0))
+	}
+
+	if _, err := NewRateLimitedLearner(model, RateLimitConfig{Rate: 1}); err == nil {
+		t.Error("expected an error with a rate and no burst")
+	}
+}
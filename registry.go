@@ -0,0 +1,173 @@
+package art
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Model is the interface shared by the unsupervised ART variants.
+type Model interface {
+	// Fit implements the complete ART learning cycle.
+	Fit(a []float64) (categoryActivation float64, categoryIndex int)
+	// Predict implements the recognition process with optional learning.
+	Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int)
+	// Close releases the resources held by the model.
+	Close()
+}
+
+// Config holds the parameters used to instantiate a registered variant.
+type Config struct {
+	// InputLen is the number of features of the input.
+	InputLen int
+	// Params holds the variant hyperparameters by name (e.g.: "rho", "alpha", "beta").
+	Params map[string]float64
+}
+
+// Param returns the named parameter, or def if it's not set.
+func (c Config) Param(name string, def float64) float64 {
+	if v, ok := c.Params[name]; ok {
+		return v
+	}
+	return def
+}
+
+// Validate returns an error if Params contains a parameter not listed in known,
+// so that a typo in a config file doesn't silently fall back to a default value.
+func (c Config) Validate(known ...string) error {
+	for name := range c.Params {
+		if !slices.Contains(known, name) {
+			return fmt.Errorf("unknown parameter %q, expected one of %v", name, known)
+		}
+	}
+	return nil
+}
+
+// Factory instantiates a Model from a Config.
+type Factory func(cfg Config) (Model, error)
+
+var registry = struct {
+	sync.RWMutex
+	factories map[string]Factory
+}{
+	factories: make(map[string]Factory),
+}
+
+// Register makes an ART variant available by name to New.
+// It's meant to be called from the init function of the package implementing the variant,
+// and panics if called twice with the same name or with a nil factory.
+func Register(name string, factory Factory) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if factory == nil {
+		panic("art: Register factory is nil")
+	}
+	if _, dup := registry.factories[name]; dup {
+		panic("art: Register called twice for variant " + name)
+	}
+	registry.factories[name] = factory
+}
+
+// New instantiates the variant registered with the given name.
+func New(name string, cfg Config) (Model, error) {
+	registry.RLock()
+	factory, ok := registry.factories[name]
+	registry.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown ART variant %q (registered: %v)", name, Variants())
+	}
+	if cfg.InputLen <= 0 {
+		return nil, fmt.Errorf("input length must be positive, got %d", cfg.InputLen)
+	}
+	return factory(cfg)
+}
+
+// Variants returns the sorted names of the registered variants.
+func Variants() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// asModel avoids returning a non-nil Model wrapping a nil pointer when the constructor fails.
+func asModel[T Model](m T, err error) (Model, error) {
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func init() {
+	Register("fuzzy", func(cfg Config) (Model, error) {
+		if err := cfg.Validate("rho", "alpha", "beta", "max_categories"); err != nil {
+			return nil, err
+		}
+		var opts []Option
+		if maxCategories := int(cfg.Param("max_categories", 0)); maxCategories > 0 {
+			opts = append(opts, WithMaxCategories(maxCategories, CapAdaptVigilance))
+		}
+		return asModel(NewFuzzyART(cfg.InputLen,
+			cfg.Param("rho", 0.86), cfg.Param("alpha", 0.01), cfg.Param("beta", 1), opts...))
+	})
+
+	Register("hypersphere", func(cfg Config) (Model, error) {
+		if err := cfg.Validate("rho", "alpha", "beta", "rbar"); err != nil {
+			return nil, err
+		}
+		return asModel(NewHypersphereART(cfg.InputLen,
+			cfg.Param("rho", 0.86), cfg.Param("alpha", 0.01), cfg.Param("beta", 1),
+			cfg.Param("rbar", math.Sqrt(float64(cfg.InputLen))/2)))
+	})
+
+	Register("ellipsoid", func(cfg Config) (Model, error) {
+		if err := cfg.Validate("rho", "alpha", "beta", "mu", "d"); err != nil {
+			return nil, err
+		}
+		return asModel(NewEllipsoidART(cfg.InputLen,
+			cfg.Param("rho", 0.86), cfg.Param("alpha", 0.01), cfg.Param("beta", 1),
+			cfg.Param("mu", 0.5), cfg.Param("d", math.Sqrt(float64(cfg.InputLen)))))
+	})
+
+	Register("topo", func(cfg Config) (Model, error) {
+		if err := cfg.Validate("rho", "alpha", "beta", "beta_sbm", "phi", "tau"); err != nil {
+			return nil, err
+		}
+		return asModel(NewTopoART(cfg.InputLen,
+			cfg.Param("rho", 0.86), cfg.Param("alpha", 0.01), cfg.Param("beta", 1),
+			cfg.Param("beta_sbm", 0.5), int(cfg.Param("phi", 3)), int(cfg.Param("tau", 100))))
+	})
+
+	Register("art2a", func(cfg Config) (Model, error) {
+		if err := cfg.Validate("rho", "alpha", "beta", "theta"); err != nil {
+			return nil, err
+		}
+		maxAlpha := 1 / math.Sqrt(float64(cfg.InputLen))
+		return asModel(NewART2A(cfg.InputLen,
+			cfg.Param("rho", 0.9), cfg.Param("alpha", maxAlpha/2), cfg.Param("beta", 0.1),
+			cfg.Param("theta", maxAlpha/2)))
+	})
+
+	Register("bayesian", func(cfg Config) (Model, error) {
+		if err := cfg.Validate("smax", "sigma0"); err != nil {
+			return nil, err
+		}
+		sigma0 := cfg.Param("sigma0", 0.1)
+		if sMax, ok := cfg.Params["smax"]; ok {
+			return asModel(NewBayesianART(cfg.InputLen, sMax, sigma0))
+		}
+		// by default categories can't grow beyond the hypervolume of a new category,
+		// sigma0^(2 * InputLen), computed in log space since it underflows for long inputs
+		logSMax := 2 * float64(cfg.InputLen) * math.Log(sigma0)
+		return asModel(newBayesianART(cfg.InputLen, logSMax, sigma0))
+	})
+}
@@ -0,0 +1,61 @@
+package art
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRegistryVariants(t *testing.T) {
+	// 16x16 images, sigma0^(2 * inputLen) underflows float64
+	const inputLen = 256
+	r := rand.New(rand.NewSource(1))
+	inputs := make([][]float64, 20)
+	for i := range inputs {
+		inputs[i] = make([]float64, inputLen)
+		for k := range inputs[i] {
+			inputs[i][k] = r.Float64()
+		}
+	}
+
+	for _, name := range Variants() {
+		t.Run(name, func(t *testing.T) {
+			model, err := New(name, Config{InputLen: inputLen})
+			if err != nil {
+				t.Fatalf("the default config is rejected: %v", err)
+			}
+			defer model.Close()
+
+			for _, a := range inputs {
+				if _, j := model.Fit(a); j < 0 {
+					t.Fatalf("expected Fit to return a category, got %d", j)
+				}
+			}
+			for _, a := range inputs {
+				if _, j := model.Predict(a, false); j < 0 {
+					t.Errorf("expected a category for a learned input, got %d", j)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant string
+		cfg     Config
+	}{
+		{"unknown variant", "nope", Config{InputLen: 4}},
+		{"zero input length", "fuzzy", Config{}},
+		{"unknown parameter", "fuzzy", Config{InputLen: 4, Params: map[string]float64{"rhoo": 0.9}}},
+		{"invalid smax", "bayesian", Config{InputLen: 4, Params: map[string]float64{"smax": 0}}},
+		{"invalid sigma0", "bayesian", Config{InputLen: 4, Params: map[string]float64{"sigma0": -1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.variant, tt.cfg); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package art
+
+import (
+	"time"
+)
+
+// PredictOrReject works like Predict without learning, but runs the search of Fit:
+// categories are tested in activation order and the first one passing the vigilance test
+// (resonance >= rho, or the vigilance of the category) wins.
+// When no category passes it, the input is rejected as unknown (open-set recognition):
+// ok is false, the category index is -1 and the activation is the best resonance found.
+// Where Fit would create a new category, PredictOrReject rejects the input.
+// If the model has no categories yet, every input is rejected.
+func (f *FuzzyARTOf[T]) PredictOrReject(a []float64) (categoryActivation float64, categoryIndex int, ok bool) {
+	if f.latency != nil {
+		defer f.latency.predict.observe(time.Now())
+	}
+	A := f.complementCode(a)
+	if len(f.w) == 0 {
+		return 0, -1, false
+	}
+	f.activateCategories(A)
+	aNorm := f.inputNorm(A)
+	for _, t := range f.t {
+		resonance := f.normalizedActivation(t.fiNorm, aNorm)
+		if resonance >= f.vigilance(t.j) {
+			return resonance, t.j, true
+		}
+		categoryActivation = max(categoryActivation, resonance)
+	}
+	return categoryActivation, -1, false
+}
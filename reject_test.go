@@ -0,0 +1,35 @@
+package art
+
+import "testing"
+
+func TestPredictOrReject(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	if _, j, ok := model.PredictOrReject([]float64{0.5, 0.5, 0.5, 0.5}); ok || j != -1 {
+		t.Errorf("expected a rejection from an empty model, got %d", j)
+	}
+
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	model.Fit([]float64{0.9, 0.9, 0.9, 0.9})
+
+	known := []float64{0.12, 0.1, 0.1, 0.1}
+	activation, j, ok := model.PredictOrReject(known)
+	if wantActivation, want := model.Predict(known, false); !ok || j != want || activation != wantActivation {
+		t.Errorf("expected category %d (%f), got %d (%f), ok %t", want, wantActivation, j, activation, ok)
+	}
+
+	unknown := []float64{0.5, 0.5, 0.5, 0.5}
+	activation, j, ok = model.PredictOrReject(unknown)
+	if ok || j != -1 {
+		t.Errorf("expected a rejection, got category %d", j)
+	}
+	if _, best := model.Predict(unknown, false); best == -1 || activation >= model.rho || activation <= 0 {
+		t.Errorf("expected the best resonance below rho, got %f", activation)
+	}
+	if len(model.w) != 2 {
+		t.Errorf("expected nothing learned, got %d categories", len(model.w))
+	}
+}
@@ -0,0 +1,55 @@
+package art
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ReplayBuffer wraps a model learning a stream, keeping a reservoir sample of the past inputs
+// and rehearsing some of them after every new input, a tool to study or prevent prototype drift
+// in continual learning: the categories keep learning the older regimes while the stream moves on.
+// The reservoir is a uniform sample of all the inputs seen (Vitter's algorithm R),
+// drawn from the random source of the model, reproducible with WithSeed.
+type ReplayBuffer struct {
+	model *FuzzyART
+	// replays is the number of stored inputs learned after every new input
+	replays int
+	samples [][]float64
+	// seen counts the inputs offered to the reservoir
+	seen int
+}
+
+// NewReplayBuffer wraps model with a reservoir of capacity inputs, rehearsing replays of them after every new input.
+func NewReplayBuffer(model *FuzzyART, capacity, replays int) (*ReplayBuffer, error) {
+	if capacity < 1 {
+		return nil, fmt.Errorf("replay buffer capacity must be positive, got %d", capacity)
+	}
+	if replays < 0 {
+		return nil, fmt.Errorf("replays must not be negative, got %d", replays)
+	}
+	return &ReplayBuffer{model: model, replays: replays, samples: make([][]float64, 0, capacity)}, nil
+}
+
+// Fit learns the input, stores it in the reservoir, then learns the rehearsed inputs.
+// It returns the category activation value and the category index of the input, before the rehearsal.
+func (r *ReplayBuffer) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	categoryActivation, categoryIndex = r.model.Fit(a)
+	rng := r.model.random()
+
+	r.seen++
+	if len(r.samples) < cap(r.samples) {
+		r.samples = append(r.samples, slices.Clone(a))
+	} else if i := rng.IntN(r.seen); i < len(r.samples) {
+		r.samples[i] = slices.Clone(a)
+	}
+
+	for range r.replays {
+		r.model.Fit(r.samples[rng.IntN(len(r.samples))])
+	}
+	return categoryActivation, categoryIndex
+}
+
+// Samples returns the inputs stored in the reservoir, in no particular order.
+func (r *ReplayBuffer) Samples() [][]float64 {
+	return r.samples
+}
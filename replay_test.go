@@ -0,0 +1,47 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReplayBuffer(t *testing.T) {
+	if _, err := NewReplayBuffer(nil, 0, 1); err == nil {
+		t.Error("expected an error with capacity 0")
+	}
+
+	fit := func() (*FuzzyART, [][]float64) {
+		model, err := NewFuzzyART(4, 0.9, 0.01, 0.5, WithSeed(4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := NewReplayBuffer(model, 10, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range 100 {
+			v := float64(i%50) / 50
+			r.Fit([]float64{v, v, 1 - v, v})
+		}
+		return model, r.Samples()
+	}
+
+	model, samples := fit()
+	defer model.Close()
+	if len(samples) != 10 {
+		t.Fatalf("expected a reservoir of 10 samples, got %d", len(samples))
+	}
+	total := 0
+	for j := range model.w {
+		total += model.Count(j)
+	}
+	if total != 300 {
+		t.Errorf("expected every input and 2 replays of it learned, got %d", total)
+	}
+
+	other, otherSamples := fit()
+	defer other.Close()
+	if !slices.EqualFunc(samples, otherSamples, slices.Equal) {
+		t.Error("expected the same reservoir with the same seed")
+	}
+}
@@ -0,0 +1,392 @@
+package art
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Replication ships the categories changed by a learning FuzzyART (the primary)
+// to read-only copies (the replicas) as incremental deltas instead of full snapshots,
+// so that horizontally scaled inference fleets stay near-real-time consistent with the learner.
+//
+// Deltas are transport agnostic: Primary.DeltasSince and Replica.Apply can be wired to any RPC system.
+// Two transports are provided: HTTP long polling using encoding/gob, by Primary.ServeHTTP and Replica.Sync,
+// and the gRPC server-streaming service of proto/replication.proto, by Primary.GRPCHandler and Replica.SyncGRPC.
+
+// CategoryDelta holds the current weights of a changed category.
+type CategoryDelta struct {
+	Index   int
+	Weights []float64
+}
+
+// Delta holds the categories changed on the primary since the previous delta.
+type Delta struct {
+	// Seq is the sequence number of the delta, deltas must be applied in order without gaps.
+	Seq uint64
+	// Full marks a full snapshot, the replica discards its categories before applying it.
+	Full bool
+	// CategoryCount is the number of categories of the primary after the delta.
+	CategoryCount int
+	// Categories holds the changed (or, in a full snapshot, all the) categories.
+	Categories []CategoryDelta
+}
+
+// ErrDeltaGap is returned by Replica.Apply when a delta is missing,
+// the replica must then be resynchronized with a full snapshot.
+var ErrDeltaGap = errors.New("art: replication delta gap")
+
+// Primary wraps a learning FuzzyART and records the categories changed by Fit.
+// When Fit removes categories (e.g. evicting or merging them), the following rows shift,
+// and the next delta is a full snapshot.
+// The wrapped model must not be used directly while wrapped.
+type Primary struct {
+	mu    sync.RWMutex
+	model *FuzzyART
+	seq   uint64
+	dirty map[int]struct{}
+	// full is set when the rows shifted since the previous delta, which is then a full snapshot
+	full bool
+
+	// journal holds the most recent deltas, so that lagging replicas don't need a full snapshot
+	journal    []Delta
+	journalLen int
+
+	// published is closed and replaced every time a delta is published
+	published chan struct{}
+}
+
+// NewPrimary wraps model, keeping the last journalLen deltas.
+func NewPrimary(model *FuzzyART, journalLen int) *Primary {
+	return &Primary{
+		model:      model,
+		dirty:      make(map[int]struct{}),
+		journalLen: max(journalLen, 1),
+		published:  make(chan struct{}),
+	}
+}
+
+// Fit learns the input and marks the learning category as changed,
+// or all of them if categories were removed.
+func (p *Primary) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	compactions := p.model.compactions
+	categoryActivation, categoryIndex = p.model.Fit(a)
+	if p.model.compactions != compactions {
+		p.full = true
+	}
+	if categoryIndex >= 0 {
+		p.dirty[categoryIndex] = struct{}{}
+	}
+	return categoryActivation, categoryIndex
+}
+
+// Predict works like FuzzyART.Predict, changes are recorded when learn is true.
+// Without learning it only takes a read lock, so predictions run in parallel, as with ConcurrentFuzzyART.
+func (p *Primary) Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int) {
+	if learn {
+		return p.Fit(a)
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	f := p.model
+	if f.tieBreak != TieBreakRandom || len(f.w) == 0 {
+		return f.Predict(a, false)
+	}
+	// the random source of the model isn't safe for concurrent use
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	pred := f.predictOne(a, f.newVector(), make([]float64, len(f.w)), rng)
+	return pred.Activation, pred.Category
+}
+
+// Publish cuts a delta from the categories changed since the previous one,
+// or a full snapshot if the rows shifted, adds it to the journal and wakes up the waiting replicas.
+// It returns false if nothing changed.
+func (p *Primary) Publish() (Delta, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.dirty) == 0 && !p.full {
+		return Delta{}, false
+	}
+
+	p.seq++
+	var d Delta
+	if p.full {
+		d = p.snapshot()
+	} else {
+		d = Delta{Seq: p.seq, CategoryCount: len(p.model.w)}
+		for j := range p.dirty {
+			d.Categories = append(d.Categories, CategoryDelta{Index: j, Weights: slices.Clone(p.model.w[j])})
+		}
+		// new categories must be appended in order on the replica
+		slices.SortFunc(d.Categories, func(a, b CategoryDelta) int { return a.Index - b.Index })
+	}
+	clear(p.dirty)
+	p.full = false
+
+	p.journal = append(p.journal, d)
+	if len(p.journal) > p.journalLen {
+		p.journal = slices.Delete(p.journal, 0, len(p.journal)-p.journalLen)
+	}
+
+	close(p.published)
+	p.published = make(chan struct{})
+	return d, true
+}
+
+// PublishEvery publishes a delta every interval until the context is cancelled.
+func (p *Primary) PublishEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Publish()
+		}
+	}
+}
+
+// Snapshot returns a full snapshot of the model.
+// Unpublished changes are included too, they are harmless since the replica
+// will receive them again with the next delta.
+func (p *Primary) Snapshot() Delta {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.snapshot()
+}
+
+// snapshot implements Snapshot, p.mu must be held.
+func (p *Primary) snapshot() Delta {
+	d := Delta{Seq: p.seq, Full: true, CategoryCount: len(p.model.w)}
+	d.Categories = make([]CategoryDelta, len(p.model.w))
+	for j, w := range p.model.w {
+		d.Categories[j] = CategoryDelta{Index: j, Weights: slices.Clone(w)}
+	}
+	return d
+}
+
+// deltasSince returns the deltas following seq, if any,
+// and the channel closed when the next delta is published.
+// A replica ahead of the primary, e.g. after the primary restarted, gets a full snapshot as after a gap.
+func (p *Primary) deltasSince(seq uint64) ([]Delta, <-chan struct{}) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	switch {
+	case seq > p.seq:
+		return []Delta{p.snapshot()}, nil
+	case seq == p.seq:
+		return nil, p.published
+	case len(p.journal) == 0 || p.journal[0].Seq > seq+1:
+		return []Delta{p.snapshot()}, nil
+	}
+	return slices.Clone(p.journal[seq+1-p.journal[0].Seq:]), nil
+}
+
+// DeltasSince returns the deltas following seq, or a full snapshot
+// if they are no longer in the journal. If there are none yet it waits up to wait for the next one,
+// returning an empty slice on timeout.
+func (p *Primary) DeltasSince(ctx context.Context, seq uint64, wait time.Duration) ([]Delta, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		deltas, published := p.deltasSince(seq)
+		if deltas != nil {
+			return deltas, nil
+		}
+
+		select {
+		case <-published:
+		case <-timer.C:
+			return []Delta{}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// ServeHTTP implements the HTTP transport: GET ?since=<seq>&wait=<duration>
+// answers with the gob-encoded []Delta returned by DeltasSince,
+// GET ?full=1 with a single full snapshot.
+func (p *Primary) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	var deltas []Delta
+	if query.Get("full") == "1" {
+		deltas = []Delta{p.Snapshot()}
+	} else {
+		seq, err := strconv.ParseUint(query.Get("since"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid since parameter", http.StatusBadRequest)
+			return
+		}
+		wait := 30 * time.Second
+		if v := query.Get("wait"); v != "" {
+			if wait, err = time.ParseDuration(v); err != nil {
+				http.Error(w, "invalid wait parameter", http.StatusBadRequest)
+				return
+			}
+		}
+		if deltas, err = p.DeltasSince(r.Context(), seq, wait); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-gob")
+	gob.NewEncoder(w).Encode(deltas)
+}
+
+// Replica wraps a read-only FuzzyART kept in sync with a Primary.
+// The wrapped model must have the same hyperparameters of the primary,
+// and must not be used directly while wrapped.
+type Replica struct {
+	mu    sync.Mutex
+	model *FuzzyART
+	seq   uint64
+}
+
+// NewReplica wraps model, which should have no categories.
+func NewReplica(model *FuzzyART) *Replica {
+	return &Replica{model: model}
+}
+
+// Seq returns the sequence number of the last applied delta.
+func (r *Replica) Seq() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seq
+}
+
+// Apply applies a delta, it returns ErrDeltaGap if the delta doesn't follow the last applied one.
+// Deltas already applied are ignored.
+func (r *Replica) Apply(d Delta) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !d.Full {
+		if d.Seq <= r.seq {
+			return nil
+		}
+		if d.Seq != r.seq+1 {
+			return fmt.Errorf("%w: expected %d, got %d", ErrDeltaGap, r.seq+1, d.Seq)
+		}
+	}
+
+	f := r.model
+	if d.Full {
+		f.compact(func(int) bool { return false })
+	}
+	for _, c := range d.Categories {
+		switch {
+		case c.Index < len(f.w):
+			f.setWeights(c.Index, c.Weights)
+		case c.Index == len(f.w):
+			f.appendNewCategory(slices.Clone(c.Weights))
+		default:
+			return fmt.Errorf("%w: category %d is missing", ErrDeltaGap, len(f.w))
+		}
+	}
+	if len(f.w) != d.CategoryCount {
+		return fmt.Errorf("%w: expected %d categories, got %d", ErrDeltaGap, d.CategoryCount, len(f.w))
+	}
+
+	r.seq = d.Seq
+	return nil
+}
+
+// Predict returns the resonance and the index of the best matching category, without learning.
+func (r *Replica) Predict(a []float64) (categoryActivation float64, categoryIndex int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.model.w) == 0 {
+		return 0, -1
+	}
+	return r.model.Predict(a, false)
+}
+
+// Sync long-polls the primary HTTP endpoint at baseURL, waiting up to wait for every request,
+// and applies the received deltas until the context is cancelled or the primary answers with an error.
+// After a gap it requests a full snapshot.
+func (r *Replica) Sync(ctx context.Context, client *http.Client, baseURL string, wait time.Duration) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+
+	resync := false
+	for ctx.Err() == nil {
+		query := url.Values{}
+		if resync {
+			query.Set("full", "1")
+		} else {
+			query.Set("since", strconv.FormatUint(r.Seq(), 10))
+			query.Set("wait", wait.String())
+		}
+		u.RawQuery = query.Encode()
+
+		deltas, err := r.fetch(ctx, client, u.String())
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+
+		resync = false
+		for _, d := range deltas {
+			if err := r.Apply(d); err != nil {
+				if !errors.Is(err, ErrDeltaGap) {
+					return err
+				}
+				resync = true
+				break
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+func (r *Replica) fetch(ctx context.Context, client *http.Client, u string) ([]Delta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("primary answered %s", resp.Status)
+	}
+	var deltas []Delta
+	if err := gob.NewDecoder(resp.Body).Decode(&deltas); err != nil {
+		return nil, err
+	}
+	return deltas, nil
+}
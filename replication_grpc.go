@@ -0,0 +1,324 @@
+package art
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/oblq/art/internal/protowire"
+)
+
+// The gRPC transport serves the art.v1.Replication service of proto/replication.proto
+// on net/http, which speaks HTTP/2, with the messages encoded by hand as in proto.go,
+// so that the module doesn't depend on the gRPC runtime.
+// Any gRPC client generated from that schema can stream the deltas of a Primary,
+// and Replica.SyncGRPC can follow any server implementing it.
+
+// replicationDeltasMethod is the path of the Replication.Deltas method.
+const replicationDeltasMethod = "/art.v1.Replication/Deltas"
+
+// maxGRPCMessageLen bounds the received messages, e.g. the full snapshots, and fits int on 32-bit targets.
+const maxGRPCMessageLen = math.MaxInt32
+
+// gRPC status codes, see https://grpc.github.io/grpc/core/md_doc_statuscodes.html
+const (
+	grpcOK                = 0
+	grpcInvalidArgument   = 3
+	grpcResourceExhausted = 8
+	grpcUnimplemented     = 12
+)
+
+// field numbers of the DeltasRequest message
+const (
+	protoDeltasRequestSince = iota + 1
+	protoDeltasRequestFull
+)
+
+// field numbers of the Delta message
+const (
+	protoDeltaSeq = iota + 1
+	protoDeltaFull
+	protoDeltaCategoryCount
+	protoDeltaCategories
+)
+
+// field numbers of the CategoryDelta message
+const (
+	protoCategoryDeltaIndex = iota + 1
+	protoCategoryDeltaWeights
+)
+
+// GRPCHandler returns the handler of the gRPC transport, streaming the deltas published by Publish
+// to the callers of Replication.Deltas until they cancel the call.
+// gRPC requires HTTP/2: serve it with TLS, or enable unencrypted HTTP/2 (h2c) in the Protocols of the server.
+func (p *Primary) GRPCHandler() http.Handler {
+	return http.HandlerFunc(p.serveGRPC)
+}
+
+func (p *Primary) serveGRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "gRPC requests only", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", "application/grpc")
+	if r.URL.Path != replicationDeltasMethod {
+		writeGRPCStatus(w.Header(), "", grpcUnimplemented, fmt.Sprintf("unknown method %s", r.URL.Path))
+		return
+	}
+	msg, err := readGRPCMessage(r.Body)
+	if err != nil {
+		writeGRPCStatus(w.Header(), "", grpcInvalidArgument, err.Error())
+		return
+	}
+	since, full, err := decodeDeltasRequest(msg)
+	if err != nil {
+		writeGRPCStatus(w.Header(), "", grpcInvalidArgument, err.Error())
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.WriteHeader(http.StatusOK)
+	if rc.Flush() != nil {
+		return
+	}
+	var buf, frame []byte
+	for {
+		var deltas []Delta
+		if full {
+			deltas, full = []Delta{p.Snapshot()}, false
+		} else {
+			var published <-chan struct{}
+			if deltas, published = p.deltasSince(since); deltas == nil {
+				select {
+				case <-published:
+					continue
+				case <-r.Context().Done():
+					return
+				}
+			}
+		}
+
+		for _, d := range deltas {
+			buf = encodeDelta(buf[:0], d)
+			if len(buf) > maxGRPCMessageLen {
+				writeGRPCStatus(w.Header(), http.TrailerPrefix, grpcResourceExhausted, fmt.Sprintf("delta %d is %d bytes, more than %d", d.Seq, len(buf), maxGRPCMessageLen))
+				return
+			}
+			frame = appendGRPCMessage(frame[:0], buf)
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+			since = d.Seq
+		}
+		if rc.Flush() != nil {
+			return
+		}
+	}
+}
+
+// SyncGRPC works like Sync over the gRPC transport: it streams the deltas from the Replication.Deltas method
+// of the server at baseURL and applies them until the context is cancelled or the call fails.
+// After a gap it calls the method again requesting a full snapshot.
+// The client must speak HTTP/2, with TLS or with unencrypted HTTP/2 (h2c) in the Protocols of its transport.
+func (r *Replica) SyncGRPC(ctx context.Context, client *http.Client, baseURL string) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	u := strings.TrimSuffix(baseURL, "/") + replicationDeltasMethod
+
+	full := false
+	for ctx.Err() == nil {
+		err := r.stream(ctx, client, u, full)
+		full = errors.Is(err, ErrDeltaGap)
+		if err != nil && !full {
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// stream calls Replication.Deltas and applies the received deltas until the stream ends.
+func (r *Replica) stream(ctx context.Context, client *http.Client, u string, full bool) error {
+	body := appendGRPCMessage(nil, encodeDeltasRequest(nil, r.Seq(), full))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("primary answered %s", resp.Status)
+	}
+	// a Trailers-Only response carries the status in the headers
+	if resp.Header.Get("Grpc-Status") != "" {
+		return grpcStatusError(resp.Header)
+	}
+	for {
+		msg, err := readGRPCMessage(resp.Body)
+		if err == io.EOF {
+			if resp.Trailer.Get("Grpc-Status") == "" {
+				return errors.New("gRPC stream ended without a status")
+			}
+			return grpcStatusError(resp.Trailer)
+		}
+		if err != nil {
+			return err
+		}
+		d, err := decodeDelta(msg)
+		if err != nil {
+			return err
+		}
+		if err := r.Apply(d); err != nil {
+			return err
+		}
+	}
+}
+
+// appendGRPCMessage appends the length-prefixed frame of the uncompressed message msg.
+func appendGRPCMessage(b, msg []byte) []byte {
+	b = append(b, 0)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(msg)))
+	return append(b, msg...)
+}
+
+// readGRPCMessage reads a length-prefixed message, it returns io.EOF at the end of the stream.
+func readGRPCMessage(r io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, errors.New("truncated gRPC message")
+		}
+		return nil, err
+	}
+	if prefix[0] != 0 {
+		return nil, errors.New("compressed gRPC messages are not supported")
+	}
+	n := binary.BigEndian.Uint32(prefix[1:])
+	if n > maxGRPCMessageLen {
+		return nil, fmt.Errorf("gRPC message of %d bytes, more than %d", n, maxGRPCMessageLen)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, errors.New("truncated gRPC message")
+	}
+	return msg, nil
+}
+
+// writeGRPCStatus sets the status of the call in h: in the headers of a Trailers-Only response
+// with an empty prefix, or in the trailers with http.TrailerPrefix once the body is written.
+func writeGRPCStatus(h http.Header, prefix string, code int, message string) {
+	h.Set(prefix+"Grpc-Status", strconv.Itoa(code))
+	h.Set(prefix+"Grpc-Message", url.PathEscape(message))
+}
+
+// grpcStatusError returns the error of the status in h, nil if it is OK.
+func grpcStatusError(h http.Header) error {
+	code, err := strconv.Atoi(h.Get("Grpc-Status"))
+	if err != nil {
+		return fmt.Errorf("invalid gRPC status %q", h.Get("Grpc-Status"))
+	}
+	if code == grpcOK {
+		return nil
+	}
+	message, err := url.PathUnescape(h.Get("Grpc-Message"))
+	if err != nil {
+		message = h.Get("Grpc-Message")
+	}
+	return fmt.Errorf("primary answered gRPC status %d: %s", code, message)
+}
+
+func encodeDeltasRequest(b []byte, since uint64, full bool) []byte {
+	b = protowire.AppendVarint(b, protoDeltasRequestSince, since)
+	if full {
+		b = protowire.AppendVarint(b, protoDeltasRequestFull, 1)
+	}
+	return b
+}
+
+func decodeDeltasRequest(data []byte) (since uint64, full bool, err error) {
+	d := protowire.NewDecoder(data)
+	for num, t, ok := d.Next(); ok; num, t, ok = d.Next() {
+		switch num {
+		case protoDeltasRequestSince:
+			since = d.Varint(t)
+		case protoDeltasRequestFull:
+			full = d.Varint(t) != 0
+		default:
+			d.Skip(t)
+		}
+	}
+	if d.Err != nil {
+		return 0, false, fmt.Errorf("decoding deltas request: %w", d.Err)
+	}
+	return since, full, nil
+}
+
+func encodeDelta(b []byte, d Delta) []byte {
+	b = protowire.AppendVarint(b, protoDeltaSeq, d.Seq)
+	if d.Full {
+		b = protowire.AppendVarint(b, protoDeltaFull, 1)
+	}
+	b = protowire.AppendVarint(b, protoDeltaCategoryCount, uint64(d.CategoryCount))
+	var c []byte
+	for _, cd := range d.Categories {
+		c = protowire.AppendVarint(c[:0], protoCategoryDeltaIndex, uint64(cd.Index))
+		c = protowire.AppendPackedDoubles(c, protoCategoryDeltaWeights, cd.Weights)
+		b = protowire.AppendBytes(b, protoDeltaCategories, c)
+	}
+	return b
+}
+
+func decodeDelta(data []byte) (Delta, error) {
+	var delta Delta
+	d := protowire.NewDecoder(data)
+	for num, t, ok := d.Next(); ok; num, t, ok = d.Next() {
+		switch num {
+		case protoDeltaSeq:
+			delta.Seq = d.Varint(t)
+		case protoDeltaFull:
+			delta.Full = d.Varint(t) != 0
+		case protoDeltaCategoryCount:
+			delta.CategoryCount = int(d.Varint(t))
+		case protoDeltaCategories:
+			var c CategoryDelta
+			cd := protowire.NewDecoder(d.Bytes(t))
+			for num, t, ok := cd.Next(); ok; num, t, ok = cd.Next() {
+				switch num {
+				case protoCategoryDeltaIndex:
+					c.Index = int(cd.Varint(t))
+				case protoCategoryDeltaWeights:
+					c.Weights = cd.Doubles(t, c.Weights)
+				default:
+					cd.Skip(t)
+				}
+			}
+			if cd.Err != nil {
+				return Delta{}, fmt.Errorf("decoding category delta %d: %w", len(delta.Categories), cd.Err)
+			}
+			delta.Categories = append(delta.Categories, c)
+		default:
+			d.Skip(t)
+		}
+	}
+	if d.Err != nil {
+		return Delta{}, fmt.Errorf("decoding delta: %w", d.Err)
+	}
+	return delta, nil
+}
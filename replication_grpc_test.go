@@ -0,0 +1,165 @@
+package art
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newGRPCServer serves h with TLS and HTTP/2, as required by gRPC.
+func newGRPCServer(h http.Handler) *httptest.Server {
+	server := httptest.NewUnstartedServer(h)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	return server
+}
+
+func TestReplicationGRPC(t *testing.T) {
+	primaryModel, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primaryModel.Close()
+	replicaModel, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replicaModel.Close()
+
+	primary := NewPrimary(primaryModel, 2)
+	replica := NewReplica(replicaModel)
+
+	server := newGRPCServer(primary.GRPCHandler())
+	defer server.Close()
+
+	// the replica starts behind the journal, and gets a full snapshot first
+	for _, v := range []float64{0.1, 0.5, 0.9} {
+		primary.Fit([]float64{v, v, v, v})
+		primary.Publish()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- replica.SyncGRPC(ctx, server.Client(), server.URL) }()
+
+	var seq uint64
+	for _, a := range [][]float64{{0.12, 0.1, 0.1, 0.1}, {0.5, 0.1, 0.9, 0.5}} {
+		primary.Fit(a)
+		d, _ := primary.Publish()
+		seq = d.Seq
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for replica.Seq() != seq {
+		if time.Now().After(deadline) {
+			t.Fatalf("replica stuck at delta %d, expected %d", replica.Seq(), seq)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("SyncGRPC should stop with the context, got %v", err)
+	}
+	if !slices.EqualFunc(replicaModel.w, primaryModel.w, slices.Equal) {
+		t.Errorf("replica weights %v differ from the primary %v", replicaModel.w, primaryModel.w)
+	}
+}
+
+func TestReplicationGRPCGap(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	primary := NewPrimary(model, 8)
+	for _, v := range []float64{0.1, 0.5} {
+		primary.Fit([]float64{v, v, v, v})
+		primary.Publish()
+	}
+
+	// the replica applied delta 1 of another primary, delta 2 doesn't follow its categories
+	replicaModel, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replicaModel.Close()
+	replica := NewReplica(replicaModel)
+	if err := replica.Apply(Delta{Seq: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	server := newGRPCServer(primary.GRPCHandler())
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- replica.SyncGRPC(ctx, server.Client(), server.URL) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for replica.Seq() != 2 || len(replicaModel.w) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("replica stuck at delta %d with %d categories", replica.Seq(), len(replicaModel.w))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+	if !slices.EqualFunc(replicaModel.w, model.w, slices.Equal) {
+		t.Errorf("replica weights %v differ from the primary %v", replicaModel.w, model.w)
+	}
+}
+
+func TestReplicationGRPCStatus(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	server := newGRPCServer(NewPrimary(model, 2).GRPCHandler())
+	defer server.Close()
+
+	for name, tc := range map[string]struct {
+		path string
+		body []byte
+		code string
+	}{
+		"unknown method":  {"/art.v1.Replication/Unknown", appendGRPCMessage(nil, nil), "12"},
+		"invalid message": {replicationDeltasMethod, appendGRPCMessage(nil, []byte{0xff}), "3"},
+		"truncated frame": {replicationDeltasMethod, []byte{0, 0, 0, 0, 9}, "3"},
+	} {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+tc.path, bytes.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/grpc")
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.ProtoMajor != 2 {
+			t.Errorf("%s: expected HTTP/2, got %s", name, resp.Proto)
+		}
+		if code := resp.Header.Get("Grpc-Status"); code != tc.code {
+			t.Errorf("%s: expected gRPC status %s, got %q", name, tc.code, code)
+		}
+	}
+}
+
+func TestDeltaProto(t *testing.T) {
+	d := Delta{Seq: 7, Full: true, CategoryCount: 2, Categories: []CategoryDelta{
+		{Index: 0, Weights: []float64{0.1, 0.2, 0.9, 0.8}},
+		{Index: 1, Weights: []float64{0, 0.5, 0.5, 1}},
+	}}
+	got, err := decodeDelta(encodeDelta(nil, d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Seq != d.Seq || got.Full != d.Full || got.CategoryCount != d.CategoryCount ||
+		!slices.EqualFunc(got.Categories, d.Categories, func(a, b CategoryDelta) bool {
+			return a.Index == b.Index && slices.Equal(a.Weights, b.Weights)
+		}) {
+		t.Errorf("expected %+v, got %+v", d, got)
+	}
+}
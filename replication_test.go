@@ -0,0 +1,225 @@
+package art
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReplication(t *testing.T) {
+	primaryModel, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primaryModel.Close()
+	replicaModel, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replicaModel.Close()
+
+	primary := NewPrimary(primaryModel, 2)
+	replica := NewReplica(replicaModel)
+
+	server := httptest.NewServer(primary)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- replica.Sync(ctx, server.Client(), server.URL, time.Second) }()
+
+	inputs := [][]float64{
+		{0.1, 0.1, 0.1, 0.1},
+		{0.9, 0.9, 0.9, 0.9},
+		{0.12, 0.1, 0.1, 0.1},
+		{0.5, 0.1, 0.9, 0.5},
+	}
+	var seq uint64
+	for _, a := range inputs {
+		primary.Fit(a)
+		d, _ := primary.Publish()
+		seq = d.Seq
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for replica.Seq() != seq {
+		if time.Now().After(deadline) {
+			t.Fatalf("replica stuck at delta %d, expected %d", replica.Seq(), seq)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Sync should stop with the context, got %v", err)
+	}
+
+	if len(replicaModel.w) != len(primaryModel.w) {
+		t.Fatalf("expected %d categories, got %d", len(primaryModel.w), len(replicaModel.w))
+	}
+	for j := range primaryModel.w {
+		if !slices.Equal(replicaModel.w[j], primaryModel.w[j]) {
+			t.Errorf("category %d differs: %v != %v", j, replicaModel.w[j], primaryModel.w[j])
+		}
+	}
+	for _, a := range inputs {
+		_, want := primary.Predict(a, false)
+		if _, got := replica.Predict(a); got != want {
+			t.Errorf("replica predicted %d, primary %d", got, want)
+		}
+	}
+}
+
+func TestReplicationJournal(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	primary := NewPrimary(model, 2)
+
+	for _, v := range []float64{0.1, 0.5, 0.9} {
+		primary.Fit([]float64{v, v, v, v})
+		primary.Publish()
+	}
+
+	deltas, err := primary.DeltasSince(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deltas) != 2 || deltas[0].Seq != 2 || deltas[0].Full {
+		t.Errorf("expected deltas 2 and 3 from the journal, got %+v", deltas)
+	}
+
+	// delta 1 was trimmed from the journal
+	deltas, err = primary.DeltasSince(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deltas) != 1 || !deltas[0].Full || deltas[0].CategoryCount != 3 {
+		t.Errorf("expected a full snapshot with 3 categories, got %+v", deltas)
+	}
+
+	replicaModel, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replicaModel.Close()
+	replica := NewReplica(replicaModel)
+	if err := replica.Apply(Delta{Seq: 2, CategoryCount: 2}); !errors.Is(err, ErrDeltaGap) {
+		t.Errorf("expected ErrDeltaGap, got %v", err)
+	}
+}
+
+func TestReplicationEviction(t *testing.T) {
+	// evicting the oldest category shifts all the following rows
+	primaryModel, err := NewFuzzyART(4, 0.95, 0.01, 1, WithEvictor(3, EvictorFunc(func(*FuzzyART) int { return 0 })))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primaryModel.Close()
+	replicaModel, err := NewFuzzyART(4, 0.95, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replicaModel.Close()
+	primary, replica := NewPrimary(primaryModel, 8), NewReplica(replicaModel)
+
+	for _, v := range []float64{0.1, 0.3, 0.5, 0.7, 0.9} {
+		primary.Fit([]float64{v, v, v, v})
+		d, _ := primary.Publish()
+		if err := replica.Apply(d); err != nil {
+			t.Fatal(err)
+		}
+		if v >= 0.7 && !d.Full {
+			t.Errorf("expected a full snapshot after an eviction, got %+v", d)
+		}
+	}
+	if !slices.EqualFunc(replicaModel.w, primaryModel.w, slices.Equal) {
+		t.Errorf("replica weights %v differ from the primary %v", replicaModel.w, primaryModel.w)
+	}
+}
+
+func TestReplicationPrimaryRestart(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	primary := NewPrimary(model, 2)
+	for _, v := range []float64{0.1, 0.5, 0.9} {
+		primary.Fit([]float64{v, v, v, v})
+		primary.Publish()
+	}
+	replicaModel, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replicaModel.Close()
+	replica := NewReplica(replicaModel)
+	if err := replica.Apply(primary.Snapshot()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the restarted primary is behind the replica
+	restartedModel, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restartedModel.Close()
+	restarted := NewPrimary(restartedModel, 2)
+	restarted.Fit([]float64{0.3, 0.3, 0.3, 0.3})
+	restarted.Publish()
+
+	server := httptest.NewServer(restarted)
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- replica.Sync(ctx, server.Client(), server.URL, 100*time.Millisecond) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for replica.Seq() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("replica stuck at delta %d, expected the restarted primary's 1", replica.Seq())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Sync should stop with the context, got %v", err)
+	}
+	if !slices.EqualFunc(replicaModel.w, restartedModel.w, slices.Equal) {
+		t.Errorf("replica weights %v differ from the restarted primary %v", replicaModel.w, restartedModel.w)
+	}
+}
+
+func TestPrimaryPredictReadLock(t *testing.T) {
+	for _, policy := range []TieBreak{TieBreakOldest, TieBreakRandom} {
+		model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithTieBreak(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer model.Close()
+		primary := NewPrimary(model, 2)
+		primary.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+
+		// a prediction without learning must not wait for the other readers
+		primary.mu.RLock()
+		done := make(chan int)
+		go func() {
+			_, j := primary.Predict([]float64{0.1, 0.1, 0.1, 0.1}, false)
+			done <- j
+		}()
+		select {
+		case j := <-done:
+			if j != 0 {
+				t.Errorf("expected category 0, got %d", j)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Predict without learning blocked on a read lock with tie break %d", policy)
+		}
+		primary.mu.RUnlock()
+	}
+}
@@ -0,0 +1,205 @@
+package art
+
+import (
+	"math"
+	"sync"
+	"unsafe"
+
+	"github.com/oblq/art/internal/simd"
+)
+
+// newRow returns the storage of the weights of a new category, allocated in slabs of batchSize rows,
+// so that the categories of a batch are usually contiguous in memory, see intersectionNorms.
+// The slabs are aligned and the rows are rowStride values apart, the padding after every row is zero,
+// so that every row starts on a vector boundary.
+// The capacity of a row extends to the end of its slab, the rows must never be appended to.
+func (f *FuzzyARTOf[T]) newRow() []T {
+	n, stride := 2*f.M, f.rowStride()
+	if len(f.slab)+stride > cap(f.slab) {
+		f.slab = simd.MakeAlignedOf[T](f.batchSize * stride)[:0]
+	}
+	start := len(f.slab)
+	f.slab = f.slab[:start+stride]
+	return f.slab[start : start+n]
+}
+
+// rowStride returns the distance between the rows of a slab, 2M padded to a multiple of the vector width.
+func (f *FuzzyARTOf[T]) rowStride() int {
+	return simd.PaddedOf[T](2 * f.M)
+}
+
+// newVector returns a zeroed, aligned and padded buffer of 2M values, e.g. for complement-coded inputs,
+// so that the batch kernel can read it with the stride of the rows, see intersectionNorms.
+func (f *FuzzyARTOf[T]) newVector() []T {
+	return simd.MakeAlignedOf[T](2 * f.M)
+}
+
+// contiguousRows returns the rows of the categories start to end as a single slice, false if they are not
+// consecutive in the same slab (or mapped file), e.g. after categories are removed or copied on write.
+// The rows are rowStride values apart in the slabs and 2M values apart in a mapped file.
+func (f *FuzzyARTOf[T]) contiguousRows(start, end int) ([]T, bool) {
+	first := f.w[start]
+	stride := len(first)
+	if f.mmap == nil {
+		stride = f.rowStride()
+	}
+	if cap(first) < (end-start)*stride {
+		return nil, false
+	}
+	base := uintptr(unsafe.Pointer(unsafe.SliceData(first)))
+	for k := start + 1; k < end; k++ {
+		if uintptr(unsafe.Pointer(unsafe.SliceData(f.w[k]))) != base+uintptr((k-start)*stride)*unsafe.Sizeof(T(0)) {
+			return nil, false
+		}
+	}
+	return first[:(end-start)*stride], true
+}
+
+// paddedInput returns A extended to stride values with its zero padding, false if its capacity
+// is shorter or the padding isn't zero, e.g. for the inputs of an Encoder.
+// The zero padding of A and of the rows doesn't change the norms: min(0, 0) = 0.
+func paddedInput[T Float](A []T, stride int) ([]T, bool) {
+	if cap(A) < stride {
+		return nil, false
+	}
+	padded := A[:stride]
+	for _, v := range padded[len(A):] {
+		if v != 0 {
+			return nil, false
+		}
+	}
+	return padded, true
+}
+
+// intersectionNorms sets fiNorms[k] to the fuzzy intersection norm of A with category start+k, for len(fiNorms) categories,
+// with a single kernel call when their rows are contiguous and A is padded like them (see newVector),
+// otherwise row by row using fi as the intersection buffer.
+// Without an Encoder A is complement-coded and the norms are computed by complementNorms from its first half,
+// like the predictions that never build A.
+func (f *FuzzyARTOf[T]) intersectionNorms(A []T, start int, fiNorms, fi []T) {
+	if f.encoder == nil {
+		f.complementNorms(A[:f.M], start, fiNorms)
+		return
+	}
+	end := start + len(fiNorms)
+	if rows, ok := f.contiguousRows(start, end); ok {
+		if padded, ok := paddedInput(A, len(rows)/len(fiNorms)); ok {
+			f.kernels().FuzzyIntersectionNormBatch(padded, rows, len(fiNorms), fiNorms)
+			return
+		}
+	}
+	for k := range fiNorms {
+		fiNorms[k] = f.kernels().FuzzyIntersection(A, f.w[start+k], fi)
+	}
+}
+
+// allIntersectionNorms works like intersectionNorms for all the categories, batch by batch, fiNorms has a norm per category.
+func (f *FuzzyARTOf[T]) allIntersectionNorms(A []T, fiNorms, fi []T) {
+	for start := 0; start < len(f.w); start += f.batchSize {
+		f.intersectionNorms(A, start, fiNorms[start:min(start+f.batchSize, len(f.w))], fi)
+	}
+}
+
+// complementNorms works like intersectionNorms for the raw input a of a model without an Encoder:
+// the kernel complement-codes a on the fly, in the same pass as the intersections, so the complement-coded input is never built.
+func (f *FuzzyARTOf[T]) complementNorms(a []T, start int, fiNorms []T) {
+	end := start + len(fiNorms)
+	if rows, ok := f.contiguousRows(start, end); ok {
+		f.kernels().ComplementIntersectionNormBatch(a, rows, len(rows)/len(fiNorms), len(fiNorms), fiNorms)
+		return
+	}
+	for k := range fiNorms {
+		f.kernels().ComplementIntersectionNormBatch(a, f.w[start+k], 2*len(a), 1, fiNorms[k:k+1])
+	}
+}
+
+// encodedInput returns the encoding of a by the Encoder of the model, or nil without one:
+// the complement coding is then fused in the kernels by inputNorms, and never built.
+func (f *FuzzyARTOf[T]) encodedInput(a []float64) []T {
+	if f.encoder == nil {
+		return nil
+	}
+	return f.mustEncode(a)
+}
+
+// inputNorms works like intersectionNorms for the input a, given its encodedInput A:
+// with complementNorms on a without an Encoder, otherwise with intersectionNorms on A.
+func (f *FuzzyARTOf[T]) inputNorms(a, A []T, start int, fiNorms, fi []T) {
+	if A == nil {
+		f.complementNorms(a, start, fiNorms)
+		return
+	}
+	f.intersectionNorms(A, start, fiNorms, fi)
+}
+
+// allInputNorms works like inputNorms for all the categories, batch by batch, fiNorms has a norm per category.
+func (f *FuzzyARTOf[T]) allInputNorms(a, A []T, fiNorms, fi []T) {
+	for start := 0; start < len(f.w); start += f.batchSize {
+		f.inputNorms(a, A, start, fiNorms[start:min(start+f.batchSize, len(f.w))], fi)
+	}
+}
+
+// intersectionNorm returns the fuzzy intersection norm of A with category j, as computed by intersectionNorms.
+func (f *FuzzyARTOf[T]) intersectionNorm(A []T, j int, fi []T) float64 {
+	var fiNorm [1]T
+	f.intersectionNorms(A, j, fiNorm[:], fi)
+	return float64(fiNorm[0])
+}
+
+// unbounded holds a read-only row of +Inf values of every weight type, see unboundedRow.
+var unbounded sync.Map
+
+// unboundedRow returns a read-only row of n +Inf values of T, grown as needed:
+// the fuzzy intersection of any input with it is the input itself.
+func unboundedRow[T Float](n int) []T {
+	var key T
+	if row, ok := unbounded.Load(key); ok && len(row.([]T)) >= n {
+		return row.([]T)[:n]
+	}
+	row := make([]T, n)
+	for i := range row {
+		row[i] = T(math.Inf(1))
+	}
+	unbounded.Store(key, row)
+	return row
+}
+
+// inputNorm returns the L1 norm of the input A, summing complement-coded inputs as complementNorm does.
+// It's meant for the learning cycle and the other users of the shared activation list, which don't run
+// concurrently: the norm is computed in a buffer of the model. Concurrent predictions use sampleNorm.
+func (f *FuzzyARTOf[T]) inputNorm(A []T) float64 {
+	if f.encoder == nil {
+		return f.complementNormIn(A[:f.M], f.norm[:])
+	}
+	return float64(f.kernels().SumFloat64(A))
+}
+
+// complementNorm returns the L1 norm of the complement coding of a without building it, as the fused kernel
+// intersection of a with an unboundedRow: the values are summed as in the intersection norms, so that
+// an input inside a category resonates with it exactly, with match 1.
+func (f *FuzzyARTOf[T]) complementNorm(a []T) float64 {
+	var norm [1]T
+	return f.complementNormIn(a, norm[:])
+}
+
+// complementNormIn works like complementNorm, computing the norm in the buffer norm of length 1.
+func (f *FuzzyARTOf[T]) complementNormIn(a, norm []T) float64 {
+	f.kernels().ComplementIntersectionNormBatch(a, unboundedRow[T](2*len(a)), 2*len(a), 1, norm)
+	return float64(norm[0])
+}
+
+// sampleNorm returns the L1 norm of the input a given its encodedInput A,
+// or its complement coding without an Encoder. It's safe for concurrent use.
+func (f *FuzzyARTOf[T]) sampleNorm(a, A []T) float64 {
+	if f.encoder == nil {
+		return f.complementNorm(a)
+	}
+	return float64(f.kernels().SumFloat64(A))
+}
+
+// learnInput updates the weights of category j toward its fuzzy intersection with A, computed in fi,
+// with learning rate beta: the activations don't keep the fuzzy intersections, see intersectionNorms.
+func (f *FuzzyARTOf[T]) learnInput(j int, A, fi []T, beta float64) {
+	f.kernels().FuzzyIntersection(A, f.w[j], fi)
+	f.learn(j, fi, beta)
+}
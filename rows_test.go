@@ -0,0 +1,172 @@
+package art
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"unsafe"
+
+	"github.com/oblq/art/internal/simd"
+)
+
+func TestContiguousRows(t *testing.T) {
+	model, err := NewFuzzyART(6, 0.95, 0.01, 1, WithDeterministic(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.batchSize = 4
+	rng := rand.New(rand.NewPCG(1, 2))
+	for model.CategoryCount() < 10 {
+		a := make([]float64, 6)
+		for i := range a {
+			a[i] = rng.Float64()
+		}
+		model.Fit(a)
+	}
+
+	for start := 0; start < 10; start += 4 {
+		if _, ok := model.contiguousRows(start, min(start+4, 10)); !ok {
+			t.Errorf("expected the rows of the batch at %d in a single slab", start)
+		}
+	}
+	if _, ok := model.contiguousRows(2, 6); ok {
+		t.Error("expected the rows of two slabs not to be contiguous")
+	}
+	if _, err := model.DeleteCategory(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := model.contiguousRows(0, 4); ok {
+		t.Error("expected the rows to be scattered after a deletion")
+	}
+
+	// the batch kernel and the scattered rows compute the same norms
+	a := []float64{0.5, 0.2, 0.8, 0.1, 0.9, 0.4}
+	A := model.complementCode(a)
+	got, want := make([]float64, 9), make([]float64, 9)
+	model.allIntersectionNorms(A, got, make([]float64, 12))
+	for j := range want {
+		want[j] = simd.Generic().FuzzyIntersection(A, model.w[j], make([]float64, 12))
+	}
+	for j := range want {
+		if got[j] != want[j] {
+			t.Errorf("category %d: expected norm %f, got %f", j, want[j], got[j])
+		}
+	}
+}
+
+func TestPaddedRows(t *testing.T) {
+	model, err := NewFuzzyART(3, 0.99, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.2, 0.3}, {0.9, 0.8, 0.7}, {0.5, 0.1, 0.9}} {
+		model.Fit(a)
+	}
+
+	// 2M = 6 values are padded to 8, every row starts on a vector boundary
+	if model.rowStride() != 8 {
+		t.Fatalf("expected a stride of 8 values, got %d", model.rowStride())
+	}
+	for j, w := range model.w {
+		if addr := uintptr(unsafe.Pointer(unsafe.SliceData(w))); addr%simd.Alignment != 0 {
+			t.Errorf("row %d: expected an aligned row, got address %#x", j, addr)
+		}
+		if padding := w[len(w):model.rowStride()]; !slices.Equal(padding, []float64{0, 0}) {
+			t.Errorf("row %d: expected zero padding, got %v", j, padding)
+		}
+	}
+	rows, ok := model.contiguousRows(0, 3)
+	if !ok || len(rows) != 3*8 {
+		t.Fatalf("expected 3 contiguous rows of 8 values, got %d (%t)", len(rows), ok)
+	}
+
+	A := model.complementCode([]float64{0.4, 0.4, 0.4})
+	if padded, ok := paddedInput(A, 8); !ok || len(padded) != 8 {
+		t.Errorf("expected the complement-coded input padded to 8 values, got %v (%t)", padded, ok)
+	}
+	if _, ok := paddedInput(A[:6:6], 8); ok {
+		t.Error("expected an input without padding capacity to be rejected")
+	}
+	dirty := append(slices.Clone(A), 1, 1)[:6]
+	if _, ok := paddedInput(dirty, 8); ok {
+		t.Error("expected an input with a nonzero padding to be rejected")
+	}
+}
+
+func TestComplementNorms(t *testing.T) {
+	model, err := NewFuzzyART(13, 0.95, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.batchSize = 4
+	rng := rand.New(rand.NewPCG(3, 4))
+	sample := func() []float64 {
+		a := make([]float64, 13)
+		for i := range a {
+			a[i] = rng.Float64()
+		}
+		return a
+	}
+	for model.CategoryCount() < 8 {
+		model.Fit(sample())
+	}
+
+	// the fused kernel computes the norms of the complement-coded input, in a batch or row by row
+	a := sample()
+	A := model.complementCode(a)
+	fi := make([]float64, 26)
+	batch, scattered := make([]float64, 4), make([]float64, 4)
+	model.complementNorms(a, 0, batch)
+	for k := range scattered {
+		model.complementNorms(a, k, scattered[k:k+1])
+		want := simd.Generic().FuzzyIntersection(A, model.w[k], fi)
+		if d := scattered[k] - want; d > 1e-12 || d < -1e-12 {
+			t.Errorf("category %d: expected norm %f, got %f", k, want, scattered[k])
+		}
+	}
+	if !slices.Equal(batch, scattered) {
+		t.Errorf("expected the batch norms %v to match the scattered ones %v", batch, scattered)
+	}
+
+	// a committed input is inside its category, and resonates with it exactly
+	a = sample()
+	if _, j := model.Fit(a); model.intersectionNorm(model.complementCode(a), j, fi) != model.sampleNorm(a, nil) {
+		t.Errorf("expected the norm of the input %f to match its intersection with category %d", model.sampleNorm(a, nil), j)
+	}
+	if resonance, _ := model.Predict(a, false); resonance != 1 {
+		t.Errorf("expected the committed input to resonate with 1, got %f", resonance)
+	}
+}
+
+func TestFitAllocs(t *testing.T) {
+	model, err := NewFuzzyART(16, 0.9, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	a := make([]float64, 16)
+	for i := range a {
+		a[i] = float64(i) / 16
+	}
+	model.Fit(a)
+
+	// learning an input resonating with a category of a single batch doesn't allocate
+	tests := []struct {
+		name  string
+		learn func()
+	}{
+		{"Fit", func() { model.Fit(a) }},
+		{"Predict learning", func() { model.Predict(a, true) }},
+		{"FitWeighted", func() { model.FitWeighted(a, 0.5) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(100, tt.learn); allocs != 0 {
+				t.Errorf("expected no allocations, got %v", allocs)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package art
+
+import "fmt"
+
+// VigilanceSchedule returns the vigilance (rho) FitAll uses for the sampleIdx-th sample it fits,
+// counted across the epochs from 0, e.g. to train coarse-to-fine raising the vigilance every epoch.
+type VigilanceSchedule func(sampleIdx int) float64
+
+// WithVigilanceSchedule sets the vigilance schedule consulted by FitAll before every sample,
+// the model keeps the last vigilance of the schedule after training.
+// The schedule is not saved with the model.
+func WithVigilanceSchedule(s VigilanceSchedule) Option {
+	return func(f *fuzzyState) error {
+		f.schedule = s
+		return nil
+	}
+}
+
+// LinearVigilance returns a schedule raising the vigilance linearly from `from` to `to` over steps samples,
+// then keeping `to`, e.g. LinearVigilance(0.5, 0.9, epochs*len(samples)) for FitAll.
+func LinearVigilance(from, to float64, steps int) VigilanceSchedule {
+	return func(sampleIdx int) float64 {
+		if sampleIdx >= steps-1 {
+			return to
+		}
+		return from + (to-from)*float64(sampleIdx)/float64(steps-1)
+	}
+}
+
+// scheduleVigilance sets the vigilance of the sampleIdx-th sample of FitAll.
+func (f *FuzzyARTOf[T]) scheduleVigilance(sampleIdx int) {
+	rho := f.schedule(sampleIdx)
+	if rho < 0 || rho > 1 {
+		panic(fmt.Sprintf("scheduled vigilance must be between 0 and 1, got %f at sample %d", rho, sampleIdx))
+	}
+	f.rho = rho
+}
@@ -0,0 +1,48 @@
+package art
+
+import "testing"
+
+func TestVigilanceSchedule(t *testing.T) {
+	samples := make([][]float64, 40)
+	for i := range samples {
+		v := float64(i) / 40
+		samples[i] = []float64{v, 1 - v, v / 2, 0.5}
+	}
+
+	categories := func(rho float64, opts ...Option) (int, *FuzzyART) {
+		model, err := NewFuzzyART(4, rho, 0.01, 1, append(opts, WithSeed(2))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		model.FitAll(samples, 5)
+		return len(model.w), model
+	}
+	coarse, coarseModel := categories(0.5)
+	coarseModel.Close()
+
+	var seen []int
+	scheduled, model := categories(0.5, WithVigilanceSchedule(func(sampleIdx int) float64 {
+		seen = append(seen, sampleIdx)
+		// coarse-to-fine, raising the vigilance every epoch
+		return 0.5 + 0.1*float64(sampleIdx/len(samples))
+	}))
+	defer model.Close()
+	if len(seen) != 5*len(samples) || seen[len(seen)-1] != len(seen)-1 {
+		t.Fatalf("expected the schedule to be consulted for every sample of the 5 epochs, got %d calls", len(seen))
+	}
+	if model.rho < 0.9-1e-12 || model.rho > 0.9+1e-12 {
+		t.Errorf("expected the last scheduled vigilance 0.9, got %f", model.rho)
+	}
+	if scheduled <= coarse {
+		t.Errorf("expected the schedule to refine the %d coarse categories, got %d", coarse, scheduled)
+	}
+}
+
+func TestLinearVigilance(t *testing.T) {
+	s := LinearVigilance(0.5, 0.9, 5)
+	for i, want := range []float64{0.5, 0.6, 0.7, 0.8, 0.9, 0.9} {
+		if got := s(i); got < want-1e-12 || got > want+1e-12 {
+			t.Errorf("sample %d: expected vigilance %f, got %f", i, want, got)
+		}
+	}
+}
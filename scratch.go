@@ -0,0 +1,106 @@
+package art
+
+import (
+	"context"
+	"sync"
+
+	"github.com/oblq/art/internal/simd"
+)
+
+// predictScratch holds the per-call buffers of Predict without learning,
+// so that concurrent predictions don't share the activation list.
+type predictScratch[T Float] struct {
+	// fi holds a fuzzy intersection buffer of 2M values for every category batch
+	fi []T
+	// best holds the winner of every category batch
+	best []fuzzyActivation[T]
+	// fiNorms holds the fuzzy intersection norm of every category
+	fiNorms []T
+}
+
+// beats reports whether activation t wins over best, as ordered by sortCategoriesByActivation,
+// given that t has the higher category index.
+func (f *FuzzyARTOf[T]) beats(t, best *fuzzyActivation[T]) bool {
+	return t.activation > best.activation || (t.activation == best.activation && f.breakTie(t, best) < 0)
+}
+
+// winner returns the most active category for the input a, given its encodedInput A, without touching f.t:
+// it only reads the model, so it can run concurrently with other calls to winner.
+// The categories are split in batches on the worker pool as in activateCategories.
+// The model must have at least one category, and a tie-break policy other than TieBreakRandom.
+func (f *FuzzyARTOf[T]) winner(a, A []T) fuzzyActivation[T] {
+	best, _ := f.winnerCtx(context.Background(), a, A)
+	return best
+}
+
+// winnerCtx works like winner, but stops spawning category batches once ctx is done, returning its error.
+func (f *FuzzyARTOf[T]) winnerCtx(ctx context.Context, a, A []T) (fuzzyActivation[T], error) {
+	if err := ctx.Err(); err != nil {
+		return fuzzyActivation[T]{}, err
+	}
+	batches := (len(f.w) + f.batchSize - 1) / f.batchSize
+	s, _ := f.scratch.Get().(*predictScratch[T])
+	if s == nil {
+		s = new(predictScratch[T])
+	}
+	defer f.scratch.Put(s)
+	// every batch has its own aligned buffer, a padded vector apart, none without an Encoder
+	stride := simd.PaddedOf[T](len(A))
+	if len(s.fi) < batches*stride {
+		s.fi = simd.MakeAlignedOf[T](batches * stride)
+	}
+	if len(s.best) < batches {
+		s.best = make([]fuzzyActivation[T], batches)
+	}
+	if len(s.fiNorms) < len(f.w) {
+		s.fiNorms = make([]T, len(f.w))
+	}
+
+	batchWinner := func(b int) {
+		fi := s.fi[b*stride : b*stride+len(A)]
+		start, end := b*f.batchSize, min((b+1)*f.batchSize, len(f.w))
+		best := &s.best[b]
+		fiNorms := s.fiNorms[start:end]
+		f.inputNorms(a, A, start, fiNorms, fi)
+		var t fuzzyActivation[T]
+		for j := start; j < end; j++ {
+			t.j = j
+			t.fiNorm, t.wNorm = float64(fiNorms[j-start]), f.categories[j].wNorm
+			t.activation = t.fiNorm / (f.alpha + t.wNorm) * f.usagePrior(j)
+			if j == start || f.beats(&t, best) {
+				*best = t
+			}
+		}
+	}
+
+	if batches == 1 {
+		batchWinner(0)
+		return s.best[0], nil
+	}
+	var wg sync.WaitGroup
+	for b := range batches {
+		if err := ctx.Err(); err != nil {
+			wg.Wait()
+			return fuzzyActivation[T]{}, err
+		}
+		wg.Add(1)
+		// acquire a worker
+		f.workerPool <- struct{}{}
+		go func() {
+			defer func() {
+				<-f.workerPool
+				wg.Done()
+			}()
+			batchWinner(b)
+		}()
+	}
+	wg.Wait()
+
+	best := s.best[0]
+	for _, t := range s.best[1:batches] {
+		if f.beats(&t, &best) {
+			best = t
+		}
+	}
+	return best, nil
+}
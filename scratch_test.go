@@ -0,0 +1,50 @@
+package art
+
+import (
+	"math/rand/v2"
+	"sync"
+	"testing"
+)
+
+func TestPredictConcurrent(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	sample := func() []float64 {
+		return []float64{rng.Float64(), rng.Float64(), float64(rng.IntN(3)) / 2, float64(rng.IntN(3)) / 2}
+	}
+
+	for _, policy := range []TieBreak{TieBreakOldest, TieBreakLargest, TieBreakSmallestNorm} {
+		model, err := NewFuzzyART(4, 0.9, 0.01, 0.5, WithTieBreak(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// several category batches
+		for len(model.w) < 3*model.batchSize {
+			model.Fit(sample())
+		}
+
+		inputs := make([][]float64, 200)
+		want := make([]int, len(inputs))
+		for i := range inputs {
+			inputs[i] = sample()
+			// the sorted activation list is the reference
+			model.activateCategories(model.complementCode(inputs[i]))
+			want[i] = model.t[0].j
+		}
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i, a := range inputs {
+					if _, j := model.Predict(a, false); j != want[i] {
+						t.Errorf("%s: input %d predicted %d, expected %d", policy, i, j, want[i])
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		model.Close()
+	}
+}
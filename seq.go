@@ -0,0 +1,28 @@
+package art
+
+import "iter"
+
+// FitSeq fits every sample of seq in order, as by Fit, e.g. reading them from a stream
+// without materializing them in a slice. It returns the number of samples fitted.
+func (f *FuzzyARTOf[T]) FitSeq(seq iter.Seq[[]float64]) int {
+	n := 0
+	for a := range seq {
+		f.Fit(a)
+		n++
+	}
+	return n
+}
+
+// PredictSeq returns an iterator predicting the samples of seq without learning, as by Predict,
+// yielding every sample with its prediction. The samples are predicted lazily, while iterating:
+// the model must not be modified by another goroutine meanwhile.
+func (f *FuzzyARTOf[T]) PredictSeq(seq iter.Seq[[]float64]) iter.Seq2[[]float64, Prediction] {
+	return func(yield func([]float64, Prediction) bool) {
+		for a := range seq {
+			activation, j := f.Predict(a, false)
+			if !yield(a, Prediction{Activation: activation, Category: j}) {
+				return
+			}
+		}
+	}
+}
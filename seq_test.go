@@ -0,0 +1,33 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSeq(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	samples := [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}, {0.11, 0.1, 0.1, 0.1}}
+	if n := model.FitSeq(slices.Values(samples)); n != 3 || model.CategoryCount() != 2 {
+		t.Fatalf("expected 3 samples fitted in 2 categories, got %d in %d", n, model.CategoryCount())
+	}
+
+	var predicted []int
+	for a, p := range model.PredictSeq(slices.Values(samples)) {
+		if activation, j := model.Predict(a, false); p != (Prediction{Activation: activation, Category: j}) {
+			t.Errorf("sample %v: expected %d (%f), got %+v", a, j, activation, p)
+		}
+		predicted = append(predicted, p.Category)
+		if len(predicted) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(predicted, []int{0, 1}) {
+		t.Errorf("expected categories [0 1] before stopping, got %v", predicted)
+	}
+}
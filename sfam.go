@@ -0,0 +1,76 @@
+package art
+
+import "fmt"
+
+// SFAM implements Simplified Fuzzy ARTMAP (Kasuba, 1993), a supervised classifier.
+// Instead of a second ART module clustering the labels, every category stores its class label.
+// When the best resonating category has the wrong label, match tracking raises the vigilance
+// just above its resonance and the search continues, eventually creating a new category.
+type SFAM struct {
+	fuzzy *FuzzyART
+
+	// Match tracking parameter - the vigilance increase after a wrong prediction
+	// Recommended value: 0.001
+	// Purpose: Small positive values (MT+) make the search skip every category
+	// matching the input no better than the mispredicting one.
+	// Small negative values (MT-) allow categories with the same resonance to be tested,
+	// which is better suited to inconsistent (noisy) labels.
+	epsilon float64
+
+	// labels stores the class label of every category
+	labels []int
+}
+
+func NewSFAM(inputLen int, rho, alpha, beta, epsilon float64) (*SFAM, error) {
+	fuzzy, err := NewFuzzyART(inputLen, rho, alpha, beta)
+	if err != nil {
+		return nil, err
+	}
+	if epsilon <= -1 || epsilon >= 1 {
+		return nil, fmt.Errorf("match tracking parameter (epsilon) must be between -1 and 1, got %f", epsilon)
+	}
+
+	return &SFAM{
+		fuzzy:   fuzzy,
+		epsilon: epsilon,
+		labels:  make([]int, 0),
+	}, nil
+}
+
+// Fit learns the input with its class label and returns the index of the learning category.
+func (s *SFAM) Fit(a []float64, label int) (categoryIndex int) {
+	A := s.fuzzy.learningInput(a)
+	s.fuzzy.activateCategories(A)
+
+	_, categoryIndex, created := s.fuzzy.trackMatch(A, s.epsilon, func(j int) bool {
+		return s.labels[j] == label
+	})
+	if created {
+		s.labels = append(s.labels, label)
+	}
+	return categoryIndex
+}
+
+// Predict returns the label of the best matching category and its index,
+// or -1, -1 if the model has no categories yet.
+func (s *SFAM) Predict(a []float64) (label int, categoryIndex int) {
+	if len(s.labels) == 0 {
+		return -1, -1
+	}
+	_, categoryIndex = s.fuzzy.Predict(a, false)
+	return s.labels[categoryIndex], categoryIndex
+}
+
+// Label returns the class label of category j.
+func (s *SFAM) Label(j int) int {
+	return s.labels[j]
+}
+
+// CategoryCount returns the number of categories.
+func (s *SFAM) CategoryCount() int {
+	return len(s.labels)
+}
+
+func (s *SFAM) Close() {
+	s.fuzzy.Close()
+}
@@ -0,0 +1,46 @@
+package art
+
+import "testing"
+
+func TestSFAMMatchTracking(t *testing.T) {
+	// low vigilance: without match tracking both inputs would fall in the same category
+	model, err := NewSFAM(4, 0.1, 0.01, 1, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	a := []float64{0.2, 0.2, 0.2, 0.2}
+	b := []float64{0.3, 0.3, 0.3, 0.3}
+
+	if j := model.Fit(a, 0); j != 0 {
+		t.Fatalf("first sample should create category 0, got %d", j)
+	}
+	if j := model.Fit(b, 1); j != 1 {
+		t.Fatalf("mislabeled resonance should be reset by match tracking, got category %d", j)
+	}
+	if n := model.CategoryCount(); n != 2 {
+		t.Fatalf("expected 2 categories, got %d", n)
+	}
+
+	for _, c := range []struct {
+		input []float64
+		label int
+	}{{a, 0}, {b, 1}} {
+		if label, _ := model.Predict(c.input); label != c.label {
+			t.Errorf("expected label %d, got %d", c.label, label)
+		}
+	}
+}
+
+func TestSFAMEmptyPredict(t *testing.T) {
+	model, err := NewSFAM(4, 0.5, 0.01, 1, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	if label, j := model.Predict([]float64{0, 0, 0, 0}); label != -1 || j != -1 {
+		t.Errorf("empty model should return -1, -1, got %d, %d", label, j)
+	}
+}
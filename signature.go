@@ -0,0 +1,88 @@
+package art
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// InputSignature identifies the preprocessing pipeline producing the inputs of a model,
+// it guards against serving a model with features preprocessed differently from training.
+type InputSignature struct {
+	// InputLen is the number of features produced by the pipeline.
+	InputLen int
+	// Pipeline is a hash of the description of the preprocessing steps.
+	Pipeline string
+}
+
+// NewInputSignature returns the signature of a pipeline producing inputLen features,
+// described by its steps in order, e.g.: "minmax(0,255)", "pca(32)".
+// Steps should include any fitted parameter, so that refitting the pipeline changes the signature.
+func NewInputSignature(inputLen int, steps ...string) InputSignature {
+	sum := sha256.Sum256([]byte(strings.Join(steps, "\n")))
+	return InputSignature{InputLen: inputLen, Pipeline: hex.EncodeToString(sum[:16])}
+}
+
+func (s InputSignature) String() string {
+	return fmt.Sprintf("%d:%s", s.InputLen, s.Pipeline)
+}
+
+// SignatureMismatchError is returned when an input signature doesn't match the model one.
+type SignatureMismatchError struct {
+	Expected, Got InputSignature
+}
+
+func (e *SignatureMismatchError) Error() string {
+	if e.Expected.InputLen != e.Got.InputLen {
+		return fmt.Sprintf("input signature mismatch: model expects %d features, got %d", e.Expected.InputLen, e.Got.InputLen)
+	}
+	return fmt.Sprintf("input signature mismatch: model was fitted on pipeline %s, got %s", e.Expected.Pipeline, e.Got.Pipeline)
+}
+
+// WithInputSignature stores the signature of the pipeline producing the model inputs.
+func WithInputSignature(s InputSignature) Option {
+	return func(f *fuzzyState) error {
+		if s.InputLen != f.M {
+			return fmt.Errorf("input signature length must be %d, got %d", f.M, s.InputLen)
+		}
+		f.signature = s
+		return nil
+	}
+}
+
+// InputSignature returns the signature stored with WithInputSignature,
+// or one with an empty pipeline if none was set.
+func (f *FuzzyARTOf[T]) InputSignature() InputSignature {
+	if f.signature.InputLen == 0 {
+		return InputSignature{InputLen: f.M}
+	}
+	return f.signature
+}
+
+// checkSignature returns a *SignatureMismatchError if s doesn't match the model signature,
+// the pipeline is only compared when the model has one.
+func (f *FuzzyARTOf[T]) checkSignature(s InputSignature) error {
+	expected := f.InputSignature()
+	if s.InputLen != expected.InputLen || (expected.Pipeline != "" && s.Pipeline != expected.Pipeline) {
+		return &SignatureMismatchError{Expected: expected, Got: s}
+	}
+	return nil
+}
+
+// PredictSigned works like Predict without learning,
+// but first rejects inputs whose declared signature or length doesn't match the model one.
+func (f *FuzzyARTOf[T]) PredictSigned(a []float64, s InputSignature) (categoryActivation float64, categoryIndex int, err error) {
+	if err = f.checkSignature(s); err != nil {
+		return 0, -1, err
+	}
+	if len(a) != f.M {
+		return 0, -1, &SignatureMismatchError{Expected: f.InputSignature(), Got: InputSignature{InputLen: len(a), Pipeline: s.Pipeline}}
+	}
+	if len(f.w) == 0 {
+		return 0, -1, nil
+	}
+
+	categoryActivation, categoryIndex = f.Predict(a, false)
+	return categoryActivation, categoryIndex, nil
+}
@@ -0,0 +1,28 @@
+package art
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPredictSigned(t *testing.T) {
+	signature := NewInputSignature(4, "minmax(0,255)")
+	model, err := NewFuzzyART(4, 0.5, 0.01, 1, WithInputSignature(signature))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.2, 0.6, 0, 0})
+
+	if _, j, err := model.PredictSigned([]float64{0.2, 0.6, 0, 0}, signature); err != nil || j != 0 {
+		t.Errorf("expected category 0, got %d (%v)", j, err)
+	}
+
+	var mismatch *SignatureMismatchError
+	if _, _, err := model.PredictSigned([]float64{0.2, 0.6, 0, 0}, NewInputSignature(4, "zscore")); !errors.As(err, &mismatch) {
+		t.Errorf("different pipeline should be rejected, got %v", err)
+	}
+	if _, _, err := model.PredictSigned([]float64{0.2, 0.6}, signature); !errors.As(err, &mismatch) {
+		t.Errorf("different input length should be rejected, got %v", err)
+	}
+}
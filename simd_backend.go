@@ -0,0 +1,52 @@
+package art
+
+import (
+	"log"
+
+	"github.com/oblq/art/internal/simd"
+)
+
+// SIMDProvider is the interface of the SIMD backends, implemented to plug in an external one with RegisterSIMD.
+// The methods work on complement-coded vectors of the same length:
+// FuzzyIntersectionNorm stores min(A, w) in fuzzyIntersectionOut and returns its sum and the sum of w,
+// FuzzyIntersection does the same without the sum of w, FuzzyIntersectionNormBatch stores the sum of min(A, w)
+// of rows consecutive vectors of Wflat in fiNormsOut, ComplementIntersectionNormBatch does the same
+// for the complement coding [a, 1-a] of a raw input and rows stride values apart, SumFloat64 sums arr
+// and UpdateFuzzyWeights sets W to beta*fi + (1-beta)*W.
+type SIMDProvider = simd.Provider
+
+// RegisterSIMD adds p to the SIMD backends with the given name, e.g.: a wrapper of MKL,
+// to be selected with UseSIMD, which checks it against the generic kernels first.
+// It returns an error if the name is taken.
+func RegisterSIMD(name string, p SIMDProvider) error {
+	return simd.Register(name, p)
+}
+
+// SIMDBackends returns the names of the SIMD backends supported by the CPU and the build, the fastest first:
+// "avx512" (built with the avx512 tag), "avx2", "neon" or "accelerate", then the ones added by RegisterSIMD,
+// always ending with "generic".
+func SIMDBackends() []string {
+	return simd.Available()
+}
+
+// UseSIMD forces the SIMD backend of every model, one of SIMDBackends, e.g.: to rule out the native kernels
+// while debugging a performance regression. It returns an error if the backend isn't available
+// or doesn't compute the same results as the generic one on this CPU.
+// It must be called before using the models, not concurrently with them;
+// the ART_SIMD environment variable selects the backend the same way at startup.
+// Models using WithDeterministic always use the generic kernels.
+func UseSIMD(name string) error {
+	return simd.Use(name)
+}
+
+// SIMDBackend returns the name of the SIMD backend in use.
+func SIMDBackend() string {
+	return simd.ProviderName()
+}
+
+// SetSIMDLogger sets the logger of the SIMD backend warnings: a native backend failing its self-check,
+// or switching to the generic kernels after a panic. They go to log.Default() until then, nil discards them.
+// The package doesn't print anything else, SIMDBackend reports the backend in use.
+func SetSIMDLogger(l *log.Logger) {
+	simd.SetLogger(l)
+}
@@ -0,0 +1,115 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUseSIMD(t *testing.T) {
+	defer UseSIMD(SIMDBackend())
+
+	samples := [][]float64{{0.1, 0.2, 0.3, 0.4, 0.5}, {0.9, 0.8, 0.7, 0.6, 0.5}, {0.15, 0.2, 0.3, 0.4, 0.45}}
+	var reference []int
+	for _, backend := range SIMDBackends() {
+		if err := UseSIMD(backend); err != nil {
+			t.Fatal(err)
+		}
+		if SIMDBackend() != backend {
+			t.Errorf("expected the %s backend, got %s", backend, SIMDBackend())
+		}
+
+		model, err := NewFuzzyART(5, 0.8, 0.01, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var assignments []int
+		for _, a := range samples {
+			_, j := model.Fit(a)
+			assignments = append(assignments, j)
+		}
+		model.Close()
+		if reference == nil {
+			reference = assignments
+		} else if !slices.Equal(assignments, reference) {
+			t.Errorf("%s assigned %v, expected %v", backend, assignments, reference)
+		}
+	}
+
+	if err := UseSIMD("mmx"); err == nil {
+		t.Error("expected an error selecting an unavailable backend")
+	}
+}
+
+// scalarProvider is a SIMDProvider implemented outside the simd package.
+type scalarProvider struct{}
+
+func (scalarProvider) FuzzyIntersectionNorm(A, w, fuzzyIntersectionOut []float64) (fiNorm, wNorm float64) {
+	for i := range A {
+		fuzzyIntersectionOut[i] = min(A[i], w[i])
+		fiNorm += fuzzyIntersectionOut[i]
+		wNorm += w[i]
+	}
+	return fiNorm, wNorm
+}
+
+func (p scalarProvider) FuzzyIntersection(A, w, fuzzyIntersectionOut []float64) float64 {
+	fiNorm, _ := p.FuzzyIntersectionNorm(A, w, fuzzyIntersectionOut)
+	return fiNorm
+}
+
+func (p scalarProvider) FuzzyIntersectionNormBatch(A, Wflat []float64, rows int, fiNormsOut []float64) {
+	fi := make([]float64, len(A))
+	for r := range rows {
+		fiNormsOut[r] = p.FuzzyIntersection(A, Wflat[r*len(A):(r+1)*len(A)], fi)
+	}
+}
+
+func (p scalarProvider) ComplementIntersectionNormBatch(a, Wflat []float64, stride, rows int, fiNormsOut []float64) {
+	A := make([]float64, 2*len(a))
+	for i, v := range a {
+		A[i], A[len(a)+i] = v, 1-v
+	}
+	for r := range rows {
+		p.FuzzyIntersectionNormBatch(A, Wflat[r*stride:r*stride+len(A)], 1, fiNormsOut[r:r+1])
+	}
+}
+
+func (scalarProvider) SumFloat64(arr []float64) (sum float64) {
+	for _, v := range arr {
+		sum += v
+	}
+	return sum
+}
+
+func (scalarProvider) UpdateFuzzyWeights(W, fi []float64, beta float64) {
+	for i := range W {
+		W[i] = beta*fi[i] + (1-beta)*W[i]
+	}
+}
+
+func TestRegisterSIMD(t *testing.T) {
+	defer UseSIMD(SIMDBackend())
+
+	// the registry outlives the test, e.g.: with -count
+	if !slices.Contains(SIMDBackends(), "scalar") {
+		if err := RegisterSIMD("scalar", scalarProvider{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := RegisterSIMD("scalar", scalarProvider{}); err == nil {
+		t.Error("expected an error registering a backend twice")
+	}
+	if err := UseSIMD("scalar"); err != nil {
+		t.Fatal(err)
+	}
+
+	model, err := NewFuzzyART(2, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.2})
+	if _, j := model.Fit([]float64{0.9, 0.8}); j != 1 || SIMDBackend() != "scalar" {
+		t.Errorf("expected a second category with the scalar backend, got %d with %s", j, SIMDBackend())
+	}
+}
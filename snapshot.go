@@ -0,0 +1,87 @@
+package art
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ModelStateOf is a point-in-time copy of the categories of a FuzzyARTOf, see Snapshot.
+type ModelStateOf[T Float] struct {
+	m          int
+	w          [][]T
+	categories []category
+}
+
+// ModelState is the snapshot of a FuzzyART.
+type ModelState = ModelStateOf[float64]
+
+// CategoryCount returns the number of categories in the snapshot.
+func (s ModelStateOf[T]) CategoryCount() int {
+	return len(s.w)
+}
+
+// Snapshot captures the categories of the model, so that it can be rolled back with Restore,
+// e.g. after ingesting a bad batch of data.
+// The weights are copy-on-write: the snapshot shares them with the model,
+// and a category is copied only when it learns for the first time after the snapshot.
+// With WithMmapWeights the weights are copied immediately instead, so that
+// the rows of the model keep aliasing the mapped file.
+func (f *FuzzyARTOf[T]) Snapshot() ModelStateOf[T] {
+	s := ModelStateOf[T]{m: f.M, w: slices.Clone(f.w), categories: slices.Clone(f.categories)}
+	cloneVotes(s.categories)
+	if f.mmap != nil {
+		for j, w := range s.w {
+			s.w[j] = slices.Clone(w)
+		}
+		return s
+	}
+	for j := range f.categories {
+		f.categories[j].shared = true
+	}
+	return s
+}
+
+// unshare copies the weights of category j if they are shared with a snapshot, before they are written.
+func (f *FuzzyARTOf[T]) unshare(j int) {
+	if f.categories[j].shared {
+		f.w[j] = slices.Clone(f.w[j])
+		f.categories[j].shared = false
+	}
+}
+
+// Restore rolls the categories of the model back to the snapshot s,
+// which can be restored again later.
+// The attached metadata store, if any, is not rolled back.
+func (f *FuzzyARTOf[T]) Restore(s ModelStateOf[T]) error {
+	if s.m != f.M {
+		return fmt.Errorf("snapshot input length must be %d, got %d", f.M, s.m)
+	}
+
+	if f.mmap != nil {
+		f.compact(func(int) bool { return false })
+		for _, w := range s.w {
+			f.appendNewCategory(w)
+		}
+		copy(f.categories, s.categories)
+		cloneVotes(f.categories)
+		return nil
+	}
+
+	n := len(f.w)
+	f.w = append(f.w[:0], s.w...)
+	if n > len(f.w) {
+		clear(f.w[len(f.w):n])
+	}
+	f.categories = append(f.categories[:0], s.categories...)
+	cloneVotes(f.categories)
+	for j := range f.categories {
+		f.categories[j].shared = true
+	}
+	// activations are recomputed on every input, only their number matters
+	for len(f.t) < len(f.w) {
+		f.t = append(f.t, &fuzzyActivation[T]{fi: f.newVector()})
+	}
+	f.t = f.t[:len(f.w)]
+	f.coarseDirty = true
+	return nil
+}
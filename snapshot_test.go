@@ -0,0 +1,57 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	model.Fit([]float64{0.9, 0.9, 0.9, 0.9})
+
+	want := slices.Clone(model.w[0])
+	s := model.Snapshot()
+	if &model.w[0][0] != &s.w[0][0] {
+		t.Fatal("the snapshot should share the weights")
+	}
+
+	// a bad batch
+	model.Fit([]float64{0.13, 0.1, 0.1, 0.1})
+	model.Fit([]float64{0.5, 0.1, 0.9, 0.5})
+	if slices.Equal(model.w[0], want) || len(model.w) != 3 {
+		t.Fatalf("the batch should have changed category 0 and added one, got %v", model.w)
+	}
+	if !slices.Equal(s.w[0], want) {
+		t.Fatalf("learning changed the snapshot: %v", s.w[0])
+	}
+	if &model.w[1][0] != &s.w[1][0] {
+		t.Error("categories that didn't learn should still be shared")
+	}
+
+	if err := model.Restore(s); err != nil {
+		t.Fatal(err)
+	}
+	if len(model.w) != 2 || !slices.Equal(model.w[0], want) || model.Count(0) != 1 {
+		t.Fatalf("expected the snapshot categories, got %v", model.w)
+	}
+
+	// the snapshot must survive learning after the restore too
+	model.Fit([]float64{0.13, 0.1, 0.1, 0.1})
+	if !slices.Equal(s.w[0], want) {
+		t.Errorf("learning after Restore changed the snapshot: %v", s.w[0])
+	}
+
+	other, err := NewFuzzyART(8, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if err := other.Restore(s); err == nil {
+		t.Error("expected an error restoring a snapshot with a different input length")
+	}
+}
@@ -0,0 +1,162 @@
+//go:build soak
+
+package art
+
+import (
+	"flag"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Soak tests run for hours, they're excluded from the default test run:
+//
+//	make soak
+//	go test -tags soak -run TestSoak -timeout 0 -v . -args -soak.duration=4h
+var (
+	soakDuration = flag.Duration("soak.duration", 2*time.Hour, "soak test duration")
+	soakInterval = flag.Duration("soak.interval", time.Minute, "interval between soak test checks")
+)
+
+const (
+	soakInputLen = 16
+	// heapSlack is the heap growth tolerated regardless of the number of categories.
+	heapSlack = 16 << 20
+	// heapPerCategory is the upper bound of the heap used by a single category:
+	// weights, activation and fuzzy intersection buffer (2 * 2M float64) plus some overhead.
+	heapPerCategory = 2*2*soakInputLen*8 + 256
+	// goroutineSlack is the goroutine growth tolerated (test runner, timers...).
+	goroutineSlack = 2
+	// pruneMinSamples and pruneMinAge drop the noise-only categories every interval, see Prune.
+	pruneMinSamples = 2
+	pruneMinAge     = 10000
+	// latencyGrowth is the tolerated growth of the p99 latency per category between two intervals.
+	latencyGrowth = 3.0
+)
+
+type soakModel interface {
+	Model
+	CategoryCount() int
+}
+
+// pruner is implemented by the models supporting Prune.
+type pruner interface {
+	Prune(minSamples, minAge int) []int
+}
+
+// fuzzySoak adapts FuzzyART to soakModel.
+type fuzzySoak struct{ *FuzzyART }
+
+func (f fuzzySoak) CategoryCount() int { return f.FuzzyART.CategoryCount() }
+
+func heapInUse() uint64 {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[min(len(sorted)-1, int(p*float64(len(sorted))))]
+}
+
+func TestSoak(t *testing.T) {
+	models := map[string]func() (soakModel, error){
+		"fuzzy": func() (soakModel, error) {
+			f, err := NewFuzzyART(soakInputLen, 0.75, 0.01, 0.5)
+			return fuzzySoak{f}, err
+		},
+		"topo": func() (soakModel, error) {
+			return NewTopoART(soakInputLen, 0.75, 0.01, 0.5, 0.25, 5, 1000)
+		},
+	}
+
+	for name, newModel := range models {
+		// models run one after the other, so that goroutine counts are not affected by each other
+		t.Run(name, func(t *testing.T) {
+			model, err := newModel()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer model.Close()
+			soak(t, model, *soakDuration/time.Duration(len(models)))
+		})
+	}
+}
+
+func soak(t *testing.T, model soakModel, duration time.Duration) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	// drifting clusters keep the model learning for the whole run
+	centers := make([][]float64, 8)
+	for i := range centers {
+		centers[i] = make([]float64, soakInputLen)
+		for k := range centers[i] {
+			centers[i][k] = rng.Float64()
+		}
+	}
+	sample := func() []float64 {
+		c := centers[rng.IntN(len(centers))]
+		a := make([]float64, soakInputLen)
+		for k := range a {
+			c[k] = min(1, max(0, c[k]+rng.NormFloat64()*1e-4))
+			a[k] = min(1, max(0, c[k]+rng.NormFloat64()*0.05))
+		}
+		return a
+	}
+
+	baseHeap := heapInUse()
+	baseGoroutines := runtime.NumGoroutine()
+	var baseLatency float64 // p99 nanoseconds per category of the first interval
+
+	deadline := time.Now().Add(duration)
+	for interval := 1; time.Now().Before(deadline); interval++ {
+		var latencies []time.Duration
+		next := time.Now().Add(*soakInterval)
+		samples := 0
+		for time.Now().Before(next) {
+			model.Fit(sample())
+
+			start := time.Now()
+			model.Predict(sample(), false)
+			latencies = append(latencies, time.Since(start))
+			samples++
+		}
+
+		pruned := 0
+		if p, ok := model.(pruner); ok {
+			for _, j := range p.Prune(pruneMinSamples, pruneMinAge) {
+				if j == -1 {
+					pruned++
+				}
+			}
+		}
+
+		categories := model.CategoryCount()
+		heap := heapInUse()
+		goroutines := runtime.NumGoroutine()
+		slices.Sort(latencies)
+		p50, p99 := percentile(latencies, 0.5), percentile(latencies, 0.99)
+		t.Logf("interval %d: %d samples, %d categories (%d pruned), heap %d KiB, %d goroutines, predict p50 %s p99 %s",
+			interval, samples, categories, pruned, heap>>10, goroutines, p50, p99)
+
+		if limit := baseHeap + heapSlack + uint64(categories)*heapPerCategory; heap > limit {
+			t.Errorf("heap %d bytes exceeds the bound of %d bytes for %d categories", heap, limit, categories)
+		}
+		if goroutines > baseGoroutines+goroutineSlack {
+			t.Errorf("goroutines grew from %d to %d", baseGoroutines, goroutines)
+		}
+
+		perCategory := float64(p99) / float64(max(categories, 1))
+		if baseLatency == 0 {
+			baseLatency = perCategory
+		} else if perCategory > latencyGrowth*baseLatency {
+			t.Errorf("p99 latency per category grew from %.0fns to %.0fns", baseLatency, perCategory)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package art
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TemporalEncoding selects how TemporalART encodes the input history.
+type TemporalEncoding int
+
+const (
+	// SlidingWindow concatenates the last window inputs, the oldest first.
+	// Until window inputs are seen, the oldest one is repeated.
+	SlidingWindow TemporalEncoding = iota
+	// LeakyIntegrator concatenates the current input and an exponentially decaying trace
+	// of the past ones: y_t = decay * y_t-1 + (1 - decay) * x_t.
+	LeakyIntegrator
+)
+
+// TemporalART clusters streaming time-series by temporal pattern instead of by instantaneous vector:
+// every input is encoded together with its recent history before being learned by FuzzyART.
+// Fit and Predict must be called with the inputs in time order, Reset starts a new sequence.
+type TemporalART struct {
+	fuzzy *FuzzyART
+
+	encoding TemporalEncoding
+
+	// Number of inputs encoded by the sliding window
+	window int
+
+	// Decay of the leaky integrator trace
+	// Range: 0.0 to 1.0, higher values remember longer histories.
+	decay float64
+
+	// M is the number of features of a single input.
+	M int
+
+	// history stores the last window inputs (sliding window) or the trace (leaky integrator)
+	history [][]float64
+}
+
+// NewTemporalART creates a TemporalART for inputs of inputLen features.
+// window is only used by SlidingWindow, decay only by LeakyIntegrator.
+func NewTemporalART(inputLen int, encoding TemporalEncoding, window int, decay, rho, alpha, beta float64) (*TemporalART, error) {
+	encodedLen := 0
+	switch encoding {
+	case SlidingWindow:
+		if window < 1 {
+			return nil, fmt.Errorf("window must be at least 1, got %d", window)
+		}
+		encodedLen = inputLen * window
+	case LeakyIntegrator:
+		if decay < 0 || decay >= 1 {
+			return nil, fmt.Errorf("decay must be between 0 and 1 (excluded), got %f", decay)
+		}
+		encodedLen = 2 * inputLen
+	default:
+		return nil, fmt.Errorf("unknown temporal encoding %d", encoding)
+	}
+
+	fuzzy, err := NewFuzzyART(encodedLen, rho, alpha, beta)
+	if err != nil {
+		return nil, err
+	}
+	return &TemporalART{
+		fuzzy:    fuzzy,
+		encoding: encoding,
+		window:   window,
+		decay:    decay,
+		M:        inputLen,
+	}, nil
+}
+
+// encode adds the input to the history and returns the encoded history.
+func (t *TemporalART) encode(a []float64) []float64 {
+	if len(a) != t.M {
+		panic(fmt.Sprintf("input length must be %d, got %d", t.M, len(a)))
+	}
+
+	if t.encoding == LeakyIntegrator {
+		if t.history == nil {
+			t.history = [][]float64{slices.Clone(a)}
+		} else {
+			trace := t.history[0]
+			for i, v := range a {
+				trace[i] = t.decay*trace[i] + (1-t.decay)*v
+			}
+		}
+		return append(slices.Clone(a), t.history[0]...)
+	}
+
+	if len(t.history) == t.window {
+		t.history = append(t.history[:0], t.history[1:]...)
+	}
+	t.history = append(t.history, slices.Clone(a))
+
+	encoded := make([]float64, 0, t.M*t.window)
+	for range t.window - len(t.history) {
+		encoded = append(encoded, t.history[0]...)
+	}
+	for _, h := range t.history {
+		encoded = append(encoded, h...)
+	}
+	return encoded
+}
+
+// Fit adds the input to the history and learns the encoded history.
+func (t *TemporalART) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	return t.fuzzy.Fit(t.encode(a))
+}
+
+// Predict adds the input to the history and returns the resonance
+// and the index of the category best matching the encoded history,
+// or -1 if the model has no categories yet.
+// If learn is true, it also updates the matching category.
+func (t *TemporalART) Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int) {
+	encoded := t.encode(a)
+	if !learn && len(t.fuzzy.w) == 0 {
+		return 0, -1
+	}
+	return t.fuzzy.Predict(encoded, learn)
+}
+
+// Reset clears the history, the next input starts a new sequence.
+func (t *TemporalART) Reset() {
+	t.history = nil
+}
+
+// CategoryCount returns the number of categories.
+func (t *TemporalART) CategoryCount() int {
+	return len(t.fuzzy.w)
+}
+
+func (t *TemporalART) Close() {
+	t.fuzzy.Close()
+}
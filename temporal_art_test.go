@@ -0,0 +1,36 @@
+package art
+
+import "testing"
+
+func TestTemporalART(t *testing.T) {
+	for name, encoding := range map[string]TemporalEncoding{"window": SlidingWindow, "leaky": LeakyIntegrator} {
+		t.Run(name, func(t *testing.T) {
+			// window 2 and leaky integration both encode 8 features
+			model, err := NewTemporalART(4, encoding, 2, 0.5, 0.9, 0.01, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer model.Close()
+
+			low := []float64{0.1, 0.1, 0.1, 0.1}
+			high := []float64{0.9, 0.9, 0.9, 0.9}
+
+			// the same instantaneous input, reached rising or falling
+			model.Fit(low)
+			_, rising := model.Fit(high)
+			model.Reset()
+			model.Fit(high)
+			model.Fit(high)
+			_, falling := model.Fit(low)
+			if rising == falling {
+				t.Errorf("rising and falling patterns should be different categories, got %d", rising)
+			}
+
+			model.Reset()
+			model.Predict(low, false)
+			if _, j := model.Predict(high, false); j != rising {
+				t.Errorf("expected rising category %d, got %d", rising, j)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package art
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"sync"
+)
+
+// ParamSetter is implemented by the models whose hyperparameters can be changed while learning.
+// Param returns the current value of a parameter SetParam can change.
+type ParamSetter interface {
+	SetParam(name string, value float64) error
+	Param(name string) (float64, error)
+}
+
+// SetParam changes the vigilance ("rho"), the learning rate ("beta")
+// or the category cap ("max_categories", 0 disables it) of the model, the new value applies from the next input.
+// Lowering the cap below the current number of categories stops the creation of new ones, without removing any.
+func (f *FuzzyARTOf[T]) SetParam(name string, value float64) error {
+	switch name {
+	case "rho":
+		return f.SetRho(value)
+	case "beta":
+		return f.SetBeta(value)
+	case "max_categories":
+		if value < 0 || value != float64(int(value)) {
+			return fmt.Errorf("maximum categories must be a non-negative integer, got %f", value)
+		}
+		f.maxCategories = int(value)
+	default:
+		return fmt.Errorf("parameter %q can't be changed while learning", name)
+	}
+	return nil
+}
+
+// Param returns the current value of a parameter changed by SetParam.
+func (f *FuzzyARTOf[T]) Param(name string) (float64, error) {
+	switch name {
+	case "rho":
+		return f.rho, nil
+	case "beta":
+		return f.beta, nil
+	case "max_categories":
+		return float64(f.maxCategories), nil
+	default:
+		return 0, fmt.Errorf("parameter %q can't be changed while learning", name)
+	}
+}
+
+// tenant holds the model of a key, mu serializes its usage with the hot updates.
+type tenant struct {
+	mu        sync.Mutex
+	model     Model
+	overrides map[string]float64
+}
+
+// Tenants manages one model per key (e.g.: per tenant) of the same variant.
+// Every key uses the shared base config with its own parameter overrides layered on top,
+// overrides can be changed at any time and are applied to the live models when they implement ParamSetter.
+type Tenants struct {
+	variant string
+	base    Config
+
+	mu      sync.Mutex
+	tenants map[string]*tenant
+}
+
+// NewTenants returns a Tenants creating models of the named variant from the base config.
+func NewTenants(variant string, base Config) (*Tenants, error) {
+	// fail fast on invalid base configs
+	m, err := New(variant, base)
+	if err != nil {
+		return nil, err
+	}
+	m.Close()
+
+	return &Tenants{variant: variant, base: base, tenants: make(map[string]*tenant)}, nil
+}
+
+func (t *Tenants) tenant(key string) *tenant {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tn, ok := t.tenants[key]
+	if !ok {
+		tn = &tenant{overrides: make(map[string]float64)}
+		t.tenants[key] = tn
+	}
+	return tn
+}
+
+// lookup returns the tenant of the key, nil if it doesn't exist yet.
+func (t *Tenants) lookup(key string) *tenant {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tenants[key]
+}
+
+// config returns the base config with the overrides layered on top.
+func (t *Tenants) config(overrides map[string]float64) Config {
+	cfg := Config{InputLen: t.base.InputLen, Params: maps.Clone(t.base.Params)}
+	if cfg.Params == nil {
+		cfg.Params = make(map[string]float64)
+	}
+	maps.Copy(cfg.Params, overrides)
+	return cfg
+}
+
+// Config returns the effective config of the key, the base config for unknown keys.
+func (t *Tenants) Config(key string) Config {
+	tn := t.lookup(key)
+	if tn == nil {
+		return t.config(nil)
+	}
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+	return t.config(tn.overrides)
+}
+
+// Use calls fn with the model of the key, creating it on first use.
+// Calls for the same key are serialized, models are not safe for concurrent use.
+func (t *Tenants) Use(key string, fn func(m Model) error) error {
+	tn := t.tenant(key)
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+
+	if tn.model == nil {
+		m, err := New(t.variant, t.config(tn.overrides))
+		if err != nil {
+			return fmt.Errorf("tenant %q: %w", key, err)
+		}
+		tn.model = m
+	}
+	return fn(tn.model)
+}
+
+// SetOverrides replaces the parameter overrides of the key.
+// If the model already exists, the changed parameters are applied to it,
+// which requires the model to implement ParamSetter; nothing changes on error.
+func (t *Tenants) SetOverrides(key string, overrides map[string]float64) error {
+	tn := t.tenant(key)
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+
+	cfg := t.config(overrides)
+	if tn.model == nil {
+		// validate the overrides before storing them
+		m, err := New(t.variant, cfg)
+		if err != nil {
+			return fmt.Errorf("tenant %q: %w", key, err)
+		}
+		m.Close()
+		tn.overrides = maps.Clone(overrides)
+		return nil
+	}
+
+	setter, ok := tn.model.(ParamSetter)
+	if !ok {
+		return fmt.Errorf("tenant %q: variant %q doesn't support hot parameter updates", key, t.variant)
+	}
+	current := t.config(tn.overrides)
+	changed := make(map[string]float64)
+	for name, v := range cfg.Params {
+		if old, ok := current.Params[name]; !ok || old != v {
+			changed[name] = v
+		}
+	}
+	// removed overrides fall back to the base value, or to the variant default if there is none
+	for name := range current.Params {
+		if _, ok := cfg.Params[name]; !ok {
+			return fmt.Errorf("tenant %q: parameter %q has no base value to restore", key, name)
+		}
+	}
+
+	// the live values, defaults included, are restored if any change fails
+	previous := make(map[string]float64, len(changed))
+	for name := range changed {
+		v, err := setter.Param(name)
+		if err != nil {
+			return fmt.Errorf("tenant %q: %w", key, err)
+		}
+		previous[name] = v
+	}
+	for name, v := range changed {
+		if err := setter.SetParam(name, v); err != nil {
+			for name, v := range previous {
+				setter.SetParam(name, v)
+			}
+			return fmt.Errorf("tenant %q: %w", key, err)
+		}
+	}
+	tn.overrides = maps.Clone(overrides)
+	return nil
+}
+
+// Close releases all the models.
+func (t *Tenants) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, tn := range t.tenants {
+		tn.mu.Lock()
+		if tn.model != nil {
+			tn.model.Close()
+			tn.model = nil
+		}
+		tn.mu.Unlock()
+	}
+}
+
+// AdminHandler returns the admin API managing the overrides:
+//
+//	GET /tenants/{key}  returns the effective config of the key, 404 if the key is unknown
+//	PUT /tenants/{key}  replaces the overrides of the key with the JSON object in the body, e.g.: {"rho": 0.9}
+func (t *Tenants) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tenants/{key}", func(w http.ResponseWriter, r *http.Request) {
+		key := r.PathValue("key")
+		if t.lookup(key) == nil {
+			http.Error(w, fmt.Sprintf("tenant %q not found", key), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(t.Config(key))
+	})
+	mux.HandleFunc("PUT /tenants/{key}", func(w http.ResponseWriter, r *http.Request) {
+		var overrides map[string]float64
+		if err := json.NewDecoder(r.Body).Decode(&overrides); err != nil {
+			http.Error(w, "invalid overrides: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		key := r.PathValue("key")
+		if err := t.SetOverrides(key, overrides); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(t.Config(key))
+	})
+	return mux
+}
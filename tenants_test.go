@@ -0,0 +1,109 @@
+package art
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTenantsOverrides(t *testing.T) {
+	tenants, err := NewTenants("fuzzy", Config{InputLen: 4, Params: map[string]float64{"rho": 0.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tenants.Close()
+
+	if err := tenants.SetOverrides("strict", map[string]float64{"rho": 0.95}); err != nil {
+		t.Fatal(err)
+	}
+
+	categories := func(key string) int {
+		var n int
+		tenants.Use(key, func(m Model) error {
+			for _, v := range []float64{0.1, 0.2, 0.3} {
+				m.Fit([]float64{v, v, v, v})
+			}
+			n = len(m.(*FuzzyART).w)
+			return nil
+		})
+		return n
+	}
+	if n := categories("default"); n != 1 {
+		t.Errorf("base vigilance should give 1 category, got %d", n)
+	}
+	if n := categories("strict"); n != 3 {
+		t.Errorf("overridden vigilance should give 3 categories, got %d", n)
+	}
+
+	// hot update through the admin API
+	server := httptest.NewServer(tenants.AdminHandler())
+	defer server.Close()
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/tenants/default", strings.NewReader(`{"rho": 0.99}`))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %s", resp.Status)
+	}
+	if rho := tenants.Config("default").Params["rho"]; rho != 0.99 {
+		t.Errorf("expected rho 0.99, got %f", rho)
+	}
+	tenants.Use("default", func(m Model) error {
+		if rho := m.(*FuzzyART).rho; rho != 0.99 {
+			t.Errorf("live model should use rho 0.99, got %f", rho)
+		}
+		return nil
+	})
+
+	if err := tenants.SetOverrides("default", map[string]float64{"rho": 2}); err == nil {
+		t.Error("invalid overrides should be rejected")
+	}
+}
+
+func TestTenantsOverridesRestoreDefaults(t *testing.T) {
+	tenants, err := NewTenants("fuzzy", Config{InputLen: 4, Params: map[string]float64{"rho": 0.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tenants.Close()
+	tenants.Use("key", func(m Model) error { return nil })
+
+	// max_categories has no base value, it must fall back to the live default whatever the map order
+	for range 20 {
+		if err := tenants.SetOverrides("key", map[string]float64{"max_categories": 5, "rho": 2}); err == nil {
+			t.Fatal("invalid overrides should be rejected")
+		}
+		tenants.Use("key", func(m Model) error {
+			f := m.(*FuzzyART)
+			if f.maxCategories != 0 || f.rho != 0.5 {
+				t.Errorf("failed update should leave the model unchanged, got max_categories %d and rho %f", f.maxCategories, f.rho)
+			}
+			return nil
+		})
+	}
+}
+
+func TestTenantsAdminUnknownKey(t *testing.T) {
+	tenants, err := NewTenants("fuzzy", Config{InputLen: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tenants.Close()
+
+	server := httptest.NewServer(tenants.AdminHandler())
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/tenants/unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404, got %s", resp.Status)
+	}
+	if tenants.lookup("unknown") != nil {
+		t.Error("a GET should not create the tenant")
+	}
+}
@@ -0,0 +1,92 @@
+package art
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// TieBreak selects which category wins among categories with equal activations.
+// Ties are common with binary-ish data, and the policy can materially change the results.
+type TieBreak int
+
+const (
+	// TieBreakOldest gives the priority to the oldest category, the default.
+	TieBreakOldest TieBreak = iota
+	// TieBreakLargest gives the priority to the category that learned the most samples.
+	TieBreakLargest
+	// TieBreakSmallestNorm gives the priority to the category with the smallest weights norm,
+	// the largest hyper-rectangle.
+	TieBreakSmallestNorm
+	// TieBreakRandom breaks ties randomly, reproducible with WithSeed.
+	TieBreakRandom
+)
+
+func (t TieBreak) String() string {
+	switch t {
+	case TieBreakOldest:
+		return "oldest"
+	case TieBreakLargest:
+		return "largest"
+	case TieBreakSmallestNorm:
+		return "smallest-norm"
+	case TieBreakRandom:
+		return "random"
+	}
+	return fmt.Sprintf("TieBreak(%d)", int(t))
+}
+
+// WithTieBreak sets the policy choosing among categories with equal activations.
+// Remaining ties (e.g.: categories with the same sample count) go to the oldest category.
+// PredictWithin always gives the priority to the oldest category.
+func WithTieBreak(policy TieBreak) Option {
+	return func(f *fuzzyState) error {
+		if policy < TieBreakOldest || policy > TieBreakRandom {
+			return fmt.Errorf("unknown tie-break policy %d", policy)
+		}
+		f.tieBreak = policy
+		return nil
+	}
+}
+
+// WithSeed seeds the random source of the randomized features (e.g.: TieBreakRandom),
+// so that runs can be reproduced. The default seed is 0.
+func WithSeed(seed uint64) Option {
+	return func(f *fuzzyState) error {
+		f.seed = seed
+		f.rng = nil
+		return nil
+	}
+}
+
+// random returns the model random source.
+func (f *FuzzyARTOf[T]) random() *rand.Rand {
+	if f.rng == nil {
+		f.rng = rand.New(rand.NewPCG(f.seed, f.seed))
+	}
+	return f.rng
+}
+
+// breakTie compares two activations with equal values according to the tie-break policy,
+// it returns 0 when the policy doesn't prefer any of the two.
+func (f *FuzzyARTOf[T]) breakTie(a, b *fuzzyActivation[T]) int {
+	switch f.tieBreak {
+	case TieBreakLargest:
+		return f.categories[b.j].count - f.categories[a.j].count
+	case TieBreakSmallestNorm:
+		wa, wb := f.categories[a.j].wNorm, f.categories[b.j].wNorm
+		if wa < wb {
+			return -1
+		}
+		if wa > wb {
+			return 1
+		}
+	case TieBreakRandom:
+		if a.tieKey < b.tieKey {
+			return -1
+		}
+		if a.tieKey > b.tieKey {
+			return 1
+		}
+	}
+	return 0
+}
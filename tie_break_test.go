@@ -0,0 +1,49 @@
+package art
+
+import "testing"
+
+func TestTieBreak(t *testing.T) {
+	centroid := []float64{0.5, 0.5, 0.5, 0.5}
+	newModel := func(opts ...Option) *FuzzyART {
+		model, err := NewFuzzyART(4, 0.9, 0.01, 1, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// identical point categories, always tied
+		if err := model.ImportClusters([][]float64{centroid, centroid, centroid}, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		model.categories[1].count = 5
+		return model
+	}
+
+	for policy, want := range map[TieBreak]int{TieBreakOldest: 0, TieBreakLargest: 1, TieBreakSmallestNorm: 0} {
+		model := newModel(WithTieBreak(policy))
+		if _, j := model.Predict(centroid, false); j != want {
+			t.Errorf("%s: expected category %d, got %d", policy, want, j)
+		}
+		model.Close()
+	}
+
+	// same seed, same choices
+	winners := func(seed uint64) (w []int) {
+		model := newModel(WithTieBreak(TieBreakRandom), WithSeed(seed))
+		defer model.Close()
+		for range 20 {
+			_, j := model.Predict(centroid, false)
+			w = append(w, j)
+		}
+		return w
+	}
+	a, b := winners(42), winners(42)
+	seen := make(map[int]bool)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("random tie-break is not reproducible: %v != %v", a, b)
+		}
+		seen[a[i]] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("random tie-break should pick different categories, got %v", a)
+	}
+}
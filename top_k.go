@@ -0,0 +1,48 @@
+package art
+
+import (
+	"time"
+)
+
+// CategoryScore holds the choice function (activation) and the match (resonance) values of a category for an input.
+type CategoryScore struct {
+	Category   int
+	Activation float64
+	Resonance  float64
+}
+
+// PredictTopK works like Predict without learning, but returns the k categories with the highest activations,
+// the winner first, as ordered by Predict (tie-break policy included).
+// Fewer categories are returned if the model has less than k.
+func (f *FuzzyARTOf[T]) PredictTopK(a []float64, k int) []CategoryScore {
+	if k <= 0 || len(f.w) == 0 {
+		return nil
+	}
+	if f.latency != nil {
+		defer f.latency.predict.observe(time.Now())
+	}
+	A := f.complementCode(a)
+	f.activateCategories(A)
+	aNorm := f.inputNorm(A)
+
+	scores := make([]CategoryScore, min(k, len(f.t)))
+	for i := range scores {
+		t := f.t[i]
+		scores[i] = CategoryScore{Category: t.j, Activation: t.activation, Resonance: f.normalizedActivation(t.fiNorm, aNorm)}
+	}
+	return scores
+}
+
+// Activations returns the scores of every category for the input, indexed by category,
+// to implement custom decision rules. The model doesn't learn the input.
+func (f *FuzzyARTOf[T]) Activations(a []float64) []CategoryScore {
+	A := f.complementCode(a)
+	f.activateCategories(A)
+	aNorm := f.inputNorm(A)
+
+	scores := make([]CategoryScore, len(f.t))
+	for _, t := range f.t {
+		scores[t.j] = CategoryScore{Category: t.j, Activation: t.activation, Resonance: f.normalizedActivation(t.fiNorm, aNorm)}
+	}
+	return scores
+}
@@ -0,0 +1,80 @@
+package art
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPredictTopK(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	if scores := model.PredictTopK([]float64{0.1, 0.2, 0.3, 0.4}, 3); len(scores) != 0 {
+		t.Errorf("expected no scores from an empty model, got %v", scores)
+	}
+
+	rng := rand.New(rand.NewPCG(5, 6))
+	sample := func() []float64 {
+		return []float64{rng.Float64(), rng.Float64(), rng.Float64(), rng.Float64()}
+	}
+	for range 200 {
+		model.Fit(sample())
+	}
+	if len(model.w) < 5 {
+		t.Fatalf("expected at least 5 categories, got %d", len(model.w))
+	}
+
+	for range 50 {
+		a := sample()
+		resonance, j := model.Predict(a, false)
+		scores := model.PredictTopK(a, 5)
+		if len(scores) != 5 {
+			t.Fatalf("expected 5 scores, got %d", len(scores))
+		}
+		if scores[0].Category != j || scores[0].Resonance != resonance {
+			t.Errorf("expected the winner %d (%f) first, got %+v", j, resonance, scores[0])
+		}
+		for i := 1; i < len(scores); i++ {
+			if scores[i].Activation > scores[i-1].Activation {
+				t.Errorf("scores not sorted by activation: %+v", scores)
+			}
+		}
+	}
+
+	if scores := model.PredictTopK(sample(), len(model.w)+10); len(scores) != len(model.w) {
+		t.Errorf("expected all the %d categories, got %d", len(model.w), len(scores))
+	}
+}
+
+func TestActivations(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range [][]float64{{0.1, 0.1, 0.1, 0.1}, {0.9, 0.9, 0.9, 0.9}, {0.1, 0.9, 0.1, 0.9}} {
+		model.Fit(a)
+	}
+
+	a := []float64{0.2, 0.1, 0.1, 0.1}
+	scores := model.Activations(a)
+	if len(scores) != len(model.w) {
+		t.Fatalf("expected %d scores, got %d", len(model.w), len(scores))
+	}
+	for j, s := range scores {
+		var norm float64
+		for i, v := range []float64{0.2, 0.1, 0.1, 0.1, 0.8, 0.9, 0.9, 0.9} {
+			norm += min(v, model.w[j][i])
+		}
+		if s.Category != j || math.Abs(s.Resonance-norm/4) > 1e-12 || math.Abs(s.Activation-norm/(0.01+model.categories[j].wNorm)) > 1e-12 {
+			t.Errorf("category %d: unexpected score %+v", j, s)
+		}
+	}
+	resonance, j := model.Predict(a, false)
+	if resonance != scores[j].Resonance {
+		t.Errorf("expected the resonance %f of the winner, got %f", resonance, scores[j].Resonance)
+	}
+}
@@ -0,0 +1,211 @@
+package art
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// TopoART implements a single-module TopoART (Tscherepanow, 2010) on top of FuzzyART.
+// For every input the best and the second best resonating categories are learned,
+// the latter with the smaller learning rate betaSBM, and an edge connecting them is created.
+// The resulting graph describes the topology of the data: connected categories belong to the same cluster.
+// Every tau samples the categories that learned less than phi samples are removed as noise.
+type TopoART struct {
+	fuzzy *FuzzyART
+
+	// Learning rate of the second best matching category
+	// Range: 0.0 to beta
+	betaSBM float64
+
+	// Minimum number of samples a category must learn to survive the noise cleanup.
+	phi int
+
+	// Number of samples between two noise cleanups.
+	tau int
+
+	// counts stores the number of samples learned by each category
+	counts []int
+
+	// edges stores the topology graph, as pairs of category indexes [i, j] with i < j
+	edges map[[2]int]struct{}
+
+	samples int
+}
+
+func NewTopoART(inputLen int, rho, alpha, beta, betaSBM float64, phi, tau int) (*TopoART, error) {
+	fuzzy, err := NewFuzzyART(inputLen, rho, alpha, beta)
+	if err != nil {
+		return nil, err
+	}
+	if betaSBM <= 0 || betaSBM > beta {
+		return nil, fmt.Errorf("second best learning rate (betaSBM) must be between 0 and beta, got %f", betaSBM)
+	}
+	if phi < 1 {
+		return nil, fmt.Errorf("noise threshold (phi) must be at least 1, got %d", phi)
+	}
+	if tau < 1 {
+		return nil, fmt.Errorf("cleanup period (tau) must be at least 1, got %d", tau)
+	}
+
+	return &TopoART{
+		fuzzy:   fuzzy,
+		betaSBM: betaSBM,
+		phi:     phi,
+		tau:     tau,
+		counts:  make([]int, 0),
+		edges:   make(map[[2]int]struct{}),
+	}, nil
+}
+
+func edge(i, j int) [2]int {
+	if i > j {
+		i, j = j, i
+	}
+	return [2]int{i, j}
+}
+
+// Fit learns the input on the best and second best resonating categories and connects them.
+// Category indexes are stable until the next noise cleanup, which happens every tau samples.
+func (t *TopoART) Fit(a []float64) (categoryActivation float64, categoryIndex int) {
+	f := t.fuzzy
+	A := f.learningInput(a)
+	f.activateCategories(A)
+	aNorm := f.inputNorm(A)
+
+	best := -1
+	var maxResonance float64
+	for _, act := range f.t {
+		resonance := f.normalizedActivation(act.fiNorm, aNorm)
+		if resonance < f.rho {
+			maxResonance = math.Max(maxResonance, resonance)
+			continue
+		}
+
+		if best == -1 {
+			f.learnInput(act.j, A, act.fi, f.beta)
+			best, categoryActivation = act.j, resonance
+			continue
+		}
+
+		f.learnInput(act.j, A, act.fi, t.betaSBM)
+		t.edges[edge(best, act.j)] = struct{}{}
+		break
+	}
+
+	if best == -1 {
+		best = f.appendNewCategory(A)
+		t.counts = append(t.counts, 0)
+		categoryActivation = maxResonance
+	}
+	t.counts[best]++
+
+	t.samples++
+	if t.samples%t.tau == 0 {
+		// the category just learned could be removed as noise, in which case best becomes -1
+		best = t.cleanup()[best]
+	}
+
+	return categoryActivation, best
+}
+
+// cleanup removes the noise categories and their edges, and returns the index remapping.
+func (t *TopoART) cleanup() (remap []int) {
+	remap = t.fuzzy.compact(func(j int) bool {
+		return t.counts[j] >= t.phi
+	})
+
+	n := 0
+	for j, c := range t.counts {
+		if remap[j] != -1 {
+			t.counts[n] = c
+			n++
+		}
+	}
+	t.counts = t.counts[:n]
+
+	edges := make(map[[2]int]struct{}, len(t.edges))
+	for e := range t.edges {
+		i, j := remap[e[0]], remap[e[1]]
+		if i != -1 && j != -1 {
+			edges[edge(i, j)] = struct{}{}
+		}
+	}
+	t.edges = edges
+	return remap
+}
+
+// Predict returns the resonance and the index of the best matching category,
+// or -1 if the model has no categories yet.
+// If learn is true, it works like Fit.
+func (t *TopoART) Predict(a []float64, learn bool) (categoryActivation float64, categoryIndex int) {
+	if learn {
+		return t.Fit(a)
+	}
+	if len(t.fuzzy.w) == 0 {
+		return 0, -1
+	}
+	return t.fuzzy.Predict(a, false)
+}
+
+// Edges returns the edges of the topology graph as pairs of category indexes [i, j], with i < j,
+// sorted by i and then by j.
+func (t *TopoART) Edges() [][2]int {
+	edges := make([][2]int, 0, len(t.edges))
+	for e := range t.edges {
+		edges = append(edges, e)
+	}
+	slices.SortFunc(edges, func(a, b [2]int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	})
+	return edges
+}
+
+// Clusters returns the cluster of every category, indexed by category.
+// Clusters are the connected components of the topology graph,
+// numbered in order of their oldest category.
+func (t *TopoART) Clusters() []int {
+	adjacency := make([][]int, len(t.counts))
+	for e := range t.edges {
+		adjacency[e[0]] = append(adjacency[e[0]], e[1])
+		adjacency[e[1]] = append(adjacency[e[1]], e[0])
+	}
+
+	clusters := make([]int, len(t.counts))
+	for j := range clusters {
+		clusters[j] = -1
+	}
+
+	cluster := 0
+	for j := range clusters {
+		if clusters[j] != -1 {
+			continue
+		}
+		stack := []int{j}
+		clusters[j] = cluster
+		for len(stack) > 0 {
+			k := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, n := range adjacency[k] {
+				if clusters[n] == -1 {
+					clusters[n] = cluster
+					stack = append(stack, n)
+				}
+			}
+		}
+		cluster++
+	}
+	return clusters
+}
+
+// CategoryCount returns the number of categories.
+func (t *TopoART) CategoryCount() int {
+	return len(t.counts)
+}
+
+func (t *TopoART) Close() {
+	t.fuzzy.Close()
+}
@@ -0,0 +1,92 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTopoART(t *testing.T) {
+	tests := []struct {
+		name     string
+		tau      int
+		want     []int
+		edges    [][2]int
+		clusters []int
+	}{
+		// 0.16 resonates with the categories of 0.1 and 0.25, which are connected
+		{"no cleanup", 100, []int{0, 1, 0, 2}, [][2]int{{0, 1}}, []int{0, 0, 1}},
+		// the cleanup after the fourth input removes the categories that learned a single sample, the last one included
+		{"cleanup", 4, []int{0, 1, 0, -1}, [][2]int{}, []int{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := NewTopoART(1, 0.9, 0.01, 1, 0.5, 2, tt.tau)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer model.Close()
+
+			for i, a := range []float64{0.1, 0.25, 0.16, 0.9} {
+				if _, j := model.Fit([]float64{a}); j != tt.want[i] {
+					t.Errorf("input %f: expected category %d, got %d", a, tt.want[i], j)
+				}
+			}
+			if got := model.Edges(); !slices.Equal(got, tt.edges) {
+				t.Errorf("expected edges %v, got %v", tt.edges, got)
+			}
+			if got := model.Clusters(); !slices.Equal(got, tt.clusters) {
+				t.Errorf("expected clusters %v, got %v", tt.clusters, got)
+			}
+			if model.CategoryCount() != len(tt.clusters) {
+				t.Errorf("expected %d categories, got %d", len(tt.clusters), model.CategoryCount())
+			}
+		})
+	}
+}
+
+func TestTopoARTSecondBestLearning(t *testing.T) {
+	model, err := NewTopoART(1, 0.9, 0.01, 1, 0.5, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	for _, a := range []float64{0.1, 0.25, 0.16} {
+		model.Fit([]float64{a})
+	}
+
+	// the best category learns the input with beta, the second best with betaSBM
+	for j, want := range [][]float64{{0.1, 0.84}, {0.205, 0.75}} {
+		w := model.fuzzy.w[j]
+		for i := range want {
+			if d := w[i] - want[i]; d > 1e-12 || d < -1e-12 {
+				t.Errorf("category %d: expected weights %v, got %v", j, want, w)
+				break
+			}
+		}
+	}
+
+	if _, j := model.Predict([]float64{0.12}, false); j != 0 {
+		t.Errorf("expected category 0, got %d", j)
+	}
+	if model.CategoryCount() != 2 {
+		t.Errorf("Predict without learning must not create categories, got %d", model.CategoryCount())
+	}
+}
+
+func TestNewTopoARTErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		betaSBM  float64
+		phi, tau int
+	}{
+		{"betaSBM above beta", 0.9, 1, 1},
+		{"zero betaSBM", 0, 1, 1},
+		{"phi", 0.1, 0, 1},
+		{"tau", 0.1, 1, 0},
+	}
+	for _, tt := range tests {
+		if _, err := NewTopoART(1, 0.9, 0.01, 0.5, tt.betaSBM, tt.phi, tt.tau); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !unix
+
+package tui
+
+import (
+	"io"
+	"os"
+)
+
+// keyReader reads the keys from a file without owning it.
+// Without a way to interrupt the read, the reader ends at the next key after Close.
+type keyReader struct {
+	io.Reader
+}
+
+func newKeyReader(f *os.File) (*keyReader, error) {
+	return &keyReader{f}, nil
+}
+
+// Close is a no-op, the file is not closed.
+func (r *keyReader) Close() error {
+	return nil
+}
@@ -0,0 +1,77 @@
+//go:build unix
+
+package tui
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// keyReader reads the keys from the descriptor of a file without owning it:
+// Close wakes up a pending read through a cancel pipe, and leaves the file open.
+type keyReader struct {
+	fd int
+	// cancel is a pipe, closing its write end wakes up the poll of Read
+	cancel [2]int
+
+	// mu is held by Read, so that Close frees the pipe once Read returned
+	mu     sync.Mutex
+	closed bool
+}
+
+func newKeyReader(f *os.File) (*keyReader, error) {
+	r := &keyReader{fd: int(f.Fd())}
+	if err := unix.Pipe(r.cancel[:]); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *keyReader) Read(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return 0, io.EOF
+	}
+
+	fds := []unix.PollFd{
+		{Fd: int32(r.fd), Events: unix.POLLIN},
+		{Fd: int32(r.cancel[0]), Events: unix.POLLIN},
+	}
+	for {
+		if _, err := unix.Poll(fds, -1); err != nil {
+			if err == unix.EINTR {
+				continue
+			}
+			return 0, err
+		}
+		if fds[1].Revents != 0 {
+			return 0, io.EOF
+		}
+		if fds[0].Revents == 0 {
+			continue
+		}
+		n, err := unix.Read(r.fd, b)
+		switch {
+		case err == unix.EAGAIN || err == unix.EINTR:
+			continue
+		case err != nil:
+			return 0, err
+		case n == 0:
+			return 0, io.EOF
+		}
+		return n, nil
+	}
+}
+
+// Close stops the reader, the file is not closed.
+func (r *keyReader) Close() error {
+	unix.Close(r.cancel[1])
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return unix.Close(r.cancel[0])
+}
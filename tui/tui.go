@@ -0,0 +1,330 @@
+// Package tui provides an interactive terminal UI for long training runs.
+// It shows the training progress and a sparkline of the category growth,
+// and lets the user pause, checkpoint, adjust the vigilance or stop gracefully.
+//
+// Keybindings:
+//
+//	p, space   pause/resume
+//	c          checkpoint
+//	+, -       increase/decrease rho
+//	q          stop after the current sample
+//
+// It's built on golang.org/x/term rather than a TUI framework such as bubbletea or tcell:
+// a few lines redrawn in place don't need one, and the module keeps its dependencies minimal.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// RhoStep is the vigilance change applied by the + and - keys.
+const RhoStep = 0.01
+
+// Session describes a training run driven by the TUI.
+type Session struct {
+	// Total is the number of samples to train, 0 if unknown.
+	Total int
+
+	// Step trains the next sample and returns the current number of categories.
+	// Returning done stops the session.
+	Step func() (categories int, done bool, err error)
+
+	// Checkpoint, if not nil, is called when the user presses c.
+	Checkpoint func() error
+
+	// AdjustRho, if not nil, is called with +RhoStep or -RhoStep
+	// and returns the new vigilance value.
+	AdjustRho func(delta float64) (rho float64, err error)
+
+	// In and Out default to os.Stdin and os.Stdout.
+	// When In is a terminal it's put in raw mode for the duration of the session.
+	// In is not closed by Run.
+	In  *os.File
+	Out io.Writer
+
+	// RefreshInterval defaults to 200ms.
+	RefreshInterval time.Duration
+
+	// SparklineWidth is the number of category samples shown, it defaults to 60.
+	SparklineWidth int
+}
+
+type command byte
+
+const (
+	cmdPause      command = 'p'
+	cmdCheckpoint command = 'c'
+	cmdRhoUp      command = '+'
+	cmdRhoDown    command = '-'
+	cmdQuit       command = 'q'
+)
+
+type state struct {
+	samples    int
+	categories int
+	paused     bool
+	rho        float64
+	rhoKnown   bool
+	message    string
+	history    []int
+	startTime  time.Time
+	lines      int
+}
+
+// ErrStopped is returned by Run when the user stopped the session.
+var ErrStopped = errors.New("tui: stopped by user")
+
+// Run drives the session until Step reports done, the user stops it or the context is cancelled.
+func Run(ctx context.Context, s Session) error {
+	if s.Step == nil {
+		return errors.New("tui: Step is required")
+	}
+	if s.In == nil {
+		s.In = os.Stdin
+	}
+	if s.Out == nil {
+		s.Out = os.Stdout
+	}
+	if s.RefreshInterval <= 0 {
+		s.RefreshInterval = 200 * time.Millisecond
+	}
+	if s.SparklineWidth <= 0 {
+		s.SparklineWidth = 60
+	}
+
+	keys, err := newKeyReader(s.In)
+	if err != nil {
+		return fmt.Errorf("tui: %w", err)
+	}
+	// closing keys unblocks a pending read, and stopKeys a pending send
+	stopKeys := make(chan struct{})
+	defer func() {
+		close(stopKeys)
+		keys.Close()
+	}()
+
+	fd := int(s.In.Fd())
+	if term.IsTerminal(fd) {
+		oldState, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("tui: %w", err)
+		}
+		defer term.Restore(fd, oldState)
+	}
+
+	commands := make(chan command, 8)
+	go readKeys(keys, commands, stopKeys)
+
+	fmt.Fprint(s.Out, "\x1b[?25l")
+	defer fmt.Fprint(s.Out, "\x1b[?25h\r\n")
+
+	st := &state{startTime: time.Now()}
+	lastRender := time.Time{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return cancel(ctx, s, st)
+		case cmd := <-commands:
+			if err := apply(s, st, cmd); err != nil {
+				return err
+			}
+			continue
+		default:
+		}
+
+		// wait for the next keys without spinning until resumed
+		for st.paused {
+			select {
+			case <-ctx.Done():
+				return cancel(ctx, s, st)
+			case cmd := <-commands:
+				if err := apply(s, st, cmd); err != nil {
+					return err
+				}
+			}
+		}
+
+		categories, done, err := s.Step()
+		if err != nil {
+			st.message = err.Error()
+			render(s, st)
+			return err
+		}
+		st.samples++
+		st.categories = categories
+
+		if done || time.Since(lastRender) >= s.RefreshInterval {
+			st.history = append(st.history, categories)
+			if len(st.history) > s.SparklineWidth {
+				st.history = st.history[len(st.history)-s.SparklineWidth:]
+			}
+			render(s, st)
+			lastRender = time.Now()
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// apply handles a command and renders it, it returns ErrStopped if the user stopped the session.
+func apply(s Session, st *state, cmd command) error {
+	if stop := handle(s, st, cmd); stop {
+		st.message = "stopped"
+		render(s, st)
+		return ErrStopped
+	}
+	render(s, st)
+	return nil
+}
+
+func cancel(ctx context.Context, s Session, st *state) error {
+	st.message = "cancelled"
+	render(s, st)
+	return ctx.Err()
+}
+
+func readKeys(in io.Reader, commands chan<- command, stop <-chan struct{}) {
+	buf := make([]byte, 1)
+	for {
+		if _, err := in.Read(buf); err != nil {
+			return
+		}
+		cmd := command(buf[0])
+		switch buf[0] {
+		case ' ':
+			cmd = cmdPause
+		case '=':
+			cmd = cmdRhoUp
+		case 3: // Ctrl-C in raw mode
+			cmd = cmdQuit
+		}
+		select {
+		case commands <- cmd:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// handle applies a command and reports whether the session must stop.
+func handle(s Session, st *state, cmd command) bool {
+	switch cmd {
+	case cmdPause:
+		st.paused = !st.paused
+		if st.paused {
+			st.message = "paused"
+		} else {
+			st.message = "resumed"
+		}
+	case cmdCheckpoint:
+		if s.Checkpoint == nil {
+			st.message = "checkpoint not available"
+		} else if err := s.Checkpoint(); err != nil {
+			st.message = "checkpoint failed: " + err.Error()
+		} else {
+			st.message = fmt.Sprintf("checkpoint saved at sample %d", st.samples)
+		}
+	case cmdRhoUp, cmdRhoDown:
+		if s.AdjustRho == nil {
+			st.message = "rho adjustment not available"
+			break
+		}
+		delta := RhoStep
+		if cmd == cmdRhoDown {
+			delta = -RhoStep
+		}
+		rho, err := s.AdjustRho(delta)
+		if err != nil {
+			st.message = err.Error()
+			break
+		}
+		st.rho, st.rhoKnown = rho, true
+		st.message = fmt.Sprintf("rho set to %.3f", rho)
+	case cmdQuit:
+		return true
+	}
+	return false
+}
+
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values scaled between their minimum and maximum.
+func sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = (v - lo) * (len(sparkChars) - 1) / (hi - lo)
+		}
+		sb.WriteRune(sparkChars[i])
+	}
+	return sb.String()
+}
+
+func progress(s Session, st *state) string {
+	elapsed := time.Since(st.startTime)
+	rate := float64(st.samples) / max(elapsed.Seconds(), 1e-9)
+	if s.Total <= 0 {
+		return fmt.Sprintf("%d samples, %.0f it/s, %s", st.samples, rate, elapsed.Round(time.Second))
+	}
+
+	const width = 40
+	filled := min(width, width*st.samples/s.Total)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	var eta time.Duration
+	if st.samples > 0 {
+		eta = time.Duration(float64(elapsed) * float64(s.Total-st.samples) / float64(st.samples))
+	}
+	return fmt.Sprintf("%3d%% [%s] (%d/%d, %.0f it/s) | ETA: %s",
+		100*st.samples/s.Total, bar, st.samples, s.Total, rate, eta.Round(time.Second))
+}
+
+func render(s Session, st *state) {
+	lines := []string{
+		"Training " + progress(s, st),
+		fmt.Sprintf("Categories: %d  %s", st.categories, sparkline(st.history)),
+	}
+	status := "running"
+	if st.paused {
+		status = "paused"
+	}
+	if st.rhoKnown {
+		status += fmt.Sprintf(" | rho %.3f", st.rho)
+	}
+	if st.message != "" {
+		status += " | " + st.message
+	}
+	lines = append(lines, status, "[p] pause  [c] checkpoint  [+/-] rho  [q] stop")
+
+	var sb strings.Builder
+	if st.lines > 0 {
+		// move the cursor back to the first line of the previous frame
+		fmt.Fprintf(&sb, "\x1b[%dF", st.lines-1)
+	}
+	for i, line := range lines {
+		sb.WriteString("\r\x1b[2K")
+		sb.WriteString(line)
+		if i < len(lines)-1 {
+			sb.WriteString("\r\n")
+		}
+	}
+	st.lines = len(lines)
+	fmt.Fprint(s.Out, sb.String())
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// keys returns a reader that delivers the given keys.
+func keys(t *testing.T, input string) *os.File {
+	t.Helper()
+	r, _ := pipe(t, input)
+	return r
+}
+
+// pipe returns a pipe with the given keys written in it.
+func pipe(t *testing.T, input string) (r, w *os.File) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		r.Close()
+		w.Close()
+	})
+	if _, err := io.WriteString(w, input); err != nil {
+		t.Fatal(err)
+	}
+	return r, w
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		total       int
+		err         error
+		checkpoints int
+	}{
+		{"done", "", 5, nil, 0},
+		{"quit", "q", 0, ErrStopped, 0},
+		{"ctrl-c", "\x03", 0, ErrStopped, 0},
+		// more commands than the channel buffer while paused
+		{"paused commands", "p" + strings.Repeat("c", 20) + "q", 0, ErrStopped, 20},
+		{"resume", "p pq", 0, ErrStopped, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkpoints, samples := 0, 0
+			s := Session{
+				Total: tt.total,
+				Step: func() (int, bool, error) {
+					samples++
+					return samples, tt.total > 0 && samples == tt.total, nil
+				},
+				Checkpoint: func() error {
+					checkpoints++
+					return nil
+				},
+				In:  keys(t, tt.input),
+				Out: io.Discard,
+			}
+			if err := Run(context.Background(), s); !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if checkpoints != tt.checkpoints {
+				t.Errorf("expected %d checkpoints, got %d", tt.checkpoints, checkpoints)
+			}
+			if tt.total > 0 && samples != tt.total {
+				t.Errorf("expected %d samples, got %d", tt.total, samples)
+			}
+		})
+	}
+}
+
+func TestRunPausedCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	paused := make(chan struct{})
+	s := Session{
+		Step: func() (int, bool, error) { return 1, false, nil },
+		AdjustRho: func(delta float64) (float64, error) {
+			// the + key comes after the pause, so the session is paused now
+			close(paused)
+			return 0.5 + delta, nil
+		},
+		In:  keys(t, "p+"),
+		Out: io.Discard,
+	}
+	go func() {
+		<-paused
+		cancel()
+	}()
+	if err := Run(ctx, s); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestRunStopsReadingKeys(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for range 10 {
+		s := Session{
+			Step: func() (int, bool, error) { return 1, true, nil },
+			// keys left unread when the session ends
+			In:  keys(t, strings.Repeat("c", 20)),
+			Out: io.Discard,
+		}
+		if err := Run(context.Background(), s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d goroutines, got %d", before, runtime.NumGoroutine())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRunKeepsInOpen(t *testing.T) {
+	r, w := pipe(t, "")
+	s := Session{
+		Step: func() (int, bool, error) { return 1, true, nil },
+		In:   r,
+		Out:  io.Discard,
+	}
+	if err := Run(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+
+	// the key reader is gone, and the keys typed after the session go to the caller
+	if _, err := io.WriteString(w, "x"); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1)
+	if _, err := r.Read(buf); err != nil || buf[0] != 'x' {
+		t.Errorf("expected to read x from In, got %q, %v", buf, err)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   string
+	}{
+		{nil, ""},
+		{[]int{3, 3}, "▁▁"},
+		{[]int{0, 7}, "▁█"},
+		{[]int{0, 1, 2, 3, 4, 5, 6, 7}, "▁▂▃▄▅▆▇█"},
+	}
+	for _, tt := range tests {
+		if got := sparkline(tt.values); got != tt.want {
+			t.Errorf("sparkline(%v): expected %q, got %q", tt.values, tt.want, got)
+		}
+	}
+}
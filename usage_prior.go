@@ -0,0 +1,29 @@
+package art
+
+import (
+	"fmt"
+	"math"
+)
+
+// WithUsagePrior weights the choice function by the number of samples learned by every category:
+// T_j = |A∧w_j| / (alpha + |w_j|) * (1 + strength * ln(n_j)).
+// On imbalanced data it keeps tiny spurious categories from winning ties
+// (or near ties) against well supported ones. The vigilance test is not affected.
+// Recommended value: 0.01 to 0.1, 0 disables the prior.
+func WithUsagePrior(strength float64) Option {
+	return func(f *fuzzyState) error {
+		if strength < 0 {
+			return fmt.Errorf("usage prior strength must not be negative, got %f", strength)
+		}
+		f.priorStrength = strength
+		return nil
+	}
+}
+
+// usagePrior returns the choice function multiplier of category j.
+func (f *FuzzyARTOf[T]) usagePrior(j int) float64 {
+	if f.priorStrength == 0 {
+		return 1
+	}
+	return 1 + f.priorStrength*math.Log(float64(max(f.categories[j].count, 1)))
+}
@@ -0,0 +1,22 @@
+package art
+
+import "testing"
+
+func TestUsagePrior(t *testing.T) {
+	for strength, want := range map[float64]int{0: 0, 0.05: 1} {
+		model, err := NewFuzzyART(4, 0.5, 0.01, 1, WithUsagePrior(strength))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// a tiny category and a well supported one, equally close to the input
+		if err := model.ImportClusters([][]float64{{0.4, 0.4, 0.4, 0.4}, {0.6, 0.6, 0.6, 0.6}}, []int{1, 1}, nil); err != nil {
+			t.Fatal(err)
+		}
+		model.categories[1].count = 1000
+
+		if _, j := model.Predict([]float64{0.5, 0.5, 0.5, 0.5}, false); j != want {
+			t.Errorf("strength %.2f: expected category %d, got %d", strength, want, j)
+		}
+		model.Close()
+	}
+}
@@ -0,0 +1,92 @@
+package art
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoCategories is returned by TryPredict when the model has no categories to predict.
+var ErrNoCategories = errors.New("model has no categories")
+
+// InputError describes an invalid input, returned by TryFit and TryPredict.
+type InputError struct {
+	// Length is the length of the input and Expected the length expected by the model,
+	// they differ for inputs with the wrong length.
+	Length, Expected int
+	// Feature is the index of the first feature out of the [0, 1] range (NaN and Inf included),
+	// -1 for inputs with the wrong length.
+	Feature int
+	Value   float64
+	// Encoded is true when the error is about the output of the encoder, see WithEncoder.
+	Encoded bool
+}
+
+func (e *InputError) Error() string {
+	what := "input"
+	if e.Encoded {
+		what = "encoded input"
+	}
+	if e.Feature == -1 {
+		return fmt.Sprintf("%s length must be %d, got %d", what, e.Expected, e.Length)
+	}
+	return fmt.Sprintf("%s feature %d must be between 0 and 1, got %f", what, e.Feature, e.Value)
+}
+
+// validateInput returns an *InputError if a isn't a valid input of the model.
+// With an encoder, the encoded input is validated instead, and returned
+// so that it's not encoded again. Without one it returns nil.
+func (f *FuzzyARTOf[T]) validateInput(a []float64) (A []T, err error) {
+	if f.encoder == nil {
+		return nil, validateValues(a, f.M, false)
+	}
+	if A, err = f.encode(a); err != nil {
+		return nil, err
+	}
+	if err := validateValues(A, 2*f.M, true); err != nil {
+		return nil, err
+	}
+	return A, nil
+}
+
+// validateValues returns an *InputError if values aren't expected values between 0 and 1,
+// encoded tells whether they are the encoding of the input.
+func validateValues[V Float](values []V, expected int, encoded bool) error {
+	if len(values) != expected {
+		return &InputError{Length: len(values), Expected: expected, Feature: -1, Encoded: encoded}
+	}
+	for i, v := range values {
+		// NaN fails both comparisons
+		if !(v >= 0 && v <= 1) {
+			return &InputError{Length: len(values), Expected: expected, Feature: i, Value: float64(v), Encoded: encoded}
+		}
+	}
+	return nil
+}
+
+// TryFit works like Fit, but returns an *InputError instead of learning an invalid input:
+// an input of the wrong length or with values out of the [0, 1] range, NaN and Inf included.
+// Fit doesn't validate its inputs, a wrong length reads out of bounds or corrupts the weights.
+func (f *FuzzyARTOf[T]) TryFit(a []float64) (categoryActivation float64, categoryIndex int, err error) {
+	A, err := f.validateInput(a)
+	if err != nil {
+		return 0, -1, err
+	}
+	categoryActivation, categoryIndex, _ = f.fitEncodedCtx(context.Background(), a, A)
+	return categoryActivation, categoryIndex, nil
+}
+
+// TryPredict works like Predict, but returns an *InputError for an invalid input, see TryFit.
+// It also returns ErrNoCategories when the model has no categories and learn is false,
+// where Predict returns the category index -1.
+func (f *FuzzyARTOf[T]) TryPredict(a []float64, learn bool) (categoryActivation float64, categoryIndex int, err error) {
+	A, err := f.validateInput(a)
+	if err != nil {
+		return 0, -1, err
+	}
+	if !learn && len(f.w) == 0 {
+		return 0, -1, ErrNoCategories
+	}
+	categoryActivation, categoryIndex, _ = f.predictEncodedCtx(context.Background(), a, A, learn)
+	return categoryActivation, categoryIndex, nil
+}
@@ -0,0 +1,99 @@
+package art
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTryFitValidation(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	if _, _, err := model.TryPredict([]float64{0.1, 0.2, 0.3, 0.4}, false); !errors.Is(err, ErrNoCategories) {
+		t.Errorf("expected ErrNoCategories, got %v", err)
+	}
+
+	for _, tc := range []struct {
+		a       []float64
+		feature int
+	}{
+		{[]float64{0.1, 0.2, 0.3}, -1},
+		{[]float64{0.1, 0.2, 0.3, 0.4, 0.5}, -1},
+		{[]float64{0.1, math.NaN(), 0.3, 0.4}, 1},
+		{[]float64{0.1, 0.2, math.Inf(1), 0.4}, 2},
+		{[]float64{0.1, 0.2, 0.3, -0.1}, 3},
+	} {
+		_, j, err := model.TryFit(tc.a)
+		var inputErr *InputError
+		if !errors.As(err, &inputErr) || inputErr.Feature != tc.feature || j != -1 {
+			t.Errorf("input %v: expected an input error on feature %d, got %v", tc.a, tc.feature, err)
+		}
+		if _, _, err := model.TryPredict(tc.a, false); !errors.As(err, &inputErr) {
+			t.Errorf("input %v: expected an input error, got %v", tc.a, err)
+		}
+	}
+	if len(model.w) != 0 {
+		t.Fatalf("expected invalid inputs not to be learned, got %d categories", len(model.w))
+	}
+
+	if _, j, err := model.TryFit([]float64{0, 0.2, 0.3, 1}); err != nil || j != 0 {
+		t.Errorf("expected a valid input to be learned, got %d (%v)", j, err)
+	}
+	if _, j, err := model.TryPredict([]float64{0, 0.2, 0.3, 1}, false); err != nil || j != 0 {
+		t.Errorf("expected category 0, got %d (%v)", j, err)
+	}
+}
+
+func TestTryFitValidatesEncodedInput(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1, WithEncoder(ComplementCoded{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	var inputErr *InputError
+	if _, _, err := model.TryFit([]float64{0.1, 0.2, 0.3, 0.4}); !errors.As(err, &inputErr) || !inputErr.Encoded || inputErr.Expected != 8 {
+		t.Errorf("expected an encoded input length error, got %v", err)
+	}
+	if _, _, err := model.TryFit([]float64{0.1, 0.2, 0.3, 0.4, 0.9, 0.8, 0.7, 0.6}); err != nil {
+		t.Errorf("expected a valid complement-coded input, got %v", err)
+	}
+}
+
+func TestTryFitEncodesOnce(t *testing.T) {
+	var calls int
+	enc := EncoderFunc(func(a []float64) []float64 {
+		calls++
+		return ComplementCoded{}.Encode(a)
+	})
+	model, err := NewFuzzyART(2, 0.9, 0.01, 1, WithEncoder(enc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+
+	a := []float64{0.1, 0.2, 0.9, 0.8}
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"TryFit", func() error { _, _, err := model.TryFit(a); return err }},
+		{"TryPredict", func() error { _, _, err := model.TryPredict(a, false); return err }},
+		{"TryPredict learning", func() error { _, _, err := model.TryPredict(a, true); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			if err := tt.call(); err != nil {
+				t.Fatal(err)
+			}
+			if calls != 1 {
+				t.Errorf("expected the input to be encoded once, got %d", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package art
+
+import "fmt"
+
+// SetCategoryVigilance sets the vigilance of category j, replacing the vigilance of the model (rho)
+// in its vigilance test, so that dense regions can use stricter matching than sparse ones,
+// e.g. with a vigilance growing with CategoryStats(j).Count. 0 restores the vigilance of the model.
+// Merged categories keep the stricter vigilance of the two, if both have one.
+// The vigilance of the categories is saved with the model.
+func (f *FuzzyARTOf[T]) SetCategoryVigilance(j int, rho float64) error {
+	if j < 0 || j >= len(f.w) {
+		return fmt.Errorf("category index must be between 0 and %d, got %d", len(f.w)-1, j)
+	}
+	if rho < 0 || rho > 1 {
+		return fmt.Errorf("category vigilance must be between 0 and 1, got %f", rho)
+	}
+	f.categories[j].rho = rho
+	return nil
+}
+
+// CategoryVigilance returns the vigilance of category j, the vigilance of the model unless set by SetCategoryVigilance.
+func (f *FuzzyARTOf[T]) CategoryVigilance(j int) float64 {
+	return f.vigilance(j)
+}
+
+// vigilance returns the vigilance threshold of category j.
+func (f *FuzzyARTOf[T]) vigilance(j int) float64 {
+	if rho := f.categories[j].rho; rho != 0 {
+		return rho
+	}
+	return f.rho
+}
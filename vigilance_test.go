@@ -0,0 +1,64 @@
+package art
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCategoryVigilance(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.8, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.1, 0.1, 0.1})
+	model.Fit([]float64{0.9, 0.9, 0.9, 0.9})
+	if err := model.SetCategoryVigilance(2, 0.9); err == nil {
+		t.Error("expected an error for a missing category")
+	}
+	if err := model.SetCategoryVigilance(0, 1.1); err == nil {
+		t.Error("expected an error with vigilance 1.1")
+	}
+
+	// resonance 0.9 passes the vigilance of the model
+	a := []float64{0.2, 0.2, 0.2, 0.2}
+	if err := model.SetCategoryVigilance(0, 0.95); err != nil {
+		t.Fatal(err)
+	}
+	if model.CategoryVigilance(0) != 0.95 || model.CategoryVigilance(1) != 0.8 {
+		t.Errorf("unexpected vigilance %f and %f", model.CategoryVigilance(0), model.CategoryVigilance(1))
+	}
+	if _, j, ok := model.PredictOrReject(a); ok {
+		t.Errorf("expected the stricter category to reject the input, got %d", j)
+	}
+
+	var buf bytes.Buffer
+	if err := model.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFuzzyART(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Close()
+	decoded, err := FromProto(model.ToProto())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Close()
+	for _, m := range []*FuzzyART{loaded, decoded, model.Clone()} {
+		if m.CategoryVigilance(0) != 0.95 || m.CategoryVigilance(1) != 0.8 {
+			t.Errorf("expected the category vigilance to be restored, got %f and %f", m.CategoryVigilance(0), m.CategoryVigilance(1))
+		}
+	}
+
+	if _, j := model.Fit(a); j != 2 {
+		t.Errorf("expected a new category, got %d", j)
+	}
+	if err := model.SetCategoryVigilance(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if model.CategoryVigilance(0) != 0.8 {
+		t.Errorf("expected the vigilance of the model, got %f", model.CategoryVigilance(0))
+	}
+}
@@ -0,0 +1,28 @@
+package art
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// FitWeighted works like Fit, scaling the learning rate of the input by its weight:
+// the resonating category learns with rate min(beta * weight, 1), so that e.g. the samples of a rare class
+// (weighted by the inverse of their frequency) move the categories as much as those of a frequent one.
+// New categories are committed as by Fit, whatever the weight, which must be positive and finite.
+// With fast learning (beta = 1) only weights below 1 have an effect.
+func (f *FuzzyARTOf[T]) FitWeighted(a []float64, weight float64) (categoryActivation float64, categoryIndex int) {
+	if !(weight > 0) || math.IsInf(weight, 0) {
+		panic(fmt.Sprintf("sample weight must be positive and finite, got %f", weight))
+	}
+	if f.latency != nil {
+		defer f.latency.fit.observe(time.Now())
+	}
+	if f.frozen {
+		categoryActivation, categoryIndex, _ = f.predictCtx(context.Background(), a, nil)
+		return categoryActivation, categoryIndex
+	}
+	categoryActivation, categoryIndex, _ = f.learnCtx(context.Background(), f.learningInput(a), min(f.beta*weight, 1))
+	return categoryActivation, categoryIndex
+}
@@ -0,0 +1,39 @@
+package art
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitWeighted(t *testing.T) {
+	fit := func(weight float64) []float64 {
+		model, err := NewFuzzyART(4, 0.5, 0.01, 0.2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer model.Close()
+		model.Fit([]float64{0.5, 0.5, 0.5, 0.5})
+		if _, j := model.FitWeighted([]float64{0.3, 0.3, 0.3, 0.3}, weight); j != 0 {
+			t.Fatalf("expected category 0 to learn, got %d", j)
+		}
+		return model.w[0]
+	}
+
+	// the first feature moves from 0.5 toward 0.3 with rate min(0.2 * weight, 1)
+	for weight, want := range map[float64]float64{0.5: 0.48, 1: 0.46, 2.5: 0.4, 10: 0.3} {
+		if got := fit(weight)[0]; math.Abs(got-want) > 1e-12 {
+			t.Errorf("weight %f: expected %f, got %f", weight, want, got)
+		}
+	}
+
+	for _, weight := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic with weight %f", weight)
+				}
+			}()
+			fit(weight)
+		}()
+	}
+}
@@ -0,0 +1,27 @@
+package art
+
+import "iter"
+
+// CategoryCount returns the number of categories of the model, the valid category indexes are 0 to CategoryCount()-1.
+func (f *FuzzyARTOf[T]) CategoryCount() int {
+	return len(f.w)
+}
+
+// Weights returns a copy of the complement-coded weights of category j, 2M values:
+// the lower bounds of the category hyper-rectangle followed by the complements of its upper bounds, see Prototype.
+func (f *FuzzyARTOf[T]) Weights(j int) []float64 {
+	return cloneFloat64s(f.w[j])
+}
+
+// AllWeights returns an iterator over the categories in index order, yielding the index
+// and a copy of the weights of every category, as returned by Weights.
+// The model must not be modified while iterating.
+func (f *FuzzyARTOf[T]) AllWeights() iter.Seq2[int, []float64] {
+	return func(yield func(int, []float64) bool) {
+		for j, w := range f.w {
+			if !yield(j, cloneFloat64s(w)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package art
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWeights(t *testing.T) {
+	model, err := NewFuzzyART(4, 0.9, 0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer model.Close()
+	model.Fit([]float64{0.1, 0.2, 0.3, 0.4})
+	model.Fit([]float64{0.9, 0.8, 0.7, 0.6})
+
+	if model.CategoryCount() != 2 {
+		t.Fatalf("expected 2 categories, got %d", model.CategoryCount())
+	}
+	w := model.Weights(1)
+	if !slices.Equal(w, model.complementCode([]float64{0.9, 0.8, 0.7, 0.6})) {
+		t.Errorf("unexpected weights %v", w)
+	}
+	w[0] = 0
+	if model.w[1][0] != 0.9 {
+		t.Error("expected a copy of the weights")
+	}
+
+	var seen []int
+	for j, w := range model.AllWeights() {
+		if !slices.Equal(w, model.w[j]) {
+			t.Errorf("category %d: expected weights %v, got %v", j, model.w[j], w)
+		}
+		seen = append(seen, j)
+		break
+	}
+	if !slices.Equal(seen, []int{0}) {
+		t.Errorf("expected the iteration to stop after category 0, got %v", seen)
+	}
+}